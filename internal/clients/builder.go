@@ -10,10 +10,12 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+	"github.com/hashicorp/terraform-provider-azuread/internal/features"
 )
 
 type ClientBuilder struct {
 	AuthConfig       *auth.Credentials
+	Features         features.UserFeatures
 	PartnerID        string
 	TerraformVersion string
 }
@@ -24,6 +26,7 @@ func (b *ClientBuilder) Build(ctx context.Context) (*Client, error) {
 	client := Client{
 		TenantID:         b.AuthConfig.TenantID,
 		ClientID:         b.AuthConfig.ClientID,
+		Features:         b.Features,
 		TerraformVersion: b.TerraformVersion,
 	}
 
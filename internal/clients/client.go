@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/claims"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+	"github.com/hashicorp/terraform-provider-azuread/internal/features"
 
 	administrativeunits "github.com/hashicorp/terraform-provider-azuread/internal/services/administrativeunits/client"
 	applications "github.com/hashicorp/terraform-provider-azuread/internal/services/applications/client"
@@ -44,6 +45,7 @@ type Client struct {
 	ObjectID    string
 	Claims      *claims.Claims
 
+	Features         features.UserFeatures
 	TerraformVersion string
 
 	StopContext context.Context
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package features
+
+// UserFeatures holds provider-level settings which alter the behaviour of resources
+type UserFeatures struct {
+	ConditionalAccess ConditionalAccessFeatures
+}
+
+type ConditionalAccessFeatures struct {
+	// RequiredExcludedUsers are the object IDs of users which must be excluded from any conditional access policy
+	// that includes all users, such as emergency access accounts
+	RequiredExcludedUsers []string
+
+	// RequiredExcludedGroups are the object IDs of groups which must be excluded from any conditional access policy
+	// that includes all users
+	RequiredExcludedGroups []string
+
+	// RejectOverlappingTrustedIPRanges causes planning to fail when the IP ranges of a trusted named location overlap
+	// those of another trusted named location managed by Terraform
+	RejectOverlappingTrustedIPRanges bool
+}
+
+// Default returns the default features, which do not enforce any additional behaviour
+func Default() UserFeatures {
+	return UserFeatures{
+		ConditionalAccess: ConditionalAccessFeatures{
+			RequiredExcludedUsers:  make([]string, 0),
+			RequiredExcludedGroups: make([]string, 0),
+		},
+	}
+}
@@ -3,9 +3,12 @@
 
 package credentials
 
+const KeyCredentialTypeAsymmetricX509Cert = "AsymmetricX509Cert"
+
 const (
-	KeyCredentialUsageSign   = "Sign"
-	KeyCredentialUsageVerify = "Verify"
+	KeyCredentialUsageEncrypt = "Encrypt"
+	KeyCredentialUsageSign    = "Sign"
+	KeyCredentialUsageVerify  = "Verify"
 )
 
 var PossibleValuesForKeyCredentialUsage = []string{KeyCredentialUsageSign, KeyCredentialUsageVerify}
@@ -5,15 +5,20 @@ package credentials
 
 import (
 	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha1"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/pem"
 	"fmt"
+	"math/big"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
 	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
 	"github.com/hashicorp/go-uuid"
@@ -56,6 +61,24 @@ func GetPasswordCredential(passwordCredentials *[]stable.PasswordCredential, id
 	return
 }
 
+// PasswordCredentialDisplayName returns the display name of a password credential, which for older credentials is held
+// in the base64 encoded custom key identifier. Returns nil when the credential has neither.
+func PasswordCredentialDisplayName(credential stable.PasswordCredential) (*string, error) {
+	if credential.DisplayName != nil {
+		return pointer.To(credential.DisplayName.GetOrZero()), nil
+	}
+
+	if customKeyIdentifier := credential.CustomKeyIdentifier.GetOrZero(); customKeyIdentifier != "" {
+		displayName, err := base64.StdEncoding.DecodeString(customKeyIdentifier)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CustomKeyIdentifier: %+v", err)
+		}
+		return pointer.To(string(displayName)), nil
+	}
+
+	return nil, nil
+}
+
 func GetTokenSigningCertificateThumbprint(certByte []byte) (string, error) {
 	block, _ := pem.Decode(certByte)
 	if block == nil {
@@ -74,47 +97,188 @@ func GetTokenSigningCertificateThumbprint(certByte []byte) (string, error) {
 	return buf.String(), nil
 }
 
-func KeyCredentialForResource(d *pluginsdk.ResourceData) (*stable.KeyCredential, error) {
-	keyType := d.Get("type").(string)
-	value := d.Get("value").(string)
-
-	var encodedValue string
-	encoding := d.Get("encoding").(string)
+// CertificatePem returns the PEM encoding of a certificate value having the specified encoding, which is one of `base64`,
+// `hex` or `pem`
+func CertificatePem(value, encoding string) ([]byte, error) {
+	var der []byte
 	switch encoding {
 	case "base64":
-		der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		var err error
+		der, err = base64.StdEncoding.DecodeString(strings.TrimSpace(value))
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode base64 certificate data")
 		}
-		block := pem.Block{
-			Type:  "CERTIFICATE",
-			Bytes: der,
-		}
-		pemVal := pem.EncodeToMemory(&block)
-		if pemVal == nil {
-			return nil, fmt.Errorf("failed to PEM-encode certificate")
-		}
-		encodedValue = base64.StdEncoding.EncodeToString(pemVal)
 	case "hex":
 		bytesVal := []byte(strings.TrimSpace(value))
-		der := make([]byte, hex.DecodedLen(len(bytesVal)))
-		_, err := hex.Decode(der, bytesVal)
-		if err != nil {
+		der = make([]byte, hex.DecodedLen(len(bytesVal)))
+		if _, err := hex.Decode(der, bytesVal); err != nil {
 			return nil, fmt.Errorf("failed to decode hexadecimal certificate data: %+v", err)
 		}
-		block := pem.Block{
-			Type:  "CERTIFICATE",
-			Bytes: der,
+	case "pem":
+		return []byte(value), nil
+	default:
+		return nil, fmt.Errorf("unsupported certificate encoding %q", encoding)
+	}
+
+	block := pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: der,
+	}
+	pemVal := pem.EncodeToMemory(&block)
+	if pemVal == nil {
+		return nil, fmt.Errorf("failed to PEM-encode certificate")
+	}
+
+	return pemVal, nil
+}
+
+// GenerateCertificate generates an RSA key pair of the specified size, and a self-signed certificate for the public key
+// which is valid between the specified dates. The certificate and private key are returned in PEM encoding.
+func GenerateCertificate(subject string, keySize int, startDate, endDate time.Time) (certificatePem []byte, privateKeyPem []byte, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating RSA key: %+v", err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating serial number: %+v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: strings.TrimPrefix(subject, "CN=")},
+		NotBefore:             startDate,
+		NotAfter:              endDate,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating certificate: %+v", err)
+	}
+
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshaling private key: %+v", err)
+	}
+
+	certificatePem = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	privateKeyPem = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})
+
+	return certificatePem, privateKeyPem, nil
+}
+
+// KeyCredentialFromCertificate returns an asymmetric verification key credential with a new key ID for the specified
+// PEM-encoded certificate, having a start and end date matching the validity period of the certificate
+func KeyCredentialFromCertificate(certificatePem []byte) (*stable.KeyCredential, error) {
+	block, _ := pem.Decode(certificatePem)
+	if block == nil {
+		return nil, fmt.Errorf("decoding certificate block")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate block data: %+v", err)
+	}
+
+	keyId, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &stable.KeyCredential{
+		KeyId:         nullable.Value(keyId),
+		Type:          nullable.Value(KeyCredentialTypeAsymmetricX509Cert),
+		Usage:         nullable.Value(KeyCredentialUsageVerify),
+		Key:           nullable.Value(base64.StdEncoding.EncodeToString(certificatePem)),
+		StartDateTime: nullable.Value(cert.NotBefore.UTC().Format(time.RFC3339)),
+		EndDateTime:   nullable.Value(cert.NotAfter.UTC().Format(time.RFC3339)),
+	}, nil
+}
+
+// RotationDate returns the date on which a credential expiring on the specified end date should be rotated
+func RotationDate(endDate string, rotateBeforeDays int) (string, error) {
+	expiry, err := time.Parse(time.RFC3339, endDate)
+	if err != nil {
+		return "", fmt.Errorf("parsing end date %q: %+v", endDate, err)
+	}
+	return expiry.AddDate(0, 0, -rotateBeforeDays).UTC().Format(time.RFC3339), nil
+}
+
+// DateHasPassed returns whether the specified RFC3339 date is in the past. Empty or invalid dates are never in the past.
+func DateHasPassed(date string) bool {
+	if date == "" {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return false
+	}
+	return time.Now().After(t)
+}
+
+// RotationDue returns whether a certificate credential for a resource supporting managed rotation is due to be rotated.
+// The rotation date is derived from the planned `rotate_before_days`, so that increasing it rotates the certificate
+// immediately when the new rotation date has already passed.
+func RotationDue(diff *pluginsdk.ResourceDiff) bool {
+	if endDate := diff.Get("end_date").(string); endDate != "" {
+		if rotationDate, err := RotationDate(endDate, diff.Get("rotation.0.rotate_before_days").(int)); err == nil {
+			return DateHasPassed(rotationDate)
+		}
+	}
+	return DateHasPassed(diff.Get("rotation_date").(string))
+}
+
+// RotationCustomizeDiff plans the rotation of a certificate credential for a resource supporting managed rotation. When
+// rotating, the current key becomes the previous key and all attributes describing the current key become unknown,
+// along with any additional keys specified. Otherwise, removal of the previous key is planned once its overlap period
+// has ended, and the rotation date is updated to reflect the configured `rotate_before_days`.
+func RotationCustomizeDiff(diff *pluginsdk.ResourceDiff, rotate bool, rotatedKeys ...string) error {
+	if rotate {
+		for _, key := range append([]string{"end_date", "key_id", "previous_key_id", "previous_key_removal_date", "rotation_date", "start_date"}, rotatedKeys...) {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if DateHasPassed(diff.Get("previous_key_removal_date").(string)) {
+		if err := diff.SetNew("previous_key_id", ""); err != nil {
+			return err
 		}
-		pemVal := pem.EncodeToMemory(&block)
-		if pemVal == nil {
-			return nil, fmt.Errorf("failed to PEM-encode certificate")
+		if err := diff.SetNew("previous_key_removal_date", ""); err != nil {
+			return err
 		}
-		encodedValue = base64.StdEncoding.EncodeToString(pemVal)
-	case "pem":
-		encodedValue = base64.StdEncoding.EncodeToString([]byte(value))
 	}
 
+	if endDate := diff.Get("end_date").(string); endDate != "" {
+		rotationDate, err := RotationDate(endDate, diff.Get("rotation.0.rotate_before_days").(int))
+		if err != nil {
+			return err
+		}
+		if rotationDate != diff.Get("rotation_date").(string) {
+			if err = diff.SetNew("rotation_date", rotationDate); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func KeyCredentialForResource(d *pluginsdk.ResourceData) (*stable.KeyCredential, error) {
+	keyType := d.Get("type").(string)
+	value := d.Get("value").(string)
+
+	pemValue, err := CertificatePem(value, d.Get("encoding").(string))
+	if err != nil {
+		return nil, err
+	}
+	encodedValue := base64.StdEncoding.EncodeToString(pemValue)
+
 	var keyId string
 	if v, ok := d.GetOk("key_id"); ok {
 		keyId = v.(string)
@@ -0,0 +1,536 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package credentials
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+)
+
+func TestRotationDate(t *testing.T) {
+	cases := []struct {
+		EndDate          string
+		RotateBeforeDays int
+		Expected         string
+		Error            bool
+	}{
+		{
+			EndDate:          "2025-03-31T00:00:00Z",
+			RotateBeforeDays: 30,
+			Expected:         "2025-03-01T00:00:00Z",
+		},
+		{
+			EndDate:          "2025-01-15T12:30:00+02:00",
+			RotateBeforeDays: 0,
+			Expected:         "2025-01-15T10:30:00Z",
+		},
+		{
+			EndDate:          "2024-03-10T00:00:00Z",
+			RotateBeforeDays: 10,
+			Expected:         "2024-02-29T00:00:00Z",
+		},
+		{
+			EndDate: "2025-03-31",
+			Error:   true,
+		},
+		{
+			EndDate: "",
+			Error:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q with rotate_before_days %d", tc.EndDate, tc.RotateBeforeDays)
+
+		result, err := RotationDate(tc.EndDate, tc.RotateBeforeDays)
+		if tc.Error {
+			if err == nil {
+				t.Fatalf("expected an error for %q", tc.EndDate)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result != tc.Expected {
+			t.Fatalf("expected rotation date %q, got %q", tc.Expected, result)
+		}
+	}
+}
+
+func TestDateHasPassed(t *testing.T) {
+	cases := []struct {
+		Date     string
+		Expected bool
+	}{
+		{
+			Date:     time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+			Expected: true,
+		},
+		{
+			Date:     time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			Expected: false,
+		},
+		{
+			Date:     "",
+			Expected: false,
+		},
+		{
+			Date:     "not a date",
+			Expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Date)
+
+		if actual := DateHasPassed(tc.Date); actual != tc.Expected {
+			t.Fatalf("expected %t for %q, got %t", tc.Expected, tc.Date, actual)
+		}
+	}
+}
+
+func TestRotationCustomizeDiff(t *testing.T) {
+	past := time.Now().AddDate(0, 0, -1).UTC().Format(time.RFC3339)
+	future := time.Now().AddDate(0, 0, 1).UTC().Format(time.RFC3339)
+
+	state := map[string]string{
+		"id":                            "current",
+		"certificate_pem":               "pem",
+		"end_date":                      "2025-03-31T00:00:00Z",
+		"key_id":                        "current",
+		"previous_key_id":               "previous",
+		"previous_key_removal_date":     future,
+		"rotation.#":                    "1",
+		"rotation.0.rotate_before_days": "30",
+		"rotation_date":                 "2025-03-01T00:00:00Z",
+		"start_date":                    "2024-03-31T00:00:00Z",
+	}
+
+	cases := []struct {
+		TestName         string
+		Rotate           bool
+		RotateBeforeDays int
+		State            map[string]string
+		ExpectedComputed []string
+		ExpectedNew      map[string]string
+	}{
+		{
+			TestName:         "Unchanged",
+			RotateBeforeDays: 30,
+			State:            state,
+		},
+		{
+			TestName:         "Rotate",
+			Rotate:           true,
+			RotateBeforeDays: 30,
+			State:            state,
+			ExpectedComputed: []string{"certificate_pem", "end_date", "key_id", "previous_key_id", "previous_key_removal_date", "rotation_date", "start_date"},
+		},
+		{
+			TestName:         "PreviousKeyRemoved",
+			RotateBeforeDays: 30,
+			State:            withAttributes(state, map[string]string{"previous_key_removal_date": past}),
+			ExpectedNew: map[string]string{
+				"previous_key_id":           "",
+				"previous_key_removal_date": "",
+			},
+		},
+		{
+			TestName:         "RotateBeforeDaysChanged",
+			RotateBeforeDays: 7,
+			State:            state,
+			ExpectedNew: map[string]string{
+				"rotation.0.rotate_before_days": "7",
+				"rotation_date":                 "2025-03-24T00:00:00Z",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			resource := rotationTestResource(tc.Rotate)
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"rotation": []interface{}{
+					map[string]interface{}{
+						"rotate_before_days": tc.RotateBeforeDays,
+					},
+				},
+			})
+
+			diff, err := resource.Diff(context.Background(), &terraform.InstanceState{ID: tc.State["id"], Attributes: tc.State}, config, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(tc.ExpectedComputed) == 0 && len(tc.ExpectedNew) == 0 {
+				if diff != nil && !diff.Empty() {
+					t.Fatalf("expected no diff, got: %#v", diff.Attributes)
+				}
+				return
+			}
+			if diff == nil {
+				t.Fatalf("expected a diff, got nil")
+			}
+
+			for _, key := range tc.ExpectedComputed {
+				if attr, ok := diff.Attributes[key]; !ok || !attr.NewComputed {
+					t.Fatalf("expected %q to be computed", key)
+				}
+			}
+			for key, expected := range tc.ExpectedNew {
+				attr, ok := diff.Attributes[key]
+				if !ok {
+					t.Fatalf("expected a diff for %q", key)
+				}
+				if attr.New != expected {
+					t.Fatalf("expected new value %q for %q, got %q", expected, key, attr.New)
+				}
+			}
+			for key, attr := range diff.Attributes {
+				if _, ok := tc.ExpectedNew[key]; !ok && !attr.NewComputed {
+					t.Fatalf("unexpected diff for %q: %#v", key, attr)
+				}
+			}
+		})
+	}
+}
+
+func TestRotationDue(t *testing.T) {
+	endDate := time.Now().AddDate(0, 0, 90).UTC().Format(time.RFC3339)
+
+	cases := []struct {
+		RotateBeforeDays int
+		EndDate          string
+		RotationDate     string
+		Expected         bool
+	}{
+		{
+			RotateBeforeDays: 30,
+			EndDate:          endDate,
+			Expected:         false,
+		},
+		{
+			RotateBeforeDays: 120,
+			EndDate:          endDate,
+			Expected:         true,
+		},
+		{
+			RotateBeforeDays: 30,
+			RotationDate:     time.Now().AddDate(0, 0, -1).UTC().Format(time.RFC3339),
+			Expected:         true,
+		},
+		{
+			RotateBeforeDays: 30,
+			Expected:         false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing end date %q with rotate_before_days %d", tc.EndDate, tc.RotateBeforeDays)
+
+		var actual bool
+		resource := rotationTestResource(false)
+		resource.CustomizeDiff = func(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+			actual = RotationDue(diff)
+			return nil
+		}
+
+		state := &terraform.InstanceState{
+			ID: "current",
+			Attributes: map[string]string{
+				"id":                            "current",
+				"end_date":                      tc.EndDate,
+				"rotation.#":                    "1",
+				"rotation.0.rotate_before_days": "30",
+				"rotation_date":                 tc.RotationDate,
+			},
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"rotation": []interface{}{
+				map[string]interface{}{
+					"rotate_before_days": tc.RotateBeforeDays,
+				},
+			},
+		})
+
+		if _, err := resource.Diff(context.Background(), state, config, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual != tc.Expected {
+			t.Fatalf("expected %t, got %t", tc.Expected, actual)
+		}
+	}
+}
+
+func TestGenerateCertificate(t *testing.T) {
+	startDate := time.Now().UTC().Truncate(time.Second)
+	endDate := startDate.AddDate(0, 0, 90)
+
+	certificatePem, privateKeyPem, err := GenerateCertificate("CN=acctest", 2048, startDate, endDate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	block, _ := pem.Decode(certificatePem)
+	if block == nil || block.Type != "CERTIFICATE" {
+		t.Fatalf("expected a PEM-encoded certificate, got: %q", certificatePem)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+
+	if cert.Subject.CommonName != "acctest" {
+		t.Fatalf("expected common name %q, got %q", "acctest", cert.Subject.CommonName)
+	}
+	if !cert.NotBefore.Equal(startDate) || !cert.NotAfter.Equal(endDate) {
+		t.Fatalf("expected validity %s to %s, got %s to %s", startDate, endDate, cert.NotBefore, cert.NotAfter)
+	}
+
+	keyBlock, _ := pem.Decode(privateKeyPem)
+	if keyBlock == nil || keyBlock.Type != "PRIVATE KEY" {
+		t.Fatalf("expected a PEM-encoded private key, got: %q", privateKeyPem)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		t.Fatalf("parsing private key: %v", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		t.Fatalf("expected an RSA private key, got %T", key)
+	}
+	if !rsaKey.PublicKey.Equal(cert.PublicKey) {
+		t.Fatalf("expected private key to match certificate public key")
+	}
+
+	if _, _, err = GenerateCertificate("CN=acctest", 0, startDate, endDate); err == nil {
+		t.Fatalf("expected an error for an invalid key size")
+	}
+}
+
+func TestKeyCredentialFromCertificate(t *testing.T) {
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(1, 0, 0)
+
+	certificatePem, _, err := GenerateCertificate("CN=acctest", 2048, startDate, endDate)
+	if err != nil {
+		t.Fatalf("generating certificate: %v", err)
+	}
+
+	credential, err := KeyCredentialFromCertificate(certificatePem)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if credential.KeyId.GetOrZero() == "" {
+		t.Fatalf("expected a key ID to be generated")
+	}
+	if v := credential.Type.GetOrZero(); v != KeyCredentialTypeAsymmetricX509Cert {
+		t.Fatalf("expected type %q, got %q", KeyCredentialTypeAsymmetricX509Cert, v)
+	}
+	if v := credential.Usage.GetOrZero(); v != KeyCredentialUsageVerify {
+		t.Fatalf("expected usage %q, got %q", KeyCredentialUsageVerify, v)
+	}
+	if v := credential.StartDateTime.GetOrZero(); v != "2024-01-01T00:00:00Z" {
+		t.Fatalf("expected start date %q, got %q", "2024-01-01T00:00:00Z", v)
+	}
+	if v := credential.EndDateTime.GetOrZero(); v != "2025-01-01T00:00:00Z" {
+		t.Fatalf("expected end date %q, got %q", "2025-01-01T00:00:00Z", v)
+	}
+	if v := credential.Key.GetOrZero(); v != base64.StdEncoding.EncodeToString(certificatePem) {
+		t.Fatalf("expected key to contain the base64-encoded certificate")
+	}
+
+	another, err := KeyCredentialFromCertificate(certificatePem)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if another.KeyId.GetOrZero() == credential.KeyId.GetOrZero() {
+		t.Fatalf("expected a new key ID for each credential")
+	}
+
+	if _, err = KeyCredentialFromCertificate([]byte("not a certificate")); err == nil {
+		t.Fatalf("expected an error for an invalid certificate")
+	}
+}
+
+func TestCertificatePem(t *testing.T) {
+	certificatePem, _, err := GenerateCertificate("CN=acctest", 2048, time.Now(), time.Now().AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("generating certificate: %v", err)
+	}
+	block, _ := pem.Decode(certificatePem)
+
+	cases := []struct {
+		Value    string
+		Encoding string
+		Error    bool
+	}{
+		{
+			Value:    string(certificatePem),
+			Encoding: "pem",
+		},
+		{
+			Value:    base64.StdEncoding.EncodeToString(block.Bytes),
+			Encoding: "base64",
+		},
+		{
+			Value:    base64.StdEncoding.EncodeToString(block.Bytes) + "\n",
+			Encoding: "base64",
+		},
+		{
+			Value:    hex.EncodeToString(block.Bytes),
+			Encoding: "hex",
+		},
+		{
+			Value:    strings.ToUpper(hex.EncodeToString(block.Bytes)),
+			Encoding: "hex",
+		},
+		{
+			Value:    "not base64!",
+			Encoding: "base64",
+			Error:    true,
+		},
+		{
+			Value:    "not hex",
+			Encoding: "hex",
+			Error:    true,
+		},
+		{
+			Value:    string(certificatePem),
+			Encoding: "der",
+			Error:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %s encoding", tc.Encoding)
+
+		result, err := CertificatePem(tc.Value, tc.Encoding)
+		if tc.Error {
+			if err == nil {
+				t.Fatalf("expected an error for %s encoding", tc.Encoding)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(result) != string(certificatePem) {
+			t.Fatalf("expected PEM-encoded certificate\nexpected: %q\nactual:   %q", certificatePem, result)
+		}
+	}
+}
+
+// rotationTestResource returns a resource having the attributes used by RotationCustomizeDiff, which always or never
+// plans rotation
+func rotationTestResource(rotate bool) *pluginsdk.Resource {
+	computed := func() *pluginsdk.Schema {
+		return &pluginsdk.Schema{
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		}
+	}
+
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"certificate_pem":           computed(),
+			"end_date":                  computed(),
+			"key_id":                    computed(),
+			"previous_key_id":           computed(),
+			"previous_key_removal_date": computed(),
+			"rotation_date":             computed(),
+			"start_date":                computed(),
+
+			"rotation": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"rotate_before_days": {
+							Type:     pluginsdk.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+
+		CustomizeDiff: func(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+			return RotationCustomizeDiff(diff, rotate, "certificate_pem")
+		},
+	}
+}
+
+func withAttributes(in map[string]string, attributes map[string]string) map[string]string {
+	result := make(map[string]string, len(in))
+	for k, v := range in {
+		result[k] = v
+	}
+	for k, v := range attributes {
+		result[k] = v
+	}
+	return result
+}
+
+func TestPasswordCredentialDisplayName(t *testing.T) {
+	cases := []struct {
+		Credential stable.PasswordCredential
+		Expected   *string
+		Error      bool
+	}{
+		{
+			Credential: stable.PasswordCredential{},
+		},
+		{
+			Credential: stable.PasswordCredential{DisplayName: nullable.Value("rotated")},
+			Expected:   pointer.To("rotated"),
+		},
+		{
+			Credential: stable.PasswordCredential{CustomKeyIdentifier: nullable.Value(base64.StdEncoding.EncodeToString([]byte("legacy")))},
+			Expected:   pointer.To("legacy"),
+		},
+		{
+			Credential: stable.PasswordCredential{DisplayName: nullable.Value("rotated"), CustomKeyIdentifier: nullable.Value(base64.StdEncoding.EncodeToString([]byte("legacy")))},
+			Expected:   pointer.To("rotated"),
+		},
+		{
+			Credential: stable.PasswordCredential{CustomKeyIdentifier: nullable.Value("not base64!")},
+			Error:      true,
+		},
+	}
+
+	for i, tc := range cases {
+		actual, err := PasswordCredentialDisplayName(tc.Credential)
+		if tc.Error {
+			if err == nil {
+				t.Fatalf("case %d: expected an error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %d: unexpected error: %v", i, err)
+		}
+		if (actual == nil) != (tc.Expected == nil) || (actual != nil && *actual != *tc.Expected) {
+			t.Fatalf("case %d: expected %v, got %v", i, pointer.From(tc.Expected), pointer.From(actual))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iso8601
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var durationRegex = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseDuration parses an ISO8601 duration string (e.g. `P90D` or `PT4H30M`) into a time.Duration. Years and months
+// have no fixed length, so they are approximated as 365 and 30 days respectively, which is consistent with how
+// Microsoft Graph evaluates credential lifetimes.
+func ParseDuration(input string) (time.Duration, error) {
+	matches := durationRegex.FindStringSubmatch(input)
+	if matches == nil || input == "P" || input[len(input)-1] == 'T' {
+		return 0, fmt.Errorf("%q is not a valid ISO8601 duration", input)
+	}
+
+	units := []time.Duration{
+		365 * 24 * time.Hour,
+		30 * 24 * time.Hour,
+		7 * 24 * time.Hour,
+		24 * time.Hour,
+		time.Hour,
+		time.Minute,
+		time.Second,
+	}
+
+	var result time.Duration
+	for i, unit := range units {
+		v := matches[i+1]
+		if v == "" {
+			continue
+		}
+
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parsing component %q of duration %q: %+v", v, input, err)
+		}
+
+		result += time.Duration(f * float64(unit))
+	}
+
+	return result, nil
+}
+
+// ValidateDuration checks that the provided value is a valid ISO8601 duration string
+func ValidateDuration(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if _, err := ParseDuration(v); err != nil {
+		errors = append(errors, fmt.Errorf("expected %q to be a valid ISO8601 duration (e.g. P90D or PT12H), got %q", k, v))
+	}
+
+	return
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iso8601
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected time.Duration
+		Error    bool
+	}{
+		{
+			Input:    "P90D",
+			Expected: 90 * 24 * time.Hour,
+		},
+		{
+			Input:    "PT4H30M",
+			Expected: 4*time.Hour + 30*time.Minute,
+		},
+		{
+			Input:    "P4DT12H30M5S",
+			Expected: 4*24*time.Hour + 12*time.Hour + 30*time.Minute + 5*time.Second,
+		},
+		{
+			Input:    "P1Y",
+			Expected: 365 * 24 * time.Hour,
+		},
+		{
+			Input:    "P2W",
+			Expected: 14 * 24 * time.Hour,
+		},
+		{
+			Input:    "PT1.5S",
+			Expected: 1500 * time.Millisecond,
+		},
+		{
+			Input: "",
+			Error: true,
+		},
+		{
+			Input: "P",
+			Error: true,
+		},
+		{
+			Input: "P1DT",
+			Error: true,
+		},
+		{
+			Input: "90D",
+			Error: true,
+		},
+		{
+			Input: "P1H",
+			Error: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Input, func(t *testing.T) {
+			result, err := ParseDuration(tc.Input)
+			if tc.Error {
+				if err == nil {
+					t.Fatalf("expected an error parsing %q, got none", tc.Input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %v", tc.Input, err)
+			}
+			if result != tc.Expected {
+				t.Fatalf("expected %q to parse as %s, got %s", tc.Input, tc.Expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package requestschedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/iso8601"
+)
+
+// Schedule describes when a privileged assignment or eligibility starts, and when it expires. Dates are formatted as
+// RFC3339 strings and durations as ISO8601 duration strings.
+type Schedule struct {
+	StartDate      string
+	ExpirationDate string
+	Duration       string
+
+	// ExpirationType is optional, and is inferred from ExpirationDate or Duration when not specified
+	ExpirationType string
+}
+
+// Expand builds a RequestSchedule for a schedule request. When expirationDateChanged is true, the expiration date must
+// be at least 5 minutes in the future.
+func Expand(in Schedule, expirationDateChanged bool) (*stable.RequestSchedule, error) {
+	schedule := stable.RequestSchedule{
+		Expiration:    &stable.ExpirationPattern{},
+		StartDateTime: nullable.NoZero(in.StartDate),
+	}
+	var startDate, expiryDate time.Time
+
+	if in.StartDate != "" {
+		var err error
+		startDate, err = time.Parse(time.RFC3339, in.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %+v", in.StartDate, err)
+		}
+	}
+
+	var expirationType stable.ExpirationPatternType
+
+	switch {
+	case in.ExpirationDate != "":
+		var err error
+		expiryDate, err = time.Parse(time.RFC3339, in.ExpirationDate)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %+v", in.ExpirationDate, err)
+		}
+
+		if in.StartDate != "" && expiryDate.Before(startDate.Add(5*time.Minute)) {
+			return nil, fmt.Errorf("`expiration_date` must be at least 5 minutes after `start_date`")
+		}
+
+		if expirationDateChanged && expiryDate.Before(time.Now().Add(5*time.Minute)) {
+			return nil, fmt.Errorf("`expiration_date` must be at least 5 minutes in the future")
+		}
+
+		expirationType = stable.ExpirationPatternType_AfterDateTime
+		schedule.Expiration.EndDateTime = nullable.Value(in.ExpirationDate)
+
+	case in.Duration != "":
+		if _, err := iso8601.ParseDuration(in.Duration); err != nil {
+			return nil, fmt.Errorf("parsing %s: %+v", in.Duration, err)
+		}
+
+		expirationType = stable.ExpirationPatternType_AfterDuration
+		schedule.Expiration.Duration = nullable.Value(in.Duration)
+
+	case in.ExpirationType == string(stable.ExpirationPatternType_NoExpiration):
+		expirationType = stable.ExpirationPatternType_NoExpiration
+
+	default:
+		return nil, fmt.Errorf("either `expiration_date` or `duration` must be set, or the schedule must not expire")
+	}
+
+	if in.ExpirationType != "" && in.ExpirationType != string(expirationType) {
+		return nil, fmt.Errorf("the expiration type %q is not valid for this schedule, expected %q", in.ExpirationType, expirationType)
+	}
+
+	schedule.Expiration.Type = pointer.To(expirationType)
+
+	return &schedule, nil
+}
+
+// Flatten returns the Schedule represented by a RequestSchedule
+func Flatten(in *stable.RequestSchedule) Schedule {
+	if in == nil {
+		return Schedule{}
+	}
+
+	result := Schedule{
+		StartDate: in.StartDateTime.GetOrZero(),
+	}
+
+	if expiration := in.Expiration; expiration != nil {
+		result.Duration = expiration.Duration.GetOrZero()
+		result.ExpirationDate = expiration.EndDateTime.GetOrZero()
+		if expiration.Type != nil {
+			result.ExpirationType = string(*expiration.Type)
+		}
+	}
+
+	return result
+}
+
+// EndDate returns the time at which a RequestSchedule expires, or nil when it does not expire or the expiry cannot be
+// determined.
+func EndDate(in *stable.RequestSchedule) (*time.Time, error) {
+	if in == nil || in.Expiration == nil {
+		return nil, nil
+	}
+
+	if endDateTime := in.Expiration.EndDateTime.GetOrZero(); endDateTime != "" {
+		t, err := time.Parse(time.RFC3339, endDateTime)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %+v", endDateTime, err)
+		}
+		return &t, nil
+	}
+
+	startDateTime := in.StartDateTime.GetOrZero()
+	duration := in.Expiration.Duration.GetOrZero()
+	if startDateTime == "" || duration == "" {
+		return nil, nil
+	}
+
+	start, err := time.Parse(time.RFC3339, startDateTime)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %+v", startDateTime, err)
+	}
+
+	d, err := iso8601.ParseDuration(duration)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %+v", duration, err)
+	}
+
+	return pointer.To(start.Add(d)), nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package requestschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+func TestExpand(t *testing.T) {
+	future := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+
+	cases := []struct {
+		Input        Schedule
+		ExpectedType stable.ExpirationPatternType
+		Error        bool
+	}{
+		{
+			Input:        Schedule{StartDate: "2024-01-01T00:00:00Z", Duration: "P90D"},
+			ExpectedType: stable.ExpirationPatternType_AfterDuration,
+		},
+		{
+			Input:        Schedule{ExpirationDate: future},
+			ExpectedType: stable.ExpirationPatternType_AfterDateTime,
+		},
+		{
+			Input:        Schedule{ExpirationType: "noExpiration"},
+			ExpectedType: stable.ExpirationPatternType_NoExpiration,
+		},
+		{
+			Input: Schedule{},
+			Error: true,
+		},
+		{
+			Input: Schedule{Duration: "P90D", ExpirationType: "noExpiration"},
+			Error: true,
+		},
+		{
+			Input: Schedule{Duration: "90 days"},
+			Error: true,
+		},
+		{
+			Input: Schedule{StartDate: "2024-01-01T00:00:00Z", ExpirationDate: "2024-01-01T00:01:00Z"},
+			Error: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %+v", tc.Input)
+
+		result, err := Expand(tc.Input, false)
+		if tc.Error {
+			if err == nil {
+				t.Fatalf("expected an error for %+v", tc.Input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if actual := pointer.From(result.Expiration.Type); actual != tc.ExpectedType {
+			t.Fatalf("expected expiration type %q, got %q", tc.ExpectedType, actual)
+		}
+		if actual := result.StartDateTime.GetOrZero(); actual != tc.Input.StartDate {
+			t.Fatalf("expected start date %q, got %q", tc.Input.StartDate, actual)
+		}
+	}
+
+	if _, err := Expand(Schedule{ExpirationDate: "2020-01-01T00:00:00Z"}, true); err == nil {
+		t.Fatalf("expected an error for a changed expiration date in the past")
+	}
+}
+
+func TestEndDate(t *testing.T) {
+	cases := []struct {
+		Input    *stable.RequestSchedule
+		Expected string
+	}{
+		{
+			Input:    nil,
+			Expected: "",
+		},
+		{
+			Input: &stable.RequestSchedule{
+				Expiration: &stable.ExpirationPattern{Type: pointer.To(stable.ExpirationPatternType_NoExpiration)},
+			},
+			Expected: "",
+		},
+		{
+			Input: &stable.RequestSchedule{
+				StartDateTime: nullable.Value("2024-01-01T00:00:00Z"),
+				Expiration:    &stable.ExpirationPattern{EndDateTime: nullable.Value("2024-06-01T12:00:00Z")},
+			},
+			Expected: "2024-06-01T12:00:00Z",
+		},
+		{
+			Input: &stable.RequestSchedule{
+				StartDateTime: nullable.Value("2024-01-01T00:00:00Z"),
+				Expiration:    &stable.ExpirationPattern{Duration: nullable.Value("P10DT6H")},
+			},
+			Expected: "2024-01-11T06:00:00Z",
+		},
+	}
+
+	for _, tc := range cases {
+		result, err := EndDate(tc.Input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		actual := ""
+		if result != nil {
+			actual = result.UTC().Format(time.RFC3339)
+		}
+		if actual != tc.Expected {
+			t.Fatalf("expected end date %q, got %q", tc.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rolemanagementrules
+
+// Rules holds the configurable rules of a role management policy, as they are represented in Terraform state. These
+// are shared by all role management policy resources, regardless of the scope to which the policy applies.
+type Rules struct {
+	ActiveAssignmentRules   []ActiveAssignmentRules
+	EligibleAssignmentRules []EligibleAssignmentRules
+	ActivationRules         []ActivationRules
+	NotificationRules       []NotificationEvents
+}
+
+type ActiveAssignmentRules struct {
+	ExpirationRequired     bool   `tfschema:"expiration_required"`
+	ExpireAfter            string `tfschema:"expire_after"`
+	RequireJustification   bool   `tfschema:"require_justification"`
+	RequireMultiFactorAuth bool   `tfschema:"require_multifactor_authentication"`
+	RequireTicketInfo      bool   `tfschema:"require_ticket_info"`
+}
+
+type EligibleAssignmentRules struct {
+	ExpirationRequired bool   `tfschema:"expiration_required"`
+	ExpireAfter        string `tfschema:"expire_after"`
+}
+
+type ActivationRules struct {
+	ApprovalStages                  []ApprovalStage `tfschema:"approval_stage"`
+	MaximumDuration                 string          `tfschema:"maximum_duration"`
+	RequireApproval                 bool            `tfschema:"require_approval"`
+	RequireConditionalAccessContext string          `tfschema:"required_conditional_access_authentication_context"`
+	RequireJustification            bool            `tfschema:"require_justification"`
+	RequireMultiFactorAuth          bool            `tfschema:"require_multifactor_authentication"`
+	RequireTicketInfo               bool            `tfschema:"require_ticket_info"`
+}
+
+type ApprovalStage struct {
+	PrimaryApprovers []Approver `tfschema:"primary_approver"`
+}
+
+type Approver struct {
+	ID   string `tfschema:"object_id"`
+	Type string `tfschema:"type"`
+}
+
+type NotificationEvents struct {
+	ActiveAssignments   []NotificationRule `tfschema:"active_assignments"`
+	EligibleActivations []NotificationRule `tfschema:"eligible_activations"`
+	EligibleAssignments []NotificationRule `tfschema:"eligible_assignments"`
+}
+
+type NotificationRule struct {
+	AdminNotifications    []NotificationSettings `tfschema:"admin_notifications"`
+	ApproverNotifications []NotificationSettings `tfschema:"approver_notifications"`
+	AssigneeNotifications []NotificationSettings `tfschema:"assignee_notifications"`
+}
+
+type NotificationSettings struct {
+	AdditionalRecipients []string `tfschema:"additional_recipients"`
+	DefaultRecipients    bool     `tfschema:"default_recipients"`
+	NotificationLevel    string   `tfschema:"notification_level"`
+}
@@ -0,0 +1,416 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rolemanagementrules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+// ChangeDetector is satisfied by *pluginsdk.ResourceData, and is used to determine which rules need to be sent to the API
+type ChangeDetector interface {
+	HasChange(key string) bool
+}
+
+// FlattenRules populates the provided Rules with the values of the corresponding rules from a role management policy.
+// Any rule blocks which are not yet present are initialised, so that all rules are always reflected in state.
+func FlattenRules(policyRules *[]stable.UnifiedRoleManagementPolicyRule, rules *Rules) error {
+	if len(rules.EligibleAssignmentRules) == 0 {
+		rules.EligibleAssignmentRules = make([]EligibleAssignmentRules, 1)
+	}
+	if len(rules.ActiveAssignmentRules) == 0 {
+		rules.ActiveAssignmentRules = make([]ActiveAssignmentRules, 1)
+	}
+	if len(rules.ActivationRules) == 0 {
+		rules.ActivationRules = make([]ActivationRules, 1)
+	}
+	if len(rules.NotificationRules) == 0 {
+		rules.NotificationRules = make([]NotificationEvents, 1)
+	}
+	if len(rules.NotificationRules[0].EligibleActivations) == 0 {
+		rules.NotificationRules[0].EligibleActivations = make([]NotificationRule, 1)
+	}
+	if len(rules.NotificationRules[0].ActiveAssignments) == 0 {
+		rules.NotificationRules[0].ActiveAssignments = make([]NotificationRule, 1)
+	}
+	if len(rules.NotificationRules[0].EligibleAssignments) == 0 {
+		rules.NotificationRules[0].EligibleAssignments = make([]NotificationRule, 1)
+	}
+
+	for _, rule := range pointer.From(policyRules) {
+		switch pointer.From(rule.UnifiedRoleManagementPolicyRule().Id) {
+		case "Approval_EndUser_Assignment":
+			approvalRule, ok := rule.(stable.UnifiedRoleManagementPolicyApprovalRule)
+			if !ok || approvalRule.Setting == nil {
+				continue
+			}
+
+			rules.ActivationRules[0].RequireApproval = approvalRule.Setting.IsApprovalRequired.GetOrZero()
+			primaryApprovers := make([]Approver, 0)
+
+			if stages := approvalRule.Setting.ApprovalStages; stages != nil && len(*stages) > 0 {
+				for _, approver := range pointer.From((*stages)[0].PrimaryApprovers) {
+					switch pointer.From(approver.SubjectSet().ODataType) {
+					case "#microsoft.graph.singleUser":
+						primaryApprovers = append(primaryApprovers, Approver{
+							ID:   approver.(stable.SingleUser).UserId.GetOrZero(),
+							Type: "singleUser",
+						})
+					case "#microsoft.graph.groupMembers":
+						primaryApprovers = append(primaryApprovers, Approver{
+							ID:   approver.(stable.GroupMembers).GroupId.GetOrZero(),
+							Type: "groupMembers",
+						})
+					default:
+						return fmt.Errorf("unknown approver type: %s", pointer.From(approver.SubjectSet().ODataType))
+					}
+				}
+			}
+
+			rules.ActivationRules[0].ApprovalStages = []ApprovalStage{{PrimaryApprovers: primaryApprovers}}
+
+		case "AuthenticationContext_EndUser_Assignment":
+			if contextRule, ok := rule.(stable.UnifiedRoleManagementPolicyAuthenticationContextRule); ok && contextRule.ClaimValue.GetOrZero() != "" {
+				rules.ActivationRules[0].RequireConditionalAccessContext = contextRule.ClaimValue.GetOrZero()
+			}
+
+		case "Enablement_Admin_Assignment":
+			rules.ActiveAssignmentRules[0].RequireMultiFactorAuth = false
+			rules.ActiveAssignmentRules[0].RequireJustification = false
+
+			if enablementRule, ok := rule.(stable.UnifiedRoleManagementPolicyEnablementRule); ok {
+				for _, enabledRule := range pointer.From(enablementRule.EnabledRules) {
+					switch enabledRule {
+					case "MultiFactorAuthentication":
+						rules.ActiveAssignmentRules[0].RequireMultiFactorAuth = true
+					case "Justification":
+						rules.ActiveAssignmentRules[0].RequireJustification = true
+					}
+				}
+			}
+
+		case "Enablement_EndUser_Assignment":
+			rules.ActivationRules[0].RequireMultiFactorAuth = false
+			rules.ActivationRules[0].RequireJustification = false
+			rules.ActivationRules[0].RequireTicketInfo = false
+
+			if enablementRule, ok := rule.(stable.UnifiedRoleManagementPolicyEnablementRule); ok {
+				for _, enabledRule := range pointer.From(enablementRule.EnabledRules) {
+					switch enabledRule {
+					case "MultiFactorAuthentication":
+						rules.ActivationRules[0].RequireMultiFactorAuth = true
+					case "Justification":
+						rules.ActivationRules[0].RequireJustification = true
+					case "Ticketing":
+						rules.ActivationRules[0].RequireTicketInfo = true
+					}
+				}
+			}
+
+		case "Expiration_Admin_Eligibility":
+			if expirationRule, ok := rule.(stable.UnifiedRoleManagementPolicyExpirationRule); ok {
+				rules.EligibleAssignmentRules[0].ExpirationRequired = expirationRule.IsExpirationRequired.GetOrZero()
+				rules.EligibleAssignmentRules[0].ExpireAfter = expirationRule.MaximumDuration.GetOrZero()
+			}
+
+		case "Expiration_Admin_Assignment":
+			if expirationRule, ok := rule.(stable.UnifiedRoleManagementPolicyExpirationRule); ok {
+				rules.ActiveAssignmentRules[0].ExpirationRequired = expirationRule.IsExpirationRequired.GetOrZero()
+				rules.ActiveAssignmentRules[0].ExpireAfter = expirationRule.MaximumDuration.GetOrZero()
+			}
+
+		case "Expiration_EndUser_Assignment":
+			if expirationRule, ok := rule.(stable.UnifiedRoleManagementPolicyExpirationRule); ok {
+				rules.ActivationRules[0].MaximumDuration = expirationRule.MaximumDuration.GetOrZero()
+			}
+
+		case "Notification_Admin_Admin_Assignment":
+			rules.NotificationRules[0].ActiveAssignments[0].AdminNotifications = flattenNotificationSettings(rule)
+
+		case "Notification_Admin_Admin_Eligibility":
+			rules.NotificationRules[0].EligibleAssignments[0].AdminNotifications = flattenNotificationSettings(rule)
+
+		case "Notification_Admin_EndUser_Assignment":
+			rules.NotificationRules[0].EligibleActivations[0].AdminNotifications = flattenNotificationSettings(rule)
+
+		case "Notification_Approver_Admin_Assignment":
+			rules.NotificationRules[0].ActiveAssignments[0].ApproverNotifications = flattenNotificationSettings(rule)
+
+		case "Notification_Approver_Admin_Eligibility":
+			rules.NotificationRules[0].EligibleAssignments[0].ApproverNotifications = flattenNotificationSettings(rule)
+
+		case "Notification_Approver_EndUser_Assignment":
+			rules.NotificationRules[0].EligibleActivations[0].ApproverNotifications = flattenNotificationSettings(rule)
+
+		case "Notification_Requestor_Admin_Assignment":
+			rules.NotificationRules[0].ActiveAssignments[0].AssigneeNotifications = flattenNotificationSettings(rule)
+
+		case "Notification_Requestor_Admin_Eligibility":
+			rules.NotificationRules[0].EligibleAssignments[0].AssigneeNotifications = flattenNotificationSettings(rule)
+
+		case "Notification_Requestor_EndUser_Assignment":
+			rules.NotificationRules[0].EligibleActivations[0].AssigneeNotifications = flattenNotificationSettings(rule)
+		}
+	}
+
+	return nil
+}
+
+// ExpandRules builds the rules that should be sent to the API in order to update a role management policy. Only the
+// rules that have changed are returned, and the targets of existing rules are retained since the API requires them.
+func ExpandRules(d ChangeDetector, rules Rules, existingRules *[]stable.UnifiedRoleManagementPolicyRule) ([]stable.UnifiedRoleManagementPolicyRule, error) {
+	// Take the slice of rules and convert it to a map with the ID as the key
+	policyRules := make(map[string]stable.UnifiedRoleManagementPolicyRule)
+	for _, rule := range pointer.From(existingRules) {
+		id := rule.UnifiedRoleManagementPolicyRule().Id
+		if id == nil {
+			continue
+		}
+		policyRules[*id] = rule
+	}
+	updatedRules := make([]stable.UnifiedRoleManagementPolicyRule, 0)
+
+	if d.HasChange("eligible_assignment_rules") && len(rules.EligibleAssignmentRules) > 0 {
+		rule := stable.UnifiedRoleManagementPolicyExpirationRule{
+			Id:                   pointer.To("Expiration_Admin_Eligibility"),
+			IsExpirationRequired: nullable.Value(rules.EligibleAssignmentRules[0].ExpirationRequired),
+			MaximumDuration:      nullable.NoZero(rules.EligibleAssignmentRules[0].ExpireAfter),
+		}
+
+		if existingRule, ok := policyRules["Expiration_Admin_Eligibility"].(stable.UnifiedRoleManagementPolicyExpirationRule); ok {
+			rule.Target = existingRule.Target
+		}
+
+		updatedRules = append(updatedRules, rule)
+	}
+
+	if len(rules.ActiveAssignmentRules) > 0 {
+		if d.HasChange("active_assignment_rules.0.require_multifactor_authentication") ||
+			d.HasChange("active_assignment_rules.0.require_justification") {
+
+			enabledRules := make([]string, 0)
+			if rules.ActiveAssignmentRules[0].RequireMultiFactorAuth {
+				enabledRules = append(enabledRules, "MultiFactorAuthentication")
+			}
+			if rules.ActiveAssignmentRules[0].RequireJustification {
+				enabledRules = append(enabledRules, "Justification")
+			}
+			if rules.ActiveAssignmentRules[0].RequireTicketInfo {
+				enabledRules = append(enabledRules, "Ticketing")
+			}
+
+			rule := stable.UnifiedRoleManagementPolicyEnablementRule{
+				Id:           pointer.To("Enablement_Admin_Assignment"),
+				EnabledRules: &enabledRules,
+			}
+
+			if existingRule, ok := policyRules["Enablement_Admin_Assignment"].(stable.UnifiedRoleManagementPolicyEnablementRule); ok {
+				rule.Target = existingRule.Target
+			}
+
+			updatedRules = append(updatedRules, rule)
+		}
+
+		if d.HasChange("active_assignment_rules.0.expiration_required") ||
+			d.HasChange("active_assignment_rules.0.expire_after") {
+
+			rule := stable.UnifiedRoleManagementPolicyExpirationRule{
+				Id:                   pointer.To("Expiration_Admin_Assignment"),
+				IsExpirationRequired: nullable.Value(rules.ActiveAssignmentRules[0].ExpirationRequired),
+				MaximumDuration:      nullable.Value(rules.ActiveAssignmentRules[0].ExpireAfter),
+			}
+
+			if existingRule, ok := policyRules["Expiration_Admin_Assignment"].(stable.UnifiedRoleManagementPolicyExpirationRule); ok {
+				rule.Target = existingRule.Target
+			}
+
+			updatedRules = append(updatedRules, rule)
+		}
+	}
+
+	if len(rules.ActivationRules) > 0 {
+		activationRules := rules.ActivationRules[0]
+
+		if d.HasChange("activation_rules.0.maximum_duration") {
+			rule := stable.UnifiedRoleManagementPolicyExpirationRule{
+				Id:              pointer.To("Expiration_EndUser_Assignment"),
+				MaximumDuration: nullable.Value(activationRules.MaximumDuration),
+			}
+
+			if existingRule, ok := policyRules["Expiration_EndUser_Assignment"].(stable.UnifiedRoleManagementPolicyExpirationRule); ok {
+				rule.Target = existingRule.Target
+			}
+
+			updatedRules = append(updatedRules, rule)
+		}
+
+		if d.HasChange("activation_rules.0.require_approval") ||
+			d.HasChange("activation_rules.0.approval_stage") {
+
+			rule := stable.UnifiedRoleManagementPolicyApprovalRule{
+				Id: pointer.To("Approval_EndUser_Assignment"),
+				Setting: &stable.ApprovalSettings{
+					IsApprovalRequired: nullable.Value(activationRules.RequireApproval),
+				},
+			}
+
+			if existingRule, ok := policyRules["Approval_EndUser_Assignment"].(stable.UnifiedRoleManagementPolicyApprovalRule); ok {
+				rule.Target = existingRule.Target
+
+				if existingRule.Setting != nil {
+					rule.Setting.ApprovalStages = existingRule.Setting.ApprovalStages
+				}
+			}
+
+			if activationRules.RequireApproval && len(activationRules.ApprovalStages) != 1 {
+				return nil, fmt.Errorf("require_approval is true, but no approval_stages are provided")
+			}
+
+			if d.HasChange("activation_rules.0.approval_stage") {
+				approvalStages := make([]stable.UnifiedApprovalStage, 0)
+
+				for _, stage := range activationRules.ApprovalStages {
+					primaryApprovers := make([]stable.SubjectSet, 0)
+
+					for _, approver := range stage.PrimaryApprovers {
+						switch approver.Type {
+						case "singleUser":
+							primaryApprovers = append(primaryApprovers, stable.SingleUser{
+								UserId: nullable.Value(approver.ID),
+							})
+						case "groupMembers":
+							primaryApprovers = append(primaryApprovers, stable.GroupMembers{
+								GroupId: nullable.Value(approver.ID),
+							})
+						}
+					}
+
+					approvalStages = append(approvalStages, stable.UnifiedApprovalStage{
+						PrimaryApprovers: &primaryApprovers,
+					})
+				}
+
+				rule.Setting.ApprovalStages = &approvalStages
+			}
+
+			updatedRules = append(updatedRules, rule)
+		}
+
+		if d.HasChange("activation_rules.0.required_conditional_access_authentication_context") {
+			rule := stable.UnifiedRoleManagementPolicyAuthenticationContextRule{
+				Id:         pointer.To("AuthenticationContext_EndUser_Assignment"),
+				IsEnabled:  nullable.Value(activationRules.RequireConditionalAccessContext != ""),
+				ClaimValue: nullable.NoZero(activationRules.RequireConditionalAccessContext),
+			}
+
+			if existingRule, ok := policyRules["AuthenticationContext_EndUser_Assignment"].(stable.UnifiedRoleManagementPolicyAuthenticationContextRule); ok {
+				rule.ClaimValue = existingRule.ClaimValue
+				rule.Target = existingRule.Target
+			}
+
+			updatedRules = append(updatedRules, rule)
+		}
+
+		if d.HasChange("activation_rules.0.require_multifactor_authentication") ||
+			d.HasChange("activation_rules.0.require_justification") ||
+			d.HasChange("activation_rules.0.require_ticket_info") {
+
+			enabledRules := make([]string, 0)
+			if activationRules.RequireMultiFactorAuth {
+				enabledRules = append(enabledRules, "MultiFactorAuthentication")
+			}
+			if activationRules.RequireJustification {
+				enabledRules = append(enabledRules, "Justification")
+			}
+			if activationRules.RequireTicketInfo {
+				enabledRules = append(enabledRules, "Ticketing")
+			}
+
+			rule := stable.UnifiedRoleManagementPolicyEnablementRule{
+				Id:           pointer.To("Enablement_EndUser_Assignment"),
+				EnabledRules: &enabledRules,
+			}
+
+			if existingRule, ok := policyRules["Enablement_EndUser_Assignment"].(stable.UnifiedRoleManagementPolicyEnablementRule); ok {
+				rule.Target = existingRule.Target
+			}
+
+			updatedRules = append(updatedRules, rule)
+		}
+	}
+
+	if len(rules.NotificationRules) > 0 {
+		notificationRules := []struct {
+			path          string
+			ruleId        string
+			recipientType string
+			events        []NotificationRule
+			settings      func(NotificationRule) []NotificationSettings
+		}{
+			{"eligible_assignments.0.admin_notifications", "Notification_Admin_Admin_Eligibility", "Admin", rules.NotificationRules[0].EligibleAssignments, adminNotifications},
+			{"active_assignments.0.admin_notifications", "Notification_Admin_Admin_Assignment", "Admin", rules.NotificationRules[0].ActiveAssignments, adminNotifications},
+			{"eligible_activations.0.admin_notifications", "Notification_Admin_EndUser_Assignment", "Admin", rules.NotificationRules[0].EligibleActivations, adminNotifications},
+			{"eligible_assignments.0.approver_notifications", "Notification_Approver_Admin_Eligibility", "Approver", rules.NotificationRules[0].EligibleAssignments, approverNotifications},
+			{"active_assignments.0.approver_notifications", "Notification_Approver_Admin_Assignment", "Approver", rules.NotificationRules[0].ActiveAssignments, approverNotifications},
+			{"eligible_activations.0.approver_notifications", "Notification_Approver_EndUser_Assignment", "Approver", rules.NotificationRules[0].EligibleActivations, approverNotifications},
+			{"eligible_assignments.0.assignee_notifications", "Notification_Requestor_Admin_Eligibility", "Requestor", rules.NotificationRules[0].EligibleAssignments, assigneeNotifications},
+			{"active_assignments.0.assignee_notifications", "Notification_Requestor_Admin_Assignment", "Requestor", rules.NotificationRules[0].ActiveAssignments, assigneeNotifications},
+			{"eligible_activations.0.assignee_notifications", "Notification_Requestor_EndUser_Assignment", "Requestor", rules.NotificationRules[0].EligibleActivations, assigneeNotifications},
+		}
+
+		for _, n := range notificationRules {
+			if !d.HasChange("notification_rules.0."+n.path) || len(n.events) == 0 {
+				continue
+			}
+			settings := n.settings(n.events[0])
+			if len(settings) == 0 {
+				continue
+			}
+			updatedRules = append(updatedRules, expandNotificationSettings(n.ruleId, policyRules, settings[0], n.recipientType))
+		}
+	}
+
+	return updatedRules, nil
+}
+
+func adminNotifications(in NotificationRule) []NotificationSettings { return in.AdminNotifications }
+func approverNotifications(in NotificationRule) []NotificationSettings {
+	return in.ApproverNotifications
+}
+func assigneeNotifications(in NotificationRule) []NotificationSettings {
+	return in.AssigneeNotifications
+}
+
+func expandNotificationSettings(ruleId string, policyRules map[string]stable.UnifiedRoleManagementPolicyRule, data NotificationSettings, recipientType string) stable.UnifiedRoleManagementPolicyNotificationRule {
+	rule := stable.UnifiedRoleManagementPolicyNotificationRule{
+		Id:                         pointer.To(ruleId),
+		IsDefaultRecipientsEnabled: nullable.Value(data.DefaultRecipients),
+		NotificationLevel:          nullable.Value(data.NotificationLevel),
+		NotificationRecipients:     pointer.To(data.AdditionalRecipients),
+		NotificationType:           nullable.Value("Email"),
+		RecipientType:              nullable.Value(recipientType),
+	}
+
+	if existingRule, ok := policyRules[ruleId].(stable.UnifiedRoleManagementPolicyNotificationRule); ok {
+		rule.Target = existingRule.Target
+	}
+
+	return rule
+}
+
+func flattenNotificationSettings(input stable.UnifiedRoleManagementPolicyRule) []NotificationSettings {
+	rule, ok := input.(stable.UnifiedRoleManagementPolicyNotificationRule)
+	if !ok {
+		return nil
+	}
+
+	return []NotificationSettings{{
+		NotificationLevel:    rule.NotificationLevel.GetOrZero(),
+		DefaultRecipients:    rule.IsDefaultRecipientsEnabled.GetOrZero(),
+		AdditionalRecipients: pointer.From(rule.NotificationRecipients),
+	}}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rolemanagementrules
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+type changes map[string]bool
+
+func (c changes) HasChange(key string) bool {
+	return c[key]
+}
+
+func TestFlattenRules(t *testing.T) {
+	policyRules := []stable.UnifiedRoleManagementPolicyRule{
+		stable.UnifiedRoleManagementPolicyApprovalRule{
+			Id: pointer.To("Approval_EndUser_Assignment"),
+			Setting: &stable.ApprovalSettings{
+				IsApprovalRequired: nullable.Value(true),
+				ApprovalStages: &[]stable.UnifiedApprovalStage{
+					{
+						PrimaryApprovers: &[]stable.SubjectSet{
+							stable.SingleUser{
+								ODataType: pointer.To("#microsoft.graph.singleUser"),
+								UserId:    nullable.Value("00000000-0000-0000-0000-000000000001"),
+							},
+							stable.GroupMembers{
+								ODataType: pointer.To("#microsoft.graph.groupMembers"),
+								GroupId:   nullable.Value("00000000-0000-0000-0000-000000000002"),
+							},
+						},
+					},
+				},
+			},
+		},
+		stable.UnifiedRoleManagementPolicyEnablementRule{
+			Id:           pointer.To("Enablement_EndUser_Assignment"),
+			EnabledRules: &[]string{"MultiFactorAuthentication", "Ticketing"},
+		},
+		stable.UnifiedRoleManagementPolicyExpirationRule{
+			Id:                   pointer.To("Expiration_Admin_Eligibility"),
+			IsExpirationRequired: nullable.Value(true),
+			MaximumDuration:      nullable.Value("P180D"),
+		},
+		stable.UnifiedRoleManagementPolicyExpirationRule{
+			Id:              pointer.To("Expiration_EndUser_Assignment"),
+			MaximumDuration: nullable.Value("PT4H"),
+		},
+		stable.UnifiedRoleManagementPolicyNotificationRule{
+			Id:                         pointer.To("Notification_Approver_EndUser_Assignment"),
+			IsDefaultRecipientsEnabled: nullable.Value(false),
+			NotificationLevel:          nullable.Value("Critical"),
+			NotificationRecipients:     &[]string{"someone@example.com"},
+		},
+	}
+
+	var rules Rules
+	if err := FlattenRules(&policyRules, &rules); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedActivation := ActivationRules{
+		ApprovalStages: []ApprovalStage{{PrimaryApprovers: []Approver{
+			{ID: "00000000-0000-0000-0000-000000000001", Type: "singleUser"},
+			{ID: "00000000-0000-0000-0000-000000000002", Type: "groupMembers"},
+		}}},
+		MaximumDuration:        "PT4H",
+		RequireApproval:        true,
+		RequireMultiFactorAuth: true,
+		RequireTicketInfo:      true,
+	}
+	if !reflect.DeepEqual(rules.ActivationRules, []ActivationRules{expectedActivation}) {
+		t.Fatalf("unexpected activation rules: %+v", rules.ActivationRules)
+	}
+
+	if !reflect.DeepEqual(rules.EligibleAssignmentRules, []EligibleAssignmentRules{{ExpirationRequired: true, ExpireAfter: "P180D"}}) {
+		t.Fatalf("unexpected eligible assignment rules: %+v", rules.EligibleAssignmentRules)
+	}
+
+	if len(rules.ActiveAssignmentRules) != 1 {
+		t.Fatalf("expected active assignment rules to be initialised, got %d blocks", len(rules.ActiveAssignmentRules))
+	}
+
+	expectedNotifications := []NotificationSettings{{AdditionalRecipients: []string{"someone@example.com"}, NotificationLevel: "Critical"}}
+	if !reflect.DeepEqual(rules.NotificationRules[0].EligibleActivations[0].ApproverNotifications, expectedNotifications) {
+		t.Fatalf("unexpected approver notifications: %+v", rules.NotificationRules[0].EligibleActivations[0].ApproverNotifications)
+	}
+}
+
+func TestExpandRules(t *testing.T) {
+	target := &stable.UnifiedRoleManagementPolicyRuleTarget{Caller: nullable.Value("EndUser")}
+	existingRules := []stable.UnifiedRoleManagementPolicyRule{
+		stable.UnifiedRoleManagementPolicyEnablementRule{
+			Id:     pointer.To("Enablement_EndUser_Assignment"),
+			Target: target,
+		},
+	}
+
+	rules := Rules{
+		ActivationRules: []ActivationRules{{
+			RequireApproval:        true,
+			RequireMultiFactorAuth: true,
+			RequireJustification:   true,
+			ApprovalStages: []ApprovalStage{{PrimaryApprovers: []Approver{
+				{ID: "00000000-0000-0000-0000-000000000002", Type: "groupMembers"},
+			}}},
+		}},
+	}
+
+	d := changes{
+		"activation_rules.0.require_approval":                   true,
+		"activation_rules.0.approval_stage":                     true,
+		"activation_rules.0.require_multifactor_authentication": true,
+	}
+
+	result, err := ExpandRules(d, rules, &existingRules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(result))
+	}
+
+	approvalRule, ok := result[0].(stable.UnifiedRoleManagementPolicyApprovalRule)
+	if !ok {
+		t.Fatalf("expected first rule to be an approval rule, got %T", result[0])
+	}
+	if !approvalRule.Setting.IsApprovalRequired.GetOrZero() {
+		t.Fatalf("expected approval to be required")
+	}
+	if approvers := (*approvalRule.Setting.ApprovalStages)[0].PrimaryApprovers; approvers == nil || len(*approvers) != 1 {
+		t.Fatalf("expected 1 primary approver, got %+v", approvers)
+	}
+
+	enablementRule, ok := result[1].(stable.UnifiedRoleManagementPolicyEnablementRule)
+	if !ok {
+		t.Fatalf("expected second rule to be an enablement rule, got %T", result[1])
+	}
+	if !reflect.DeepEqual(pointer.From(enablementRule.EnabledRules), []string{"MultiFactorAuthentication", "Justification"}) {
+		t.Fatalf("unexpected enabled rules: %v", pointer.From(enablementRule.EnabledRules))
+	}
+	if enablementRule.Target != target {
+		t.Fatalf("expected the target of the existing rule to be retained")
+	}
+
+	rules.ActivationRules[0].ApprovalStages = nil
+	if _, err = ExpandRules(d, rules, &existingRules); err == nil {
+		t.Fatalf("expected an error when approval is required without an approval stage")
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-provider-azuread/internal/features"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func conditionalAccessSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"required_excluded_users": {
+					Type:        pluginsdk.TypeSet,
+					Optional:    true,
+					Description: "The object IDs of users, such as emergency access accounts, which must be excluded from every conditional access policy that includes all users",
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.IsUUID,
+					},
+				},
+
+				"reject_overlapping_trusted_ip_ranges": {
+					Type:        pluginsdk.TypeBool,
+					Optional:    true,
+					Description: "Whether planning should fail when the IP ranges of a trusted named location overlap those of another trusted named location managed by Terraform",
+				},
+
+				"required_excluded_groups": {
+					Type:        pluginsdk.TypeSet,
+					Optional:    true,
+					Description: "The object IDs of groups which must be excluded from every conditional access policy that includes all users",
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.IsUUID,
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandFeatures(d *pluginsdk.ResourceData) features.UserFeatures {
+	result := features.Default()
+
+	if v, ok := d.GetOk("conditional_access"); ok {
+		if items := v.([]interface{}); len(items) > 0 && items[0] != nil {
+			conditionalAccess := items[0].(map[string]interface{})
+			result.ConditionalAccess.RejectOverlappingTrustedIPRanges = conditionalAccess["reject_overlapping_trusted_ip_ranges"].(bool)
+			result.ConditionalAccess.RequiredExcludedUsers = tf.ExpandStringSlice(conditionalAccess["required_excluded_users"].(*pluginsdk.Set).List())
+			result.ConditionalAccess.RequiredExcludedGroups = tf.ExpandStringSlice(conditionalAccess["required_excluded_groups"].(*pluginsdk.Set).List())
+		}
+	}
+
+	return result
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/features"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
 	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
@@ -249,6 +250,8 @@ func AzureADProvider() *schema.Provider {
 				DefaultFunc: pluginsdk.EnvDefaultFunc("ARM_DISABLE_TERRAFORM_PARTNER_ID", false),
 				Description: "Disable the Terraform Partner ID, which is used if a custom `partner_id` isn't specified",
 			},
+
+			"conditional_access": conditionalAccessSchema(),
 		},
 
 		ResourcesMap:   resources,
@@ -354,13 +357,14 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			partnerId = terraformPartnerId
 		}
 
-		return buildClient(ctx, p, authConfig, partnerId)
+		return buildClient(ctx, p, authConfig, expandFeatures(d), partnerId)
 	}
 }
 
-func buildClient(ctx context.Context, p *schema.Provider, authConfig *auth.Credentials, partnerId string) (*clients.Client, pluginsdk.Diagnostics) {
+func buildClient(ctx context.Context, p *schema.Provider, authConfig *auth.Credentials, userFeatures features.UserFeatures, partnerId string) (*clients.Client, pluginsdk.Diagnostics) {
 	clientBuilder := clients.ClientBuilder{
 		AuthConfig:       authConfig,
+		Features:         userFeatures,
 		PartnerID:        partnerId,
 		TerraformVersion: p.TerraformVersion,
 	}
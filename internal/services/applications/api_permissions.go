@@ -0,0 +1,344 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/applications"
+)
+
+// apiPermissionCatalog describes the app roles and delegated permission scopes published by the service principal of an
+// API, keyed by permission value
+type apiPermissionCatalog struct {
+	AppId  string
+	Roles  map[string]string
+	Scopes map[string]string
+}
+
+// apiPermissionCatalogCache holds the permissions published by APIs, keyed by lowercase client ID, along with the client
+// IDs of APIs looked up by name, so that each API is only retrieved once per provider run
+var apiPermissionCatalogCache = struct {
+	sync.Mutex
+	catalogs map[string]*apiPermissionCatalog
+	names    map[string]string
+}{
+	catalogs: make(map[string]*apiPermissionCatalog),
+	names:    make(map[string]string),
+}
+
+// normalizeApiName removes whitespace and punctuation from an API name, so that a name such as `Microsoft Graph` can be
+// matched against the names of well-known published APIs, such as `MicrosoftGraph`
+func normalizeApiName(in string) string {
+	return strings.ToLower(strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '_' || r == '.' {
+			return -1
+		}
+		return r
+	}, in))
+}
+
+// publishedApiClientId returns the client ID of a well-known published API, as also exposed by the
+// `azuread_application_published_app_ids` data source
+func publishedApiClientId(name string) (string, bool) {
+	normalized := normalizeApiName(name)
+	for apiName, appId := range environments.PublishedApis {
+		if normalizeApiName(apiName) == normalized {
+			return appId, true
+		}
+	}
+	return "", false
+}
+
+// apiPermissionCatalogByName returns the permissions published by an API, identified either by the name of a well-known
+// published API, or by the display name of its service principal in the tenant
+func apiPermissionCatalogByName(ctx context.Context, client *serviceprincipal.ServicePrincipalClient, name string) (*apiPermissionCatalog, error) {
+	if appId, ok := publishedApiClientId(name); ok {
+		return apiPermissionCatalogByClientId(ctx, client, appId)
+	}
+
+	apiPermissionCatalogCache.Lock()
+	appId, ok := apiPermissionCatalogCache.names[normalizeApiName(name)]
+	apiPermissionCatalogCache.Unlock()
+	if ok {
+		return apiPermissionCatalogByClientId(ctx, client, appId)
+	}
+
+	options := serviceprincipal.ListServicePrincipalsOperationOptions{
+		Filter: pointer.To(fmt.Sprintf("displayName eq '%s'", odata.EscapeSingleQuote(name))),
+	}
+	resp, err := client.ListServicePrincipals(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("listing service principals for filter %q: %+v", *options.Filter, err)
+	}
+	if resp.Model == nil || len(*resp.Model) == 0 {
+		return nil, fmt.Errorf("no published API or service principal was found with the name %q", name)
+	}
+	if len(*resp.Model) > 1 {
+		return nil, fmt.Errorf("found multiple service principals with the name %q, specify the client ID of the API instead", name)
+	}
+
+	servicePrincipal := (*resp.Model)[0]
+	catalog := newApiPermissionCatalog(servicePrincipal)
+
+	apiPermissionCatalogCache.Lock()
+	apiPermissionCatalogCache.names[normalizeApiName(name)] = catalog.AppId
+	apiPermissionCatalogCache.catalogs[strings.ToLower(catalog.AppId)] = catalog
+	apiPermissionCatalogCache.Unlock()
+
+	return catalog, nil
+}
+
+// apiPermissionCatalogByClientId returns the permissions published by the service principal for the API with the
+// specified client ID
+func apiPermissionCatalogByClientId(ctx context.Context, client *serviceprincipal.ServicePrincipalClient, appId string) (*apiPermissionCatalog, error) {
+	apiPermissionCatalogCache.Lock()
+	catalog, ok := apiPermissionCatalogCache.catalogs[strings.ToLower(appId)]
+	apiPermissionCatalogCache.Unlock()
+	if ok {
+		return catalog, nil
+	}
+
+	options := serviceprincipal.ListServicePrincipalsOperationOptions{
+		Filter: pointer.To(fmt.Sprintf("appId eq '%s'", odata.EscapeSingleQuote(appId))),
+	}
+	resp, err := client.ListServicePrincipals(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("listing service principals for filter %q: %+v", *options.Filter, err)
+	}
+	if resp.Model != nil {
+		for _, servicePrincipal := range *resp.Model {
+			if strings.EqualFold(servicePrincipal.AppId.GetOrZero(), appId) {
+				catalog = newApiPermissionCatalog(servicePrincipal)
+				break
+			}
+		}
+	}
+	if catalog == nil {
+		return nil, fmt.Errorf("no service principal was found for the API with client ID %q", appId)
+	}
+
+	apiPermissionCatalogCache.Lock()
+	apiPermissionCatalogCache.catalogs[strings.ToLower(appId)] = catalog
+	apiPermissionCatalogCache.Unlock()
+
+	return catalog, nil
+}
+
+func newApiPermissionCatalog(servicePrincipal stable.ServicePrincipal) *apiPermissionCatalog {
+	return &apiPermissionCatalog{
+		AppId:  servicePrincipal.AppId.GetOrZero(),
+		Roles:  applications.FlattenAppRoleIDs(servicePrincipal.AppRoles),
+		Scopes: applications.FlattenOAuth2PermissionScopeIDs(servicePrincipal.OAuth2PermissionScopes),
+	}
+}
+
+// permissionId returns the ID of the app role or delegated permission scope with the specified value
+func (c apiPermissionCatalog) permissionId(value, permissionType string) (string, error) {
+	permissions := c.Scopes
+	if permissionType == ResourceAccessTypeRole {
+		permissions = c.Roles
+	}
+
+	if id, ok := permissions[value]; ok {
+		return id, nil
+	}
+	for v, id := range permissions {
+		if strings.EqualFold(v, value) {
+			return id, nil
+		}
+	}
+
+	return "", fmt.Errorf("the API with client ID %q does not publish a permission of type %q with the value %q", c.AppId, permissionType, value)
+}
+
+// permissionIds returns the IDs of the app roles or delegated permission scopes with the specified values
+func (c apiPermissionCatalog) permissionIds(values []string, permissionType string) ([]string, error) {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		id, err := c.permissionId(value, permissionType)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+// mergeApiPermissionIds returns the union of two lists of permission IDs
+func mergeApiPermissionIds(a, b []string) []string {
+	result := make([]string, 0, len(a)+len(b))
+	for _, id := range slices.Concat(a, b) {
+		if !slices.ContainsFunc(result, func(v string) bool { return strings.EqualFold(v, id) }) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// resolveApplicationRequiredResourceAccess populates the `resource_app_id` and `id` properties of any
+// `required_resource_access` blocks which specify an API by name, or permissions by value
+func resolveApplicationRequiredResourceAccess(ctx context.Context, client *serviceprincipal.ServicePrincipalClient, in []interface{}) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(in))
+
+	for _, raw := range in {
+		if raw == nil {
+			continue
+		}
+		requiredResourceAccess := raw.(map[string]interface{})
+		resourceAppId, _ := requiredResourceAccess["resource_app_id"].(string)
+		resourceAppName, _ := requiredResourceAccess["resource_app_name"].(string)
+
+		var catalog *apiPermissionCatalog
+		var err error
+		switch {
+		case resourceAppName != "":
+			if catalog, err = apiPermissionCatalogByName(ctx, client, resourceAppName); err != nil {
+				return nil, err
+			}
+			resourceAppId = catalog.AppId
+		case resourceAppId == "":
+			return nil, fmt.Errorf("one of `resource_app_id` or `resource_app_name` must be specified for each `required_resource_access` block")
+		}
+
+		resourceAccess := make([]interface{}, 0)
+		for _, accessRaw := range requiredResourceAccess["resource_access"].([]interface{}) {
+			if accessRaw == nil {
+				continue
+			}
+			access := accessRaw.(map[string]interface{})
+			id, _ := access["id"].(string)
+			value, _ := access["value"].(string)
+			permissionType := access["type"].(string)
+
+			switch {
+			case value != "":
+				if catalog == nil {
+					if catalog, err = apiPermissionCatalogByClientId(ctx, client, resourceAppId); err != nil {
+						return nil, err
+					}
+				}
+				if id, err = catalog.permissionId(value, permissionType); err != nil {
+					return nil, err
+				}
+			case id == "":
+				return nil, fmt.Errorf("one of `id` or `value` must be specified for each `resource_access` block")
+			}
+
+			resourceAccess = append(resourceAccess, map[string]interface{}{
+				"id":    id,
+				"type":  permissionType,
+				"value": value,
+			})
+		}
+
+		result = append(result, map[string]interface{}{
+			"resource_access":   resourceAccess,
+			"resource_app_id":   resourceAppId,
+			"resource_app_name": resourceAppName,
+		})
+	}
+
+	return result, nil
+}
+
+// requiredResourceAccessFromConfig converts the raw configuration for `required_resource_access` blocks, so that
+// properties which are computed from names and values can be distinguished from those which are configured
+func requiredResourceAccessFromConfig(in cty.Value) []interface{} {
+	result := make([]interface{}, 0)
+	if in.IsNull() {
+		return result
+	}
+
+	stringAttr := func(v cty.Value, key string) string {
+		if attr := v.GetAttr(key); !attr.IsNull() {
+			return attr.AsString()
+		}
+		return ""
+	}
+
+	for it := in.ElementIterator(); it.Next(); {
+		_, requiredResourceAccess := it.Element()
+
+		resourceAccess := make([]interface{}, 0)
+		if accessList := requiredResourceAccess.GetAttr("resource_access"); !accessList.IsNull() {
+			for accessIt := accessList.ElementIterator(); accessIt.Next(); {
+				_, access := accessIt.Element()
+				resourceAccess = append(resourceAccess, map[string]interface{}{
+					"id":    stringAttr(access, "id"),
+					"type":  stringAttr(access, "type"),
+					"value": stringAttr(access, "value"),
+				})
+			}
+		}
+
+		result = append(result, map[string]interface{}{
+			"resource_access":   resourceAccess,
+			"resource_app_id":   stringAttr(requiredResourceAccess, "resource_app_id"),
+			"resource_app_name": stringAttr(requiredResourceAccess, "resource_app_name"),
+		})
+	}
+
+	return result
+}
+
+// requiredResourceAccessUsesNames determines whether any `required_resource_access` block specifies an API by name, or
+// any permission by value
+func requiredResourceAccessUsesNames(in []interface{}) bool {
+	return slices.ContainsFunc(in, func(raw interface{}) bool {
+		requiredResourceAccess := raw.(map[string]interface{})
+		if requiredResourceAccess["resource_app_name"].(string) != "" {
+			return true
+		}
+		return slices.ContainsFunc(requiredResourceAccess["resource_access"].([]interface{}), func(accessRaw interface{}) bool {
+			return accessRaw.(map[string]interface{})["value"].(string) != ""
+		})
+	})
+}
+
+// mergeApplicationRequiredResourceAccessNames copies the configured API names and permission values from existing
+// `required_resource_access` blocks to the flattened blocks returned by the API, which only contain IDs
+func mergeApplicationRequiredResourceAccessNames(flattened []map[string]interface{}, existing []interface{}) []map[string]interface{} {
+	for _, requiredResourceAccess := range flattened {
+		requiredResourceAccess["resource_app_name"] = ""
+
+		var match map[string]interface{}
+		for _, raw := range existing {
+			if v, ok := raw.(map[string]interface{}); ok && strings.EqualFold(v["resource_app_id"].(string), requiredResourceAccess["resource_app_id"].(string)) {
+				match = v
+				break
+			}
+		}
+		if match != nil {
+			requiredResourceAccess["resource_app_name"] = match["resource_app_name"]
+		}
+
+		for _, accessRaw := range requiredResourceAccess["resource_access"].([]interface{}) {
+			access := accessRaw.(map[string]interface{})
+			access["value"] = ""
+			if match == nil {
+				continue
+			}
+			for _, existingAccessRaw := range match["resource_access"].([]interface{}) {
+				existingAccess := existingAccessRaw.(map[string]interface{})
+				if strings.EqualFold(existingAccess["id"].(string), access["id"].(string)) && existingAccess["type"] == access["type"] {
+					access["value"] = existingAccess["value"]
+					break
+				}
+			}
+		}
+	}
+
+	return flattened
+}
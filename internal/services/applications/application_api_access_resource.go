@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
 	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
@@ -24,11 +25,17 @@ import (
 type ApplicationApiAccessModel struct {
 	ApplicationId string   `tfschema:"application_id"`
 	ApiClientId   string   `tfschema:"api_client_id"`
+	ApiName       string   `tfschema:"api_name"`
 	RoleIds       []string `tfschema:"role_ids"`
+	RoleNames     []string `tfschema:"role_names"`
 	ScopeIds      []string `tfschema:"scope_ids"`
+	ScopeNames    []string `tfschema:"scope_names"`
 }
 
-var _ sdk.ResourceWithUpdate = ApplicationApiAccessResource{}
+var (
+	_ sdk.ResourceWithUpdate        = ApplicationApiAccessResource{}
+	_ sdk.ResourceWithCustomizeDiff = ApplicationApiAccessResource{}
+)
 
 type ApplicationApiAccessResource struct{}
 
@@ -57,32 +64,67 @@ func (r ApplicationApiAccessResource) Arguments() map[string]*pluginsdk.Schema {
 		"api_client_id": {
 			Description:  "The client ID of the API to which access is being granted",
 			Type:         pluginsdk.TypeString,
-			Required:     true,
+			Optional:     true,
+			Computed:     true,
 			ForceNew:     true,
+			ExactlyOneOf: []string{"api_client_id", "api_name"},
 			ValidateFunc: validation.IsUUID,
 		},
 
+		"api_name": {
+			Description:  "The name of the API to which access is being granted, either a well-known published API such as `Microsoft Graph`, or the display name of its service principal",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: []string{"api_client_id", "api_name"},
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
 		"role_ids": {
 			Description:  "A set of role IDs to be granted to the application, as published by the API",
 			Type:         pluginsdk.TypeSet,
 			Optional:     true,
-			AtLeastOneOf: []string{"role_ids", "scope_ids"},
+			Computed:     true,
+			AtLeastOneOf: []string{"role_ids", "role_names", "scope_ids", "scope_names"},
 			Elem: &pluginsdk.Schema{
 				Type:         pluginsdk.TypeString,
 				ValidateFunc: validation.IsUUID,
 			},
 		},
 
+		"role_names": {
+			Description:  "A set of role values to be granted to the application, as published by the API, e.g. `User.Read.All`",
+			Type:         pluginsdk.TypeSet,
+			Optional:     true,
+			AtLeastOneOf: []string{"role_ids", "role_names", "scope_ids", "scope_names"},
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
 		"scope_ids": {
 			Description:  "A set of scope IDs to be granted to the application, as published by the API",
 			Type:         pluginsdk.TypeSet,
 			Optional:     true,
-			AtLeastOneOf: []string{"role_ids", "scope_ids"},
+			Computed:     true,
+			AtLeastOneOf: []string{"role_ids", "role_names", "scope_ids", "scope_names"},
 			Elem: &pluginsdk.Schema{
 				Type:         pluginsdk.TypeString,
 				ValidateFunc: validation.IsUUID,
 			},
 		},
+
+		"scope_names": {
+			Description:  "A set of scope values to be granted to the application, as published by the API, e.g. `User.Read`",
+			Type:         pluginsdk.TypeSet,
+			Optional:     true,
+			AtLeastOneOf: []string{"role_ids", "role_names", "scope_ids", "scope_names"},
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
 	}
 }
 
@@ -90,6 +132,105 @@ func (r ApplicationApiAccessResource) Attributes() map[string]*pluginsdk.Schema
 	return map[string]*pluginsdk.Schema{}
 }
 
+func (r ApplicationApiAccessResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			diff := metadata.ResourceDiff
+			config := diff.GetRawConfig()
+
+			// When only IDs are configured, role and scope IDs removed from the configuration must be removed explicitly, since
+			// these properties are computed
+			if config.GetAttr("api_name").IsNull() && config.GetAttr("role_names").IsNull() && config.GetAttr("scope_names").IsNull() {
+				for _, key := range []string{"role_ids", "scope_ids"} {
+					if v := config.GetAttr(key); v.IsKnown() && v.IsNull() && diff.Get(key).(*pluginsdk.Set).Len() > 0 {
+						if err := diff.SetNew(key, []interface{}{}); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			}
+
+			// Resolve the API name and permission values, so that their IDs are known at plan time
+			if !config.IsWhollyKnown() {
+				return nil
+			}
+
+			var model ApplicationApiAccessModel
+			if err := metadata.DecodeDiff(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			model.ApiClientId = ""
+			if v := config.GetAttr("api_client_id"); !v.IsNull() {
+				model.ApiClientId = v.AsString()
+			}
+			model.RoleIds = r.configuredIds(config.GetAttr("role_ids"))
+			model.ScopeIds = r.configuredIds(config.GetAttr("scope_ids"))
+
+			if err := r.resolvePermissions(ctx, metadata, &model); err != nil {
+				return err
+			}
+
+			if err := diff.SetNew("api_client_id", model.ApiClientId); err != nil {
+				return err
+			}
+			if err := diff.SetNew("role_ids", model.RoleIds); err != nil {
+				return err
+			}
+			return diff.SetNew("scope_ids", model.ScopeIds)
+		},
+	}
+}
+
+func (r ApplicationApiAccessResource) configuredIds(in cty.Value) []string {
+	result := make([]string, 0)
+	if in.IsNull() {
+		return result
+	}
+	for it := in.ElementIterator(); it.Next(); {
+		_, v := it.Element()
+		result = append(result, v.AsString())
+	}
+	return result
+}
+
+// resolvePermissions populates the API client ID and the role and scope IDs of the model from any configured API name
+// and permission values
+func (r ApplicationApiAccessResource) resolvePermissions(ctx context.Context, metadata sdk.ResourceMetaData, model *ApplicationApiAccessModel) error {
+	if model.ApiName == "" && len(model.RoleNames) == 0 && len(model.ScopeNames) == 0 {
+		return nil
+	}
+
+	client := metadata.Client.Applications.ServicePrincipalClient
+
+	var catalog *apiPermissionCatalog
+	var err error
+	if model.ApiName != "" {
+		if catalog, err = apiPermissionCatalogByName(ctx, client, model.ApiName); err != nil {
+			return fmt.Errorf("resolving `api_name`: %v", err)
+		}
+		model.ApiClientId = catalog.AppId
+	} else if catalog, err = apiPermissionCatalogByClientId(ctx, client, model.ApiClientId); err != nil {
+		return fmt.Errorf("resolving `api_client_id`: %v", err)
+	}
+
+	roleIds, err := catalog.permissionIds(model.RoleNames, ResourceAccessTypeRole)
+	if err != nil {
+		return fmt.Errorf("resolving `role_names`: %v", err)
+	}
+	scopeIds, err := catalog.permissionIds(model.ScopeNames, ResourceAccessTypeScope)
+	if err != nil {
+		return fmt.Errorf("resolving `scope_names`: %v", err)
+	}
+
+	model.RoleIds = mergeApiPermissionIds(model.RoleIds, roleIds)
+	model.ScopeIds = mergeApiPermissionIds(model.ScopeIds, scopeIds)
+
+	return nil
+}
+
 func (r ApplicationApiAccessResource) Create() sdk.ResourceFunc {
 	return sdk.ResourceFunc{
 		Timeout: 10 * time.Minute,
@@ -106,6 +247,10 @@ func (r ApplicationApiAccessResource) Create() sdk.ResourceFunc {
 				return err
 			}
 
+			if err = r.resolvePermissions(ctx, metadata, &model); err != nil {
+				return err
+			}
+
 			id := parse.NewApiAccessID(applicationId.ApplicationId, model.ApiClientId)
 
 			tf.LockByName(applicationResourceName, id.ApplicationId)
@@ -229,11 +374,20 @@ func (r ApplicationApiAccessResource) Read() sdk.ResourceFunc {
 				}
 			}
 
+			// The API name and permission values are not returned by the API, so are retained from the existing state
+			var existing ApplicationApiAccessModel
+			if err := metadata.Decode(&existing); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
 			state := ApplicationApiAccessModel{
 				ApplicationId: applicationId.ID(),
 				ApiClientId:   pointer.From(api.ResourceAppId),
+				ApiName:       existing.ApiName,
 				RoleIds:       roleIds,
+				RoleNames:     existing.RoleNames,
 				ScopeIds:      scopeIds,
+				ScopeNames:    existing.ScopeNames,
 			}
 
 			return metadata.Encode(&state)
@@ -257,6 +411,10 @@ func (r ApplicationApiAccessResource) Update() sdk.ResourceFunc {
 				return fmt.Errorf("decoding: %+v", err)
 			}
 
+			if err = r.resolvePermissions(ctx, metadata, &model); err != nil {
+				return err
+			}
+
 			// Prepare a new API to replace the existing one
 			permissions := make([]stable.ResourceAccess, 0)
 			for _, roleId := range model.RoleIds {
@@ -109,6 +109,24 @@ func TestAccApplicationApiAccess_multipleUpdate(t *testing.T) {
 	})
 }
 
+func TestAccApplicationApiAccess_byName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_api_access", "test")
+	r := ApplicationApiAccessResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.byName(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("api_client_id").HasValue("00000003-0000-0000-c000-000000000000"),
+				check.That(data.ResourceName).Key("role_ids.#").HasValue("2"),
+				check.That(data.ResourceName).Key("scope_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("api_name", "role_names", "scope_names"),
+	})
+}
+
 func TestAccApplicationApiAccess_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_application_api_access", "test")
 	r := ApplicationApiAccessResource{}
@@ -286,3 +304,27 @@ resource "azuread_application_api_access" "import" {
 }
 `, data.RandomInteger, data.RandomPassword)
 }
+
+func (ApplicationApiAccessResource) byName(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application_registration" "test" {
+  display_name = "acctest-ApiAccess-%[1]d"
+}
+
+resource "azuread_application_api_access" "test" {
+  application_id = azuread_application_registration.test.id
+  api_name       = "Microsoft Graph"
+
+  role_names = [
+    "Group.Read.All",
+    "User.Read.All",
+  ]
+
+  scope_names = [
+    "User.Read",
+  ]
+}
+`, data.RandomInteger)
+}
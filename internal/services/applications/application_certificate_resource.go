@@ -6,13 +6,16 @@ package applications
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/credentials"
@@ -26,8 +29,11 @@ func applicationCertificateResource() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		CreateContext: applicationCertificateResourceCreate,
 		ReadContext:   applicationCertificateResourceRead,
+		UpdateContext: applicationCertificateResourceUpdate,
 		DeleteContext: applicationCertificateResourceDelete,
 
+		CustomizeDiff: applicationCertificateResourceCustomizeDiff,
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(10 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -63,21 +69,21 @@ func applicationCertificateResource() *pluginsdk.Resource {
 			},
 
 			"key_id": {
-				Description:  "A UUID used to uniquely identify this certificate. If omitted, a random UUID will be automatically generated",
-				Type:         pluginsdk.TypeString,
-				Optional:     true,
-				Computed:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.IsUUID,
+				Description:   "A UUID used to uniquely identify this certificate. If omitted, a random UUID will be automatically generated",
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"rotation"},
+				ValidateFunc:  validation.IsUUID,
 			},
 
 			"start_date": {
-				Description:  "The start date from which the certificate is valid, formatted as an RFC3339 date string (e.g. `2018-01-01T01:02:03Z`). If this isn't specified, the current date and time are use",
-				Type:         pluginsdk.TypeString,
-				Optional:     true,
-				Computed:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.IsRFC3339Time,
+				Description:   "The start date from which the certificate is valid, formatted as an RFC3339 date string (e.g. `2018-01-01T01:02:03Z`). If this isn't specified, the current date and time are use",
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"rotation"},
+				ValidateFunc:  validation.IsRFC3339Time,
 			},
 
 			"end_date": {
@@ -85,8 +91,7 @@ func applicationCertificateResource() *pluginsdk.Resource {
 				Type:          pluginsdk.TypeString,
 				Optional:      true,
 				Computed:      true,
-				ForceNew:      true,
-				ConflictsWith: []string{"end_date_relative"},
+				ConflictsWith: []string{"end_date_relative", "rotation"},
 				ValidateFunc:  validation.IsRFC3339Time,
 			},
 
@@ -95,7 +100,7 @@ func applicationCertificateResource() *pluginsdk.Resource {
 				Type:          pluginsdk.TypeString,
 				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"end_date"},
+				ConflictsWith: []string{"end_date", "rotation"},
 				ValidateFunc:  validation.StringIsNotEmpty,
 				Deprecated:    "The `end_date_relative` property is deprecated and will be removed in a future version of the AzureAD provider. Please instead use the Terraform `timeadd()` function to calculate a value for the `end_date` property.",
 			},
@@ -111,17 +116,155 @@ func applicationCertificateResource() *pluginsdk.Resource {
 				}, false),
 			},
 
-			"value": {
-				Description: "The certificate data, which can be PEM encoded, base64 encoded DER or hexadecimal encoded DER. See also the `encoding` argument",
+			"usage": {
+				Description: "The usage of the certificate, either `Verify` for signature verification or `Encrypt` for token encryption",
 				Type:        pluginsdk.TypeString,
-				Required:    true,
+				Optional:    true,
 				ForceNew:    true,
+				Default:     credentials.KeyCredentialUsageVerify,
+				ValidateFunc: validation.StringInSlice([]string{
+					credentials.KeyCredentialUsageEncrypt,
+					credentials.KeyCredentialUsageVerify,
+				}, false),
+			},
+
+			"value": {
+				Description:  "The certificate data, which can be PEM encoded, base64 encoded DER or hexadecimal encoded DER. See also the `encoding` argument",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				AtLeastOneOf: []string{"rotation", "value"},
+			},
+
+			"rotation": {
+				Description:  "Manage rotation of the certificate. When `value` is omitted, a key pair and self-signed certificate are generated and rotated automatically",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				AtLeastOneOf: []string{"rotation", "value"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"key_size": {
+							Description:  "The size in bits of generated RSA keys",
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      2048,
+							ValidateFunc: validation.IntInSlice([]int{2048, 3072, 4096}),
+						},
+
+						"overlap_days": {
+							Description:  "The number of days for which the previous certificate remains valid after rotation",
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      7,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
+						"rotate_before_days": {
+							Description:  "The number of days before the certificate expires, from which a new certificate is generated",
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      30,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"subject": {
+							Description:  "The subject of generated certificates",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      "CN=Terraform Managed Certificate",
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^CN=.+`), "subject must be in the form `CN=<common name>`"),
+						},
+
+						"validity_days": {
+							Description:  "The number of days for which generated certificates are valid",
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      365,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+
+			"certificate_pem": {
+				Description: "The PEM encoded certificate",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"previous_key_id": {
+				Description: "The key ID of the previous certificate, which remains valid until `previous_key_removal_date`",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"previous_key_removal_date": {
+				Description: "The date from which the previous certificate will be removed, formatted as an RFC3339 date string",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"private_key_pem": {
+				Description: "The PEM encoded private key of a generated certificate",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
 				Sensitive:   true,
 			},
+
+			"rotation_date": {
+				Description: "The date from which the certificate will be rotated, formatted as an RFC3339 date string",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"thumbprint": {
+				Description: "The SHA-1 thumbprint of the certificate",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
 
+func applicationCertificateResourceCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	rotation := diff.Get("rotation").([]interface{})
+	generated := len(rotation) > 0 && diff.Get("value").(string) == ""
+
+	if generated && rotation[0] != nil {
+		in := rotation[0].(map[string]interface{})
+		if in["rotate_before_days"].(int) >= in["validity_days"].(int) {
+			return fmt.Errorf("`rotation.0.rotate_before_days` must be less than `rotation.0.validity_days`")
+		}
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	// Enabling or disabling rotation requires a new credential
+	if oldRotation, _ := diff.GetChange("rotation"); len(oldRotation.([]interface{})) != len(rotation) {
+		return diff.ForceNew("rotation")
+	}
+
+	// Without rotation, any change to the certificate requires a new credential
+	if len(rotation) == 0 {
+		for _, key := range []string{"end_date", "key_id", "start_date", "value"} {
+			if diff.HasChange(key) {
+				if err := diff.ForceNew(key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	// Generated certificates are rotated from their rotation date, whereas supplied certificates are rotated when changed
+	rotate := diff.HasChange("value") || (generated && credentials.RotationDue(diff))
+
+	return credentials.RotationCustomizeDiff(diff, rotate, "certificate_pem", "private_key_pem", "thumbprint")
+}
+
 func applicationCertificateResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).Applications.ApplicationClient
 
@@ -130,18 +273,35 @@ func applicationCertificateResourceCreate(ctx context.Context, d *pluginsdk.Reso
 		return tf.ErrorDiagPathF(err, "application_id", "Parsing `application_id`")
 	}
 
-	credential, err := credentials.KeyCredentialForResource(d)
-	if err != nil {
-		attr := ""
-		if kerr, ok := err.(credentials.CredentialError); ok {
-			attr = kerr.Attr()
+	var credential *stable.KeyCredential
+	var certificatePem, privateKeyPem []byte
+	if len(d.Get("rotation").([]interface{})) > 0 {
+		credential, certificatePem, privateKeyPem, err = applicationCertificateRotationCredential(d)
+		if err != nil {
+			return tf.ErrorDiagF(err, "Generating certificate credentials for %s", applicationId)
+		}
+	} else {
+		credential, err = credentials.KeyCredentialForResource(d)
+		if err != nil {
+			attr := ""
+			if kerr, ok := err.(credentials.CredentialError); ok {
+				attr = kerr.Attr()
+			}
+			return tf.ErrorDiagPathF(err, attr, "Generating certificate credentials for %s", applicationId)
+		}
+
+		// Symmetric keys are not certificates, so the PEM encoding is only retained when it can be decoded
+		if pemValue, err := credentials.CertificatePem(d.Get("value").(string), d.Get("encoding").(string)); err == nil {
+			if _, err = credentials.GetTokenSigningCertificateThumbprint(pemValue); err == nil {
+				certificatePem = pemValue
+			}
 		}
-		return tf.ErrorDiagPathF(err, attr, "Generating certificate credentials for %s", applicationId)
 	}
 
 	if credential.KeyId == nil {
 		return tf.ErrorDiagF(errors.New("keyId for certificate credential is nil"), "Creating certificate credential")
 	}
+	credential.Usage = nullable.Value(d.Get("usage").(string))
 	id := parse.NewCredentialID(applicationId.ApplicationId, "certificate", credential.KeyId.GetOrZero())
 
 	tf.LockByName(applicationResourceName, id.ObjectId)
@@ -177,44 +337,16 @@ func applicationCertificateResourceCreate(ctx context.Context, d *pluginsdk.Reso
 		return tf.ErrorDiagF(err, "Adding certificate for %s", applicationId)
 	}
 
-	// Wait for the credential to appear in the application manifest, this can take several minutes
-	timeout, _ := ctx.Deadline()
-	polledForCredential, err := (&pluginsdk.StateChangeConf{ //nolint:staticcheck
-		Pending:                   []string{"Waiting"},
-		Target:                    []string{"Done"},
-		Timeout:                   time.Until(timeout),
-		MinTimeout:                1 * time.Second,
-		ContinuousTargetOccurence: 5,
-		Refresh: func() (interface{}, string, error) {
-			resp, err := client.GetApplication(ctx, *applicationId, application.DefaultGetApplicationOperationOptions())
-			if err != nil {
-				return nil, "Error", err
-			}
-			app := resp.Model
-			if app == nil {
-				return nil, "Error", errors.New("model was nil")
-			}
-
-			if app.KeyCredentials != nil {
-				for _, cred := range *app.KeyCredentials {
-					if strings.EqualFold(cred.KeyId.GetOrZero(), id.KeyId) {
-						return &cred, "Done", nil
-					}
-				}
-			}
-
-			return nil, "Waiting", nil
-		},
-	}).WaitForStateContext(ctx)
-
-	if err != nil {
+	if err = waitForApplicationKeyCredential(ctx, client, *applicationId, id.KeyId); err != nil {
 		return tf.ErrorDiagF(err, "Waiting for certificate credential for %s", applicationId)
-	} else if polledForCredential == nil {
-		return tf.ErrorDiagF(errors.New("certificate credential not found in application manifest"), "Waiting for certificate credential for %s", applicationId)
 	}
 
 	d.SetId(id.String())
 
+	if err = setApplicationCertificate(d, certificatePem, privateKeyPem); err != nil {
+		return tf.ErrorDiagF(err, "Computing thumbprint for certificate credential for %s", applicationId)
+	}
+
 	return applicationCertificateResourceRead(ctx, d, meta)
 }
 
@@ -248,12 +380,122 @@ func applicationCertificateResourceRead(ctx context.Context, d *pluginsdk.Resour
 	tf.Set(d, "application_id", applicationId.ID())
 	tf.Set(d, "key_id", id.KeyId)
 	tf.Set(d, "type", credential.Type.GetOrZero())
+	tf.Set(d, "usage", credential.Usage.GetOrZero())
 	tf.Set(d, "start_date", credential.StartDateTime.GetOrZero())
 	tf.Set(d, "end_date", credential.EndDateTime.GetOrZero())
 
+	if rotation := d.Get("rotation").([]interface{}); len(rotation) > 0 && rotation[0] != nil {
+		if previousKeyId := d.Get("previous_key_id").(string); previousKeyId != "" && credentials.GetKeyCredential(app.KeyCredentials, previousKeyId) == nil {
+			log.Printf("[DEBUG] Previous certificate credential %q (ID %q) was not found - removing from state", previousKeyId, id.ObjectId)
+			tf.Set(d, "previous_key_id", "")
+			tf.Set(d, "previous_key_removal_date", "")
+		}
+
+		rotationDate, err := credentials.RotationDate(credential.EndDateTime.GetOrZero(), rotation[0].(map[string]interface{})["rotate_before_days"].(int))
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "end_date", "Computing rotation date for certificate credential %q", id.KeyId)
+		}
+		tf.Set(d, "rotation_date", rotationDate)
+	}
+
 	return nil
 }
 
+func applicationCertificateResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationClient
+
+	id, err := parse.CertificateID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing certificate credential with ID %q", d.Id())
+	}
+
+	// A change to the key ID is only planned when the certificate is being rotated
+	rotate := d.HasChange("key_id")
+	oldPreviousKeyId, _ := d.GetChange("previous_key_id")
+	previousKeyId := oldPreviousKeyId.(string)
+	removePrevious := previousKeyId != "" && (rotate || d.Get("previous_key_id").(string) == "")
+
+	if !rotate && !removePrevious {
+		return applicationCertificateResourceRead(ctx, d, meta)
+	}
+
+	tf.LockByName(applicationResourceName, id.ObjectId)
+	defer tf.UnlockByName(applicationResourceName, id.ObjectId)
+
+	applicationId := stable.NewApplicationID(id.ObjectId)
+
+	resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "application_id", "Retrieving %s", applicationId)
+	}
+
+	app := resp.Model
+	if app == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", applicationId)
+	}
+
+	var credential *stable.KeyCredential
+	var certificatePem, privateKeyPem []byte
+	if rotate {
+		credential, certificatePem, privateKeyPem, err = applicationCertificateRotationCredential(d)
+		if err != nil {
+			return tf.ErrorDiagF(err, "Generating certificate credentials for %s", applicationId)
+		}
+		credential.Usage = nullable.Value(d.Get("usage").(string))
+	}
+
+	newCredentials := make([]stable.KeyCredential, 0)
+	if app.KeyCredentials != nil {
+		for _, cred := range *app.KeyCredentials {
+			if removePrevious && strings.EqualFold(cred.KeyId.GetOrZero(), previousKeyId) {
+				continue
+			}
+			newCredentials = append(newCredentials, cred)
+		}
+	}
+	if credential != nil {
+		newCredentials = append(newCredentials, *credential)
+	}
+
+	properties := stable.Application{
+		Id:             &id.ObjectId,
+		KeyCredentials: &newCredentials,
+	}
+
+	// When rotating the token encryption certificate, the application must be updated to encrypt tokens using the new key
+	if rotate && strings.EqualFold(app.TokenEncryptionKeyId.GetOrZero(), id.KeyId) {
+		properties.TokenEncryptionKeyId = nullable.Value(credential.KeyId.GetOrZero())
+	}
+
+	if _, err = client.UpdateApplication(ctx, applicationId, properties, application.DefaultUpdateApplicationOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Rotating certificate credential %q for %s", id.KeyId, applicationId)
+	}
+
+	if !rotate {
+		tf.Set(d, "previous_key_id", "")
+		tf.Set(d, "previous_key_removal_date", "")
+		return applicationCertificateResourceRead(ctx, d, meta)
+	}
+
+	newId := parse.NewCredentialID(id.ObjectId, "certificate", credential.KeyId.GetOrZero())
+	if err = waitForApplicationKeyCredential(ctx, client, applicationId, newId.KeyId); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for certificate credential for %s", applicationId)
+	}
+
+	// The current certificate remains valid for the overlap period, after which it is removed on the next apply
+	overlapDays := d.Get("rotation.0.overlap_days").(int)
+	tf.Set(d, "previous_key_id", id.KeyId)
+	tf.Set(d, "previous_key_removal_date", time.Now().UTC().AddDate(0, 0, overlapDays).Format(time.RFC3339))
+
+	d.SetId(newId.String())
+
+	if err = setApplicationCertificate(d, certificatePem, privateKeyPem); err != nil {
+		return tf.ErrorDiagF(err, "Computing thumbprint for certificate credential for %s", applicationId)
+	}
+
+	return applicationCertificateResourceRead(ctx, d, meta)
+}
+
 func applicationCertificateResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).Applications.ApplicationClient
 
@@ -277,10 +519,14 @@ func applicationCertificateResourceDelete(ctx context.Context, d *pluginsdk.Reso
 		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", applicationId)
 	}
 
+	// The previous certificate is also removed when it has not yet reached the end of its overlap period
+	previousKeyId := d.Get("previous_key_id").(string)
+
 	newCredentials := make([]stable.KeyCredential, 0)
 	if app.KeyCredentials != nil {
 		for _, cred := range *app.KeyCredentials {
-			if !strings.EqualFold(cred.KeyId.GetOrZero(), id.KeyId) {
+			keyId := cred.KeyId.GetOrZero()
+			if !strings.EqualFold(keyId, id.KeyId) && (previousKeyId == "" || !strings.EqualFold(keyId, previousKeyId)) {
 				newCredentials = append(newCredentials, cred)
 			}
 		}
@@ -317,3 +563,80 @@ func applicationCertificateResourceDelete(ctx context.Context, d *pluginsdk.Reso
 
 	return nil
 }
+
+// applicationCertificateRotationCredential returns a new key credential for a certificate with managed rotation, using
+// the supplied certificate when specified, otherwise generating a new key pair and self-signed certificate
+func applicationCertificateRotationCredential(d *pluginsdk.ResourceData) (credential *stable.KeyCredential, certificatePem []byte, privateKeyPem []byte, err error) {
+	if value := d.Get("value").(string); value != "" {
+		if certificatePem, err = credentials.CertificatePem(value, d.Get("encoding").(string)); err != nil {
+			return nil, nil, nil, err
+		}
+	} else {
+		startDate := time.Now().UTC()
+		endDate := startDate.AddDate(0, 0, d.Get("rotation.0.validity_days").(int))
+		certificatePem, privateKeyPem, err = credentials.GenerateCertificate(d.Get("rotation.0.subject").(string), d.Get("rotation.0.key_size").(int), startDate, endDate)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if credential, err = credentials.KeyCredentialFromCertificate(certificatePem); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return credential, certificatePem, privateKeyPem, nil
+}
+
+// setApplicationCertificate sets the computed attributes describing a newly added certificate
+func setApplicationCertificate(d *pluginsdk.ResourceData, certificatePem, privateKeyPem []byte) error {
+	var thumbprint string
+	if len(certificatePem) > 0 {
+		var err error
+		if thumbprint, err = credentials.GetTokenSigningCertificateThumbprint(certificatePem); err != nil {
+			return err
+		}
+	}
+
+	tf.Set(d, "certificate_pem", string(certificatePem))
+	tf.Set(d, "private_key_pem", string(privateKeyPem))
+	tf.Set(d, "thumbprint", thumbprint)
+
+	return nil
+}
+
+// waitForApplicationKeyCredential waits for a key credential to appear in the application manifest, which can take
+// several minutes
+func waitForApplicationKeyCredential(ctx context.Context, client *application.ApplicationClient, applicationId stable.ApplicationId, keyId string) error {
+	timeout, _ := ctx.Deadline()
+	polledForCredential, err := (&pluginsdk.StateChangeConf{ //nolint:staticcheck
+		Pending:                   []string{"Waiting"},
+		Target:                    []string{"Done"},
+		Timeout:                   time.Until(timeout),
+		MinTimeout:                1 * time.Second,
+		ContinuousTargetOccurence: 5,
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+			if err != nil {
+				return nil, "Error", err
+			}
+			app := resp.Model
+			if app == nil {
+				return nil, "Error", errors.New("model was nil")
+			}
+
+			if cred := credentials.GetKeyCredential(app.KeyCredentials, keyId); cred != nil {
+				return cred, "Done", nil
+			}
+
+			return nil, "Waiting", nil
+		},
+	}).WaitForStateContext(ctx)
+
+	if err != nil {
+		return err
+	} else if polledForCredential == nil {
+		return errors.New("certificate credential not found in application manifest")
+	}
+
+	return nil
+}
@@ -104,6 +104,24 @@ func TestAccApplicationCertificate_complete(t *testing.T) {
 	})
 }
 
+func TestAccApplicationCertificate_encryption(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_certificate", "test")
+	endDate := time.Now().AddDate(0, 3, 27).UTC().Format(time.RFC3339)
+	r := ApplicationCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.encryption(data, endDate),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("key_id").Exists(),
+				check.That(data.ResourceName).Key("usage").HasValue("Encrypt"),
+			),
+		},
+		data.ImportStep("encoding", "end_date_relative", "value"),
+	})
+}
+
 func TestAccApplicationCertificate_base64Cert(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_application_certificate", "test")
 	endDate := time.Now().AddDate(0, 3, 27).UTC().Format(time.RFC3339)
@@ -155,6 +173,68 @@ func TestAccApplicationCertificate_relativeEndDate(t *testing.T) {
 	})
 }
 
+func TestAccApplicationCertificate_rotation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_certificate", "test")
+	r := ApplicationCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.rotation(data, 90, 7),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("certificate_pem").Exists(),
+				check.That(data.ResourceName).Key("end_date").Exists(),
+				check.That(data.ResourceName).Key("key_id").Exists(),
+				check.That(data.ResourceName).Key("private_key_pem").Exists(),
+				check.That(data.ResourceName).Key("rotation_date").Exists(),
+				check.That(data.ResourceName).Key("thumbprint").Exists(),
+			),
+		},
+		{
+			Config: r.rotation(data, 90, 14),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("previous_key_id").IsEmpty(),
+				check.That(data.ResourceName).Key("rotation_date").Exists(),
+			),
+		},
+		{
+			// The current certificate expires in 90 days, so rotating 120 days before expiry forces rotation
+			Config: r.rotation(data, 365, 120),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("previous_key_id").Exists(),
+				check.That(data.ResourceName).Key("previous_key_removal_date").Exists(),
+				func(s *terraform.State) error {
+					rs := s.RootModule().Resources[data.ResourceName]
+					if rs.Primary.Attributes["previous_key_id"] == rs.Primary.Attributes["key_id"] {
+						return fmt.Errorf("expected key_id to change on rotation")
+					}
+					return nil
+				},
+			),
+		},
+	})
+}
+
+func TestAccApplicationCertificate_rotationSuppliedCert(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_certificate", "test")
+	r := ApplicationCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.rotationSuppliedCert(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("certificate_pem").Exists(),
+				check.That(data.ResourceName).Key("private_key_pem").IsEmpty(),
+				check.That(data.ResourceName).Key("rotation_date").Exists(),
+				check.That(data.ResourceName).Key("thumbprint").Exists(),
+			),
+		},
+	})
+}
+
 func TestAccApplicationCertificate_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_application_certificate", "test")
 	endDate := time.Now().AddDate(0, 3, 27).UTC().Format(time.RFC3339)
@@ -247,6 +327,22 @@ EOT
 `, r.template(data), data.RandomID, startDate, endDate, applicationCertificatePem)
 }
 
+func (r ApplicationCertificateResource) encryption(data acceptance.TestData, endDate string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_certificate" "test" {
+  application_id = azuread_application.test.id
+  type           = "AsymmetricX509Cert"
+  usage          = "Encrypt"
+  end_date       = "%[2]s"
+  value          = <<EOT
+%[3]s
+EOT
+}
+`, r.template(data), endDate, applicationCertificatePem)
+}
+
 func (r ApplicationCertificateResource) base64Cert(data acceptance.TestData, endDate string) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -294,6 +390,40 @@ EOT
 `, r.template(data), applicationCertificatePem)
 }
 
+func (r ApplicationCertificateResource) rotation(data acceptance.TestData, validityDays, rotateBeforeDays int) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_certificate" "test" {
+  application_id = azuread_application.test.id
+
+  rotation {
+    subject            = "CN=acctestCertificate-%[2]d"
+    validity_days      = %[3]d
+    rotate_before_days = %[4]d
+    overlap_days       = 3
+  }
+}
+`, r.template(data), data.RandomInteger, validityDays, rotateBeforeDays)
+}
+
+func (r ApplicationCertificateResource) rotationSuppliedCert(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_certificate" "test" {
+  application_id = azuread_application.test.id
+  value          = <<EOT
+%[2]s
+EOT
+
+  rotation {
+    rotate_before_days = 30
+  }
+}
+`, r.template(data), applicationCertificatePem)
+}
+
 func (r ApplicationCertificateResource) requiresImport(data acceptance.TestData, endDate string) string {
 	return fmt.Sprintf(`
 %[1]s
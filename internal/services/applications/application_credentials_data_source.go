@@ -0,0 +1,353 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/credentials"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+const credentialTypePassword = "Password"
+
+func applicationCredentialsDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		ReadContext: applicationCredentialsDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"expires_within_days": {
+				Description:  "Return credentials which expire within this number of days",
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				AtLeastOneOf: []string{"expires_within_days", "include_expired", "older_than_days"},
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"include_applications": {
+				Description: "Whether to return credentials belonging to applications",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+
+			"include_expired": {
+				Description:  "Whether to return credentials which have already expired",
+				Type:         pluginsdk.TypeBool,
+				Optional:     true,
+				Default:      false,
+				AtLeastOneOf: []string{"expires_within_days", "include_expired", "older_than_days"},
+			},
+
+			"include_service_principals": {
+				Description: "Whether to return credentials belonging to service principals",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+
+			"older_than_days": {
+				Description:  "Return credentials which became valid more than this number of days ago",
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				AtLeastOneOf: []string{"expires_within_days", "include_expired", "older_than_days"},
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"credentials": {
+				Description: "A list of credentials matching the specified criteria",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"client_id": {
+							Description: "The client ID of the application to which the credential belongs",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The display name of the credential",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"end_date": {
+							Description: "The end date until which the credential is valid, formatted as an RFC3339 date string",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"expired": {
+							Description: "Whether the credential has expired",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+
+						"key_id": {
+							Description: "The unique key ID of the credential",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"object_display_name": {
+							Description: "The display name of the application or service principal to which the credential belongs",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"object_id": {
+							Description: "The object ID of the application or service principal to which the credential belongs",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"object_type": {
+							Description: "The type of object to which the credential belongs, either `Application` or `ServicePrincipal`",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"owner_object_ids": {
+							Description: "The object IDs of the owners of the application or service principal to which the credential belongs",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"start_date": {
+							Description: "The start date from which the credential is valid, formatted as an RFC3339 date string",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"type": {
+							Description: "The type of the credential, either `Password`, `AsymmetricX509Cert`, `X509CertAndPassword` or `Symmetric`",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"usage": {
+							Description: "The usage of a key credential, either `Sign` or `Verify`",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// applicationCredentialsFilter describes the criteria for credentials returned by the azuread_application_credentials
+// data source. A credential is returned when it matches any of the criteria.
+type applicationCredentialsFilter struct {
+	ExpiresBefore  *time.Time
+	StartedBefore  *time.Time
+	IncludeExpired bool
+	Now            time.Time
+}
+
+// Matches returns whether a credential with the specified RFC3339 start and end dates matches the filter, and whether
+// the credential has expired
+func (f applicationCredentialsFilter) Matches(startDate, endDate string) (matches bool, expired bool) {
+	if end, err := time.Parse(time.RFC3339, endDate); err == nil {
+		if end.Before(f.Now) {
+			return f.IncludeExpired, true
+		}
+		if f.ExpiresBefore != nil && end.Before(*f.ExpiresBefore) {
+			return true, false
+		}
+	}
+
+	if start, err := time.Parse(time.RFC3339, startDate); err == nil && f.StartedBefore != nil && start.Before(*f.StartedBefore) {
+		return true, false
+	}
+
+	return false, false
+}
+
+func applicationCredentialsDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	applicationClient := meta.(*clients.Client).Applications.ApplicationClient
+	servicePrincipalClient := meta.(*clients.Client).Applications.ServicePrincipalClient
+
+	filter := applicationCredentialsFilter{
+		IncludeExpired: d.Get("include_expired").(bool),
+		Now:            time.Now().UTC(),
+	}
+	if v, ok := d.GetOk("expires_within_days"); ok {
+		filter.ExpiresBefore = pointer.To(filter.Now.AddDate(0, 0, v.(int)))
+	}
+	if v, ok := d.GetOk("older_than_days"); ok {
+		filter.StartedBefore = pointer.To(filter.Now.AddDate(0, 0, -v.(int)))
+	}
+
+	fieldsToSelect := []string{"appId", "displayName", "id", "keyCredentials", "passwordCredentials"}
+	expandOwners := &odata.Expand{Relationship: "owners", Select: []string{"id"}}
+
+	result := make([]map[string]interface{}, 0)
+
+	if d.Get("include_applications").(bool) {
+		resp, err := applicationClient.ListApplications(ctx, application.ListApplicationsOperationOptions{
+			Expand: expandOwners,
+			Select: &fieldsToSelect,
+		})
+		if err != nil {
+			return tf.ErrorDiagF(err, "Retrieving applications")
+		}
+		if resp.Model == nil {
+			return tf.ErrorDiagF(errors.New("API returned nil result"), "Retrieving applications")
+		}
+
+		for _, app := range *resp.Model {
+			object := map[string]interface{}{
+				"client_id":           app.AppId.GetOrZero(),
+				"object_display_name": app.DisplayName.GetOrZero(),
+				"object_id":           pointer.From(app.Id),
+				"object_type":         "Application",
+				"owner_object_ids":    flattenApplicationCredentialsOwners(app.Owners),
+			}
+			flattened, err := flattenApplicationCredentials(filter, object, app.KeyCredentials, app.PasswordCredentials)
+			if err != nil {
+				return tf.ErrorDiagF(err, "Flattening credentials for application with object ID %q", pointer.From(app.Id))
+			}
+			result = append(result, flattened...)
+		}
+	}
+
+	if d.Get("include_service_principals").(bool) {
+		resp, err := servicePrincipalClient.ListServicePrincipals(ctx, serviceprincipal.ListServicePrincipalsOperationOptions{
+			Expand: expandOwners,
+			Select: &fieldsToSelect,
+		})
+		if err != nil {
+			return tf.ErrorDiagF(err, "Retrieving service principals")
+		}
+		if resp.Model == nil {
+			return tf.ErrorDiagF(errors.New("API returned nil result"), "Retrieving service principals")
+		}
+
+		for _, servicePrincipal := range *resp.Model {
+			object := map[string]interface{}{
+				"client_id":           servicePrincipal.AppId.GetOrZero(),
+				"object_display_name": servicePrincipal.DisplayName.GetOrZero(),
+				"object_id":           pointer.From(servicePrincipal.Id),
+				"object_type":         "ServicePrincipal",
+				"owner_object_ids":    flattenApplicationCredentialsOwners(servicePrincipal.Owners),
+			}
+			flattened, err := flattenApplicationCredentials(filter, object, servicePrincipal.KeyCredentials, servicePrincipal.PasswordCredentials)
+			if err != nil {
+				return tf.ErrorDiagF(err, "Flattening credentials for service principal with object ID %q", pointer.From(servicePrincipal.Id))
+			}
+			result = append(result, flattened...)
+		}
+	}
+
+	keyIds := make([]string, 0, len(result))
+	for _, v := range result {
+		keyIds = append(keyIds, v["key_id"].(string))
+	}
+
+	// Generate a unique ID based on the filters and result
+	h := sha1.New()
+	if _, err := h.Write([]byte(fmt.Sprintf("%d/%d/%t/%t/%t/%s", d.Get("expires_within_days").(int), d.Get("older_than_days").(int), filter.IncludeExpired, d.Get("include_applications").(bool), d.Get("include_service_principals").(bool), strings.Join(keyIds, "/")))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for application credentials")
+	}
+
+	d.SetId("applicationCredentials#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "credentials", result)
+
+	return nil
+}
+
+// flattenApplicationCredentials returns the key and password credentials matching the filter, each including the
+// attributes of the application or service principal to which it belongs. Credentials are decoded using the same helpers
+// as the credential resources, so that key IDs, display names and dates are reported consistently.
+func flattenApplicationCredentials(filter applicationCredentialsFilter, object map[string]interface{}, keyCredentials *[]stable.KeyCredential, passwordCredentials *[]stable.PasswordCredential) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0)
+
+	add := func(keyId, credentialType, usage, displayName, startDate, endDate string) {
+		matches, expired := filter.Matches(startDate, endDate)
+		if !matches {
+			return
+		}
+
+		credential := map[string]interface{}{
+			"display_name": displayName,
+			"end_date":     endDate,
+			"expired":      expired,
+			"key_id":       keyId,
+			"start_date":   startDate,
+			"type":         credentialType,
+			"usage":        usage,
+		}
+		for k, v := range object {
+			credential[k] = v
+		}
+		result = append(result, credential)
+	}
+
+	for _, keyId := range applicationCredentialKeyIds(keyCredentials, passwordCredentials) {
+		if credential := credentials.GetKeyCredential(keyCredentials, keyId); credential != nil {
+			add(keyId, credential.Type.GetOrZero(), credential.Usage.GetOrZero(), credential.DisplayName.GetOrZero(), credential.StartDateTime.GetOrZero(), credential.EndDateTime.GetOrZero())
+		} else if credential := credentials.GetPasswordCredential(passwordCredentials, keyId); credential != nil {
+			displayName, err := credentials.PasswordCredentialDisplayName(*credential)
+			if err != nil {
+				return nil, fmt.Errorf("password credential %q: %+v", keyId, err)
+			}
+			add(keyId, credentialTypePassword, "", pointer.From(displayName), credential.StartDateTime.GetOrZero(), credential.EndDateTime.GetOrZero())
+		}
+	}
+
+	return result, nil
+}
+
+// applicationCredentialKeyIds returns the key IDs of the specified key and password credentials, in the order returned
+// by the API. Credentials without a key ID are omitted.
+func applicationCredentialKeyIds(keyCredentials *[]stable.KeyCredential, passwordCredentials *[]stable.PasswordCredential) []string {
+	result := make([]string, 0)
+	for _, credential := range pointer.From(keyCredentials) {
+		if keyId := credential.KeyId.GetOrZero(); keyId != "" {
+			result = append(result, keyId)
+		}
+	}
+	for _, credential := range pointer.From(passwordCredentials) {
+		if keyId := credential.KeyId.GetOrZero(); keyId != "" {
+			result = append(result, keyId)
+		}
+	}
+	return result
+}
+
+func flattenApplicationCredentialsOwners(owners *[]stable.DirectoryObject) []string {
+	result := make([]string, 0)
+	if owners != nil {
+		for _, owner := range *owners {
+			if id := owner.DirectoryObject().Id; id != nil {
+				result = append(result, *id)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ApplicationCredentialsDataSource struct{}
+
+func TestAccApplicationCredentialsDataSource_expiresWithinDays(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_credentials", "test")
+	endDate := time.Now().AddDate(0, 0, 10).UTC().Format(time.RFC3339)
+	r := ApplicationCredentialsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.expiresWithinDays(data, endDate),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("credentials.#").Exists(),
+				check.That(data.ResourceName).Key("credentials.0.end_date").Exists(),
+				check.That(data.ResourceName).Key("credentials.0.key_id").Exists(),
+				check.That(data.ResourceName).Key("credentials.0.object_id").Exists(),
+				check.That(data.ResourceName).Key("credentials.0.type").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccApplicationCredentialsDataSource_olderThanDays(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_credentials", "test")
+	r := ApplicationCredentialsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.olderThanDays(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("credentials.#").Exists(),
+			),
+		},
+	})
+}
+
+func (ApplicationCredentialsDataSource) expiresWithinDays(data acceptance.TestData, endDate string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctestApp-%[1]d"
+}
+
+resource "azuread_application_password" "test" {
+  application_id = azuread_application.test.id
+  display_name   = "acctest-%[1]d"
+  end_date       = "%[2]s"
+}
+
+data "azuread_application_credentials" "test" {
+  expires_within_days        = 30
+  include_service_principals = false
+
+  depends_on = [azuread_application_password.test]
+}
+`, data.RandomInteger, endDate)
+}
+
+func (ApplicationCredentialsDataSource) olderThanDays() string {
+	return `
+data "azuread_application_credentials" "test" {
+  older_than_days = 365
+  include_expired = true
+}
+`
+}
@@ -371,6 +371,30 @@ func applicationDataSource() *pluginsdk.Resource {
 				Computed:    true,
 			},
 
+			"request_signature_verification": {
+				Description: "Settings for verifying the signature of SAML authentication requests sent by the application",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"allowed_weak_algorithms": {
+							Description: "Weak signing algorithms which are accepted when verifying signed authentication requests",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"signed_request_required": {
+							Description: "Whether authentication requests for the application must be signed",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+
 			"required_resource_access": {
 				Type:     pluginsdk.TypeList,
 				Computed: true,
@@ -412,6 +436,45 @@ func applicationDataSource() *pluginsdk.Resource {
 				Computed:    true,
 			},
 
+			"service_principal_lock_configuration": {
+				Description: "The lock configuration for sensitive properties of the service principals created for the application in other tenants",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"all_properties": {
+							Description: "Whether all sensitive properties of the service principal are locked, including credentials and the token encryption key ID",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+
+						"credentials_with_usage_sign": {
+							Description: "Whether key and password credentials with usage `Sign` are locked",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+
+						"credentials_with_usage_verify": {
+							Description: "Whether key and password credentials with usage `Verify` are locked",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+
+						"enabled": {
+							Description: "Whether the service principal lock configuration is enabled",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+
+						"token_encryption_key_id": {
+							Description: "Whether the token encryption key ID is locked",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+
 			"sign_in_audience": {
 				Description: "The Microsoft account types that are supported for the current application",
 				Type:        pluginsdk.TypeString,
@@ -456,6 +519,12 @@ func applicationDataSource() *pluginsdk.Resource {
 				Computed:    true,
 			},
 
+			"token_encryption_key_id": {
+				Description: "The key ID of the certificate used to encrypt tokens issued for the application",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
 			"web": {
 				Type:     pluginsdk.TypeList,
 				Computed: true,
@@ -601,11 +670,14 @@ func applicationDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, m
 	tf.Set(d, "optional_claims", flattenApplicationOptionalClaims(app.OptionalClaims))
 	tf.Set(d, "public_client", flattenApplicationPublicClient(app.PublicClient))
 	tf.Set(d, "publisher_domain", app.PublisherDomain.GetOrZero())
+	tf.Set(d, "request_signature_verification", flattenApplicationRequestSignatureVerification(app.RequestSignatureVerification))
 	tf.Set(d, "required_resource_access", flattenApplicationRequiredResourceAccess(app.RequiredResourceAccess))
 	tf.Set(d, "service_management_reference", app.ServiceManagementReference.GetOrZero())
+	tf.Set(d, "service_principal_lock_configuration", flattenApplicationServicePrincipalLockConfiguration(app.ServicePrincipalLockConfiguration))
 	tf.Set(d, "sign_in_audience", app.SignInAudience.GetOrZero())
 	tf.Set(d, "single_page_application", flattenApplicationSpa(app.Spa))
 	tf.Set(d, "tags", tf.FlattenStringSlicePtr(app.Tags))
+	tf.Set(d, "token_encryption_key_id", app.TokenEncryptionKeyId.GetOrZero())
 	tf.Set(d, "web", flattenApplicationWeb(app.Web))
 
 	if app.Api != nil {
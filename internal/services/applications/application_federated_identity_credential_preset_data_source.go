@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+const federatedIdentityCredentialAudience = "api://AzureADTokenExchange"
+
+func applicationFederatedIdentityCredentialPresetDataSource() *pluginsdk.Resource {
+	presets := []string{"github_actions", "gitlab", "kubernetes", "terraform_cloud"}
+
+	return &pluginsdk.Resource{
+		ReadContext: applicationFederatedIdentityCredentialPresetDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"github_actions": {
+				Description:  "Build a federated identity credential for GitHub Actions workflows",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: presets,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"entity_type": {
+							Description:  "The type of entity for which workflows are trusted",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(possibleValuesForFederatedIdentityCredentialGitHubEntityType, false),
+						},
+
+						"entity_value": {
+							Description:  "The name of the branch, environment or tag for which workflows are trusted, which may contain `*` wildcards",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"issuer": {
+							Description:  "The issuer URL of the GitHub Actions OIDC provider",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      "https://token.actions.githubusercontent.com",
+							ValidateFunc: validation.IsHttpsUrl,
+						},
+
+						"organization": {
+							Description:  "The name of the GitHub organization or user owning the repository",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"repository": {
+							Description:  "The name of the GitHub repository, which may contain `*` wildcards",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"gitlab": {
+				Description:  "Build a federated identity credential for GitLab CI/CD pipelines",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: presets,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"issuer": {
+							Description:  "The URL of the GitLab instance",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      "https://gitlab.com",
+							ValidateFunc: validation.IsHttpsUrl,
+						},
+
+						"project_path": {
+							Description:  "The path of the GitLab project, including its group, e.g. `my-group/my-project`",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"ref": {
+							Description:  "The name of the branch or tag for which pipelines are trusted, which may contain `*` wildcards",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"ref_type": {
+							Description:  "The type of ref for which pipelines are trusted",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(possibleValuesForFederatedIdentityCredentialGitLabRefType, false),
+						},
+					},
+				},
+			},
+
+			"kubernetes": {
+				Description:  "Build a federated identity credential for a Kubernetes service account, such as with Azure Kubernetes Service workload identity",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: presets,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"issuer": {
+							Description:  "The OIDC issuer URL of the cluster",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsHttpsUrl,
+						},
+
+						"namespace": {
+							Description:  "The namespace of the service account, which may contain `*` wildcards",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"service_account": {
+							Description:  "The name of the service account, which may contain `*` wildcards",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"terraform_cloud": {
+				Description:  "Build a federated identity credential for HCP Terraform or Terraform Enterprise runs",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: presets,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"issuer": {
+							Description:  "The URL of the HCP Terraform or Terraform Enterprise instance",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      "https://app.terraform.io",
+							ValidateFunc: validation.IsHttpsUrl,
+						},
+
+						"organization": {
+							Description:  "The name of the organization",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"project": {
+							Description:  "The name of the project, which may contain `*` wildcards",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      "*",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"run_phase": {
+							Description:  "The run phase for which runs are trusted, either `plan` or `apply`, or `*` for both",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      "*",
+							ValidateFunc: validation.StringInSlice([]string{"*", "apply", "plan"}, false),
+						},
+
+						"workspace": {
+							Description:  "The name of the workspace, which may contain `*` wildcards",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"audiences": {
+				Description: "The audiences to be accepted in the external token",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"claims_matching_expression": {
+				Description: "An expression matching the subject of the external token, when the preset contains wildcards",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"issuer": {
+				Description: "The URL of the external identity provider",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"subject": {
+				Description: "The subject of the external token, when the preset does not contain wildcards",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func applicationFederatedIdentityCredentialPresetDataSourceRead(_ context.Context, d *pluginsdk.ResourceData, _ interface{}) pluginsdk.Diagnostics {
+	var preset federatedIdentityCredentialPreset
+
+	if v := d.Get("github_actions").([]interface{}); len(v) > 0 && v[0] != nil {
+		in := v[0].(map[string]interface{})
+		entityType := in["entity_type"].(string)
+		entityValue := in["entity_value"].(string)
+
+		if entityType != FederatedIdentityCredentialGitHubEntityTypePullRequest && entityValue == "" {
+			return tf.ErrorDiagPathF(errors.New("`entity_value` is required"), "github_actions.0.entity_value", "`entity_value` must be specified when `entity_type` is %q", entityType)
+		}
+		if entityType == FederatedIdentityCredentialGitHubEntityTypePullRequest && entityValue != "" {
+			return tf.ErrorDiagPathF(errors.New("`entity_value` is not supported"), "github_actions.0.entity_value", "`entity_value` cannot be specified when `entity_type` is %q", entityType)
+		}
+
+		preset = gitHubActionsFederatedIdentityCredentialPreset(in["issuer"].(string), in["organization"].(string), in["repository"].(string), entityType, entityValue)
+	}
+
+	if v := d.Get("gitlab").([]interface{}); len(v) > 0 && v[0] != nil {
+		in := v[0].(map[string]interface{})
+		preset = gitLabFederatedIdentityCredentialPreset(in["issuer"].(string), in["project_path"].(string), in["ref_type"].(string), in["ref"].(string))
+	}
+
+	if v := d.Get("kubernetes").([]interface{}); len(v) > 0 && v[0] != nil {
+		in := v[0].(map[string]interface{})
+		preset = kubernetesFederatedIdentityCredentialPreset(in["issuer"].(string), in["namespace"].(string), in["service_account"].(string))
+	}
+
+	if v := d.Get("terraform_cloud").([]interface{}); len(v) > 0 && v[0] != nil {
+		in := v[0].(map[string]interface{})
+		preset = terraformCloudFederatedIdentityCredentialPreset(in["issuer"].(string), in["organization"].(string), in["project"].(string), in["workspace"].(string), in["run_phase"].(string))
+	}
+
+	// Generate a unique ID based on the result
+	h := sha1.New()
+	if _, err := h.Write([]byte(preset.Issuer + "/" + preset.Subject + "/" + preset.ClaimsMatchingExpression)); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for federated identity credential preset")
+	}
+
+	d.SetId("federatedIdentityCredentialPreset#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "audiences", []string{federatedIdentityCredentialAudience})
+	tf.Set(d, "claims_matching_expression", preset.ClaimsMatchingExpression)
+	tf.Set(d, "issuer", preset.Issuer)
+	tf.Set(d, "subject", preset.Subject)
+
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ApplicationFederatedIdentityCredentialPresetDataSource struct{}
+
+func TestAccApplicationFederatedIdentityCredentialPresetDataSource_gitHubActions(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_federated_identity_credential_preset", "test")
+	r := ApplicationFederatedIdentityCredentialPresetDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.gitHubActions(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("audiences.0").HasValue("api://AzureADTokenExchange"),
+				check.That(data.ResourceName).Key("issuer").HasValue("https://token.actions.githubusercontent.com"),
+				check.That(data.ResourceName).Key("subject").HasValue("repo:contoso/contoso-repo:environment:Production"),
+				check.That(data.ResourceName).Key("claims_matching_expression").HasValue(""),
+			),
+		},
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredentialPresetDataSource_gitHubActionsWildcard(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_federated_identity_credential_preset", "test")
+	r := ApplicationFederatedIdentityCredentialPresetDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.gitHubActionsWildcard(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("subject").HasValue(""),
+				check.That(data.ResourceName).Key("claims_matching_expression").HasValue("claims['sub'] matches 'repo:contoso/contoso-repo:ref:refs/heads/release/*'"),
+			),
+		},
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredentialPresetDataSource_gitLab(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_federated_identity_credential_preset", "test")
+	r := ApplicationFederatedIdentityCredentialPresetDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.gitLab(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("issuer").HasValue("https://gitlab.com"),
+				check.That(data.ResourceName).Key("subject").HasValue("project_path:contoso/contoso-project:ref_type:branch:ref:main"),
+			),
+		},
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredentialPresetDataSource_kubernetes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_federated_identity_credential_preset", "test")
+	r := ApplicationFederatedIdentityCredentialPresetDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.kubernetes(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("issuer").HasValue("https://westeurope.oic.prod-aks.azure.com/00000000-0000-0000-0000-000000000000/11111111-1111-1111-1111-111111111111/"),
+				check.That(data.ResourceName).Key("subject").HasValue("system:serviceaccount:default:workload"),
+			),
+		},
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredentialPresetDataSource_terraformCloud(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_federated_identity_credential_preset", "test")
+	r := ApplicationFederatedIdentityCredentialPresetDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.terraformCloud(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("issuer").HasValue("https://app.terraform.io"),
+				check.That(data.ResourceName).Key("subject").HasValue(""),
+				check.That(data.ResourceName).Key("claims_matching_expression").HasValue("claims['sub'] matches 'organization:contoso:project:*:workspace:production:run_phase:*'"),
+			),
+		},
+	})
+}
+
+func (ApplicationFederatedIdentityCredentialPresetDataSource) gitHubActions(_ acceptance.TestData) string {
+	return `
+provider "azuread" {}
+
+data "azuread_application_federated_identity_credential_preset" "test" {
+  github_actions {
+    organization = "contoso"
+    repository   = "contoso-repo"
+    entity_type  = "environment"
+    entity_value = "Production"
+  }
+}
+`
+}
+
+func (ApplicationFederatedIdentityCredentialPresetDataSource) gitHubActionsWildcard(_ acceptance.TestData) string {
+	return `
+provider "azuread" {}
+
+data "azuread_application_federated_identity_credential_preset" "test" {
+  github_actions {
+    organization = "contoso"
+    repository   = "contoso-repo"
+    entity_type  = "branch"
+    entity_value = "release/*"
+  }
+}
+`
+}
+
+func (ApplicationFederatedIdentityCredentialPresetDataSource) gitLab(_ acceptance.TestData) string {
+	return `
+provider "azuread" {}
+
+data "azuread_application_federated_identity_credential_preset" "test" {
+  gitlab {
+    project_path = "contoso/contoso-project"
+    ref_type     = "branch"
+    ref          = "main"
+  }
+}
+`
+}
+
+func (ApplicationFederatedIdentityCredentialPresetDataSource) kubernetes(_ acceptance.TestData) string {
+	return `
+provider "azuread" {}
+
+data "azuread_application_federated_identity_credential_preset" "test" {
+  kubernetes {
+    issuer          = "https://westeurope.oic.prod-aks.azure.com/00000000-0000-0000-0000-000000000000/11111111-1111-1111-1111-111111111111/"
+    namespace       = "default"
+    service_account = "workload"
+  }
+}
+`
+}
+
+func (ApplicationFederatedIdentityCredentialPresetDataSource) terraformCloud(_ acceptance.TestData) string {
+	return `
+provider "azuread" {}
+
+data "azuread_application_federated_identity_credential_preset" "test" {
+  terraform_cloud {
+    organization = "contoso"
+    workspace    = "production"
+  }
+}
+`
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
 	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/validate"
 )
 
 func applicationFederatedIdentityCredentialResource() *pluginsdk.Resource {
@@ -37,6 +38,8 @@ func applicationFederatedIdentityCredentialResource() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
 		},
 
+		CustomizeDiff: applicationFederatedIdentityCredentialResourceCustomizeDiff,
+
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
 			_, err := parse.FederatedIdentityCredentialID(id)
 			return err
@@ -78,9 +81,37 @@ func applicationFederatedIdentityCredentialResource() *pluginsdk.Resource {
 			},
 
 			"subject": {
-				Description: "The identifier of the external software workload within the external identity provider. The combination of issuer and subject must be unique on the app.",
-				Type:        pluginsdk.TypeString,
-				Required:    true,
+				Description:  "The identifier of the external software workload within the external identity provider. The combination of issuer and subject must be unique on the app.",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"claims_matching_expression", "subject"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"claims_matching_expression": {
+				Description:  "An expression matching the claims of the external token, used instead of `subject` to trust multiple external workloads with a single credential",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"claims_matching_expression", "subject"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"value": {
+							Description:      "The expression, e.g. `claims['sub'] matches 'repo:contoso/contoso-repo:ref:refs/heads/*'`",
+							Type:             pluginsdk.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validate.ClaimsMatchingExpression,
+						},
+
+						"language_version": {
+							Description:  "The version of the expression language",
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntInSlice([]int{1}),
+						},
+					},
+				},
 			},
 
 			"description": {
@@ -98,8 +129,20 @@ func applicationFederatedIdentityCredentialResource() *pluginsdk.Resource {
 	}
 }
 
+func applicationFederatedIdentityCredentialResourceCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	// Switching between a subject and a claims matching expression requires the credential to be replaced
+	if diff.Id() != "" && diff.HasChange("claims_matching_expression.#") {
+		if err := diff.ForceNew("claims_matching_expression"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func applicationFederatedIdentityCredentialResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics { //nolint
 	client := meta.(*clients.Client).Applications.ApplicationClient
+	clientBeta := meta.(*clients.Client).Applications.ApplicationClientBeta
 	federatedIdentityCredentialClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredential
 
 	applicationId, err := stable.ParseApplicationID(d.Get("application_id").(string))
@@ -120,28 +163,47 @@ func applicationFederatedIdentityCredentialResourceCreate(ctx context.Context, d
 		return tf.ErrorDiagF(errors.New("model was nil"), "retrieving %s", applicationId)
 	}
 
-	credential := stable.FederatedIdentityCredential{
-		Audiences:   tf.ExpandStringSlice(d.Get("audiences").([]interface{})),
-		Description: nullable.Value(d.Get("description").(string)),
-		Issuer:      d.Get("issuer").(string),
-		Name:        d.Get("display_name").(string),
-		Subject:     d.Get("subject").(string),
-	}
+	var credentialId *string
+	if expression := expandFederatedIdentityExpression(d.Get("claims_matching_expression").([]interface{})); expression != nil {
+		credential := flexibleFederatedIdentityCredential{
+			Audiences:                tf.ExpandStringSlice(d.Get("audiences").([]interface{})),
+			ClaimsMatchingExpression: expression,
+			Description:              nullable.Value(d.Get("description").(string)),
+			Issuer:                   d.Get("issuer").(string),
+			Name:                     d.Get("display_name").(string),
+		}
 
-	federatedIdentityCredentialResp, err := federatedIdentityCredentialClient.CreateFederatedIdentityCredential(ctx, *applicationId, credential, federatedidentitycredential.DefaultCreateFederatedIdentityCredentialOperationOptions())
-	if err != nil {
-		return tf.ErrorDiagF(err, "Adding federated identity credential for %s", applicationId)
-	}
+		newCredential, err := createFlexibleFederatedIdentityCredential(ctx, clientBeta.Client, *applicationId, credential)
+		if err != nil {
+			return tf.ErrorDiagF(err, "Adding federated identity credential for %s", applicationId)
+		}
+		credentialId = newCredential.Id
+	} else {
+		credential := stable.FederatedIdentityCredential{
+			Audiences:   tf.ExpandStringSlice(d.Get("audiences").([]interface{})),
+			Description: nullable.Value(d.Get("description").(string)),
+			Issuer:      d.Get("issuer").(string),
+			Name:        d.Get("display_name").(string),
+			Subject:     d.Get("subject").(string),
+		}
+
+		federatedIdentityCredentialResp, err := federatedIdentityCredentialClient.CreateFederatedIdentityCredential(ctx, *applicationId, credential, federatedidentitycredential.DefaultCreateFederatedIdentityCredentialOperationOptions())
+		if err != nil {
+			return tf.ErrorDiagF(err, "Adding federated identity credential for %s", applicationId)
+		}
 
-	newCredential := federatedIdentityCredentialResp.Model
-	if newCredential == nil {
-		return tf.ErrorDiagF(errors.New("nil credential received when adding federated identity credential"), "API error adding federated identity credential for %s", applicationId)
+		newCredential := federatedIdentityCredentialResp.Model
+		if newCredential == nil {
+			return tf.ErrorDiagF(errors.New("nil credential received when adding federated identity credential"), "API error adding federated identity credential for %s", applicationId)
+		}
+		credentialId = newCredential.Id
 	}
-	if newCredential.Id == nil {
+
+	if credentialId == nil {
 		return tf.ErrorDiagF(errors.New("nil or empty ID received"), "API error adding federated identity credential for %s", applicationId)
 	}
 
-	id := stable.NewApplicationIdFederatedIdentityCredentialID(applicationId.ApplicationId, *newCredential.Id)
+	id := stable.NewApplicationIdFederatedIdentityCredentialID(applicationId.ApplicationId, *credentialId)
 
 	// Wait for the credential to replicate
 	timeout, _ := ctx.Deadline()
@@ -175,13 +237,14 @@ func applicationFederatedIdentityCredentialResourceCreate(ctx context.Context, d
 	}
 
 	// TODO: migrate this to a stable.ApplicationIdFederatedIdentityCredentialId
-	resourceId := parse.NewCredentialID(applicationId.ApplicationId, "federatedIdentityCredential", *newCredential.Id)
+	resourceId := parse.NewCredentialID(applicationId.ApplicationId, "federatedIdentityCredential", *credentialId)
 	d.SetId(resourceId.String())
 
 	return applicationFederatedIdentityCredentialResourceRead(ctx, d, meta)
 }
 
 func applicationFederatedIdentityCredentialResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics { //nolint
+	clientBeta := meta.(*clients.Client).Applications.ApplicationClientBeta
 	federatedIdentityCredentialClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredential
 
 	id, err := parse.FederatedIdentityCredentialID(d.Id())
@@ -192,6 +255,27 @@ func applicationFederatedIdentityCredentialResourceUpdate(ctx context.Context, d
 	tf.LockByName(applicationResourceName, id.ObjectId)
 	defer tf.UnlockByName(applicationResourceName, id.ObjectId)
 
+	credentialId := stable.NewApplicationIdFederatedIdentityCredentialID(id.ObjectId, id.KeyId)
+
+	if expression := expandFederatedIdentityExpression(d.Get("claims_matching_expression").([]interface{})); expression != nil {
+		credential := flexibleFederatedIdentityCredential{
+			Id:                       pointer.To(id.KeyId),
+			Audiences:                tf.ExpandStringSlice(d.Get("audiences").([]interface{})),
+			ClaimsMatchingExpression: expression,
+			Description:              nullable.Value(d.Get("description").(string)),
+			Issuer:                   d.Get("issuer").(string),
+
+			// Name is immutable but must be specified as it is a required field
+			Name: d.Get("display_name").(string),
+		}
+
+		if err = updateFlexibleFederatedIdentityCredential(ctx, clientBeta.Client, credentialId, credential); err != nil {
+			return tf.ErrorDiagF(err, "Updating federated identity credential with ID %q for application with object ID %q", id.KeyId, id.ObjectId)
+		}
+
+		return applicationFederatedIdentityCredentialResourceRead(ctx, d, meta)
+	}
+
 	credential := stable.FederatedIdentityCredential{
 		Id:          pointer.To(id.KeyId),
 		Audiences:   tf.ExpandStringSlice(d.Get("audiences").([]interface{})),
@@ -203,8 +287,6 @@ func applicationFederatedIdentityCredentialResourceUpdate(ctx context.Context, d
 		Name: d.Get("display_name").(string),
 	}
 
-	credentialId := stable.NewApplicationIdFederatedIdentityCredentialID(id.ObjectId, id.KeyId)
-
 	if _, err = federatedIdentityCredentialClient.UpdateFederatedIdentityCredential(ctx, credentialId, credential, federatedidentitycredential.DefaultUpdateFederatedIdentityCredentialOperationOptions()); err != nil {
 		return tf.ErrorDiagF(err, "Updating federated identity credential with ID %q for application with object ID %q", id.KeyId, id.ObjectId)
 	}
@@ -213,6 +295,7 @@ func applicationFederatedIdentityCredentialResourceUpdate(ctx context.Context, d
 }
 
 func applicationFederatedIdentityCredentialResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics { //nolint
+	clientBeta := meta.(*clients.Client).Applications.ApplicationClientBeta
 	federatedIdentityCredentialClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredential
 
 	id, err := parse.FederatedIdentityCredentialID(d.Id())
@@ -247,6 +330,17 @@ func applicationFederatedIdentityCredentialResourceRead(ctx context.Context, d *
 	tf.Set(d, "issuer", credential.Issuer)
 	tf.Set(d, "subject", credential.Subject)
 
+	// Flexible credentials have no subject, and their claims matching expression can only be retrieved with the Beta API
+	var expression *federatedIdentityExpression
+	if credential.Subject == "" {
+		flexibleCredential, err := getFlexibleFederatedIdentityCredential(ctx, clientBeta.Client, credentialId)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "id", "Retrieving claims matching expression for %s", credentialId)
+		}
+		expression = flexibleCredential.ClaimsMatchingExpression
+	}
+	tf.Set(d, "claims_matching_expression", flattenFederatedIdentityExpression(expression))
+
 	return nil
 }
 
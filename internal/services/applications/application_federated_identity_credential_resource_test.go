@@ -85,6 +85,47 @@ func TestAccApplicationFederatedIdentityCredential_update(t *testing.T) {
 	})
 }
 
+func TestAccApplicationFederatedIdentityCredential_claimsMatchingExpression(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_federated_identity_credential", "test")
+	r := ApplicationFederatedIdentityCredentialResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.claimsMatchingExpression(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("subject").HasValue(""),
+				check.That(data.ResourceName).Key("claims_matching_expression.0.language_version").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("claims_matching_expression.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredential_preset(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_federated_identity_credential", "test")
+	r := ApplicationFederatedIdentityCredentialResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.preset(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("issuer").HasValue("https://token.actions.githubusercontent.com"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (r ApplicationFederatedIdentityCredentialResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.Applications.ApplicationFederatedIdentityCredential
 
@@ -142,3 +183,46 @@ resource "azuread_application_federated_identity_credential" "test" {
 }
 `, r.template(data), data.RandomString, data.UUID())
 }
+
+func (r ApplicationFederatedIdentityCredentialResource) claimsMatchingExpression(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_federated_identity_credential" "test" {
+  application_id = azuread_application.test.id
+  display_name   = "hashitown-%[2]s"
+  audiences      = ["api://AzureADTokenExchange"]
+  issuer         = "https://token.actions.githubusercontent.com"
+
+  claims_matching_expression {
+    value = "claims['sub'] matches 'repo:hashitown/%[2]s:ref:refs/heads/*'"
+  }
+}
+`, r.template(data), data.RandomString)
+}
+
+func (r ApplicationFederatedIdentityCredentialResource) preset(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application_federated_identity_credential_preset" "test" {
+  github_actions {
+    organization = "hashitown"
+    repository   = "%[2]s"
+    entity_type  = "branch"
+    entity_value = "*"
+  }
+}
+
+resource "azuread_application_federated_identity_credential" "test" {
+  application_id = azuread_application.test.id
+  display_name   = "hashitown-%[2]s"
+  audiences      = data.azuread_application_federated_identity_credential_preset.test.audiences
+  issuer         = data.azuread_application_federated_identity_credential_preset.test.issuer
+
+  claims_matching_expression {
+    value = data.azuread_application_federated_identity_credential_preset.test.claims_matching_expression
+  }
+}
+`, r.template(data), data.RandomString)
+}
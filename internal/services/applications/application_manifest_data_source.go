@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/applications"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func applicationManifestDataSource() *pluginsdk.Resource {
+	schema := map[string]*pluginsdk.Schema{
+		"manifest_json": {
+			Description:      "An application manifest to parse, in either the legacy AAD Graph format or the Microsoft Graph format. When `object_id` is specified, the manifest of the application in the Microsoft Graph format",
+			Type:             pluginsdk.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ExactlyOneOf:     []string{"manifest_json", "object_id"},
+			ValidateDiagFunc: validation.ValidateDiag(validation.StringIsJSON),
+		},
+
+		"object_id": {
+			Description:      "The object ID of an existing application for which to retrieve the manifest",
+			Type:             pluginsdk.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ExactlyOneOf:     []string{"manifest_json", "object_id"},
+			ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+		},
+
+		"client_id": {
+			Description: "The Client ID (also called Application ID) specified in the manifest",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"display_name": {
+			Description: "The display name for the application",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"manifest_format": {
+			Description: "The format of the manifest, either `AADGraph` or `MicrosoftGraph`",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+	}
+
+	// The attributes describing the parsed application share their schema with the azuread_application data source
+	applicationSchema := applicationDataSource().Schema
+	for _, k := range []string{
+		"api",
+		"app_role_ids",
+		"app_roles",
+		"fallback_public_client_enabled",
+		"group_membership_claims",
+		"identifier_uris",
+		"marketing_url",
+		"notes",
+		"oauth2_permission_scope_ids",
+		"optional_claims",
+		"privacy_statement_url",
+		"public_client",
+		"required_resource_access",
+		"sign_in_audience",
+		"single_page_application",
+		"support_url",
+		"tags",
+		"terms_of_service_url",
+		"web",
+	} {
+		schema[k] = applicationSchema[k]
+	}
+
+	return &pluginsdk.Resource{
+		ReadContext: applicationManifestDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: schema,
+	}
+}
+
+func applicationManifestDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationClient
+
+	var app *stable.Application
+	var format, manifest string
+
+	if objectId := d.Get("object_id").(string); objectId != "" {
+		id := stable.NewApplicationID(objectId)
+
+		resp, err := client.GetApplication(ctx, id, application.DefaultGetApplicationOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return tf.ErrorDiagPathF(nil, "object_id", "Application with object ID %q was not found", objectId)
+			}
+			return tf.ErrorDiagPathF(err, "object_id", "Retrieving %s", id)
+		}
+
+		app = resp.Model
+		if app == nil {
+			return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+		}
+
+		format = ApplicationManifestFormatMicrosoftGraph
+		if manifest, err = flattenApplicationManifest(*app); err != nil {
+			return tf.ErrorDiagF(err, "Generating manifest for %s", id)
+		}
+	} else {
+		var err error
+		manifest = d.Get("manifest_json").(string)
+		if format, app, err = parseApplicationManifest(manifest); err != nil {
+			return tf.ErrorDiagPathF(err, "manifest_json", "Parsing application manifest")
+		}
+	}
+
+	h := sha1.New()
+	if _, err := h.Write([]byte(manifest)); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for application manifest")
+	}
+
+	d.SetId("applicationManifest#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+
+	tf.Set(d, "api", flattenApplicationApi(app.Api, true))
+	tf.Set(d, "app_role_ids", applications.FlattenAppRoleIDs(app.AppRoles))
+	tf.Set(d, "app_roles", applications.FlattenAppRoles(app.AppRoles))
+	tf.Set(d, "client_id", app.AppId.GetOrZero())
+	tf.Set(d, "display_name", app.DisplayName.GetOrZero())
+	tf.Set(d, "fallback_public_client_enabled", app.IsFallbackPublicClient.GetOrZero())
+	tf.Set(d, "group_membership_claims", flattenApplicationGroupMembershipClaims(app.GroupMembershipClaims))
+	tf.Set(d, "identifier_uris", tf.FlattenStringSlicePtr(app.IdentifierUris))
+	tf.Set(d, "manifest_format", format)
+	tf.Set(d, "manifest_json", manifest)
+	tf.Set(d, "notes", app.Notes.GetOrZero())
+	tf.Set(d, "object_id", pointer.From(app.Id))
+	tf.Set(d, "optional_claims", flattenApplicationOptionalClaims(app.OptionalClaims))
+	tf.Set(d, "public_client", flattenApplicationPublicClient(app.PublicClient))
+	tf.Set(d, "required_resource_access", flattenApplicationRequiredResourceAccess(app.RequiredResourceAccess))
+	tf.Set(d, "sign_in_audience", app.SignInAudience.GetOrZero())
+	tf.Set(d, "single_page_application", flattenApplicationSpa(app.Spa))
+	tf.Set(d, "tags", tf.FlattenStringSlicePtr(app.Tags))
+	tf.Set(d, "web", flattenApplicationWeb(app.Web))
+
+	if app.Api != nil {
+		tf.Set(d, "oauth2_permission_scope_ids", applications.FlattenOAuth2PermissionScopeIDs(app.Api.OAuth2PermissionScopes))
+	}
+
+	if app.Info != nil {
+		tf.Set(d, "marketing_url", app.Info.MarketingUrl.GetOrZero())
+		tf.Set(d, "privacy_statement_url", app.Info.PrivacyStatementUrl.GetOrZero())
+		tf.Set(d, "support_url", app.Info.SupportUrl.GetOrZero())
+		tf.Set(d, "terms_of_service_url", app.Info.TermsOfServiceUrl.GetOrZero())
+	}
+
+	return nil
+}
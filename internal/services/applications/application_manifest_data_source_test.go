@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ApplicationManifestDataSource struct{}
+
+func TestAccApplicationManifestDataSource_aadGraph(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_manifest", "test")
+	r := ApplicationManifestDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.aadGraph(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("manifest_format").HasValue("AADGraph"),
+				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctest-APP-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("api.0.oauth2_permission_scopes.#").HasValue("1"),
+				check.That(data.ResourceName).Key("api.0.requested_access_token_version").HasValue("2"),
+				check.That(data.ResourceName).Key("app_roles.#").HasValue("1"),
+				check.That(data.ResourceName).Key("required_resource_access.#").HasValue("1"),
+				check.That(data.ResourceName).Key("single_page_application.0.redirect_uris.#").HasValue("1"),
+				check.That(data.ResourceName).Key("web.0.redirect_uris.#").HasValue("1"),
+				check.That(data.ResourceName).Key("web.0.implicit_grant.0.id_token_issuance_enabled").HasValue("true"),
+			),
+		},
+	})
+}
+
+func TestAccApplicationManifestDataSource_microsoftGraph(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_manifest", "test")
+	r := ApplicationManifestDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.microsoftGraph(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("manifest_format").HasValue("MicrosoftGraph"),
+				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctest-APP-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("api.0.oauth2_permission_scopes.#").HasValue("1"),
+				check.That(data.ResourceName).Key("optional_claims.0.id_token.#").HasValue("1"),
+				check.That(data.ResourceName).Key("web.0.redirect_uris.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAccApplicationManifestDataSource_existingApplication(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_manifest", "test")
+	r := ApplicationManifestDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.existingApplication(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("manifest_format").HasValue("MicrosoftGraph"),
+				check.That(data.ResourceName).Key("manifest_json").Exists(),
+				check.That(data.ResourceName).Key("client_id").Exists(),
+				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctest-APP-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("app_roles.#").HasValue("1"),
+				check.That("data.azuread_application_manifest.reparsed").Key("app_roles.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (ApplicationManifestDataSource) aadGraph(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_application_manifest" "test" {
+  manifest_json = jsonencode({
+    accessTokenAcceptedVersion     = 2
+    appId                          = "%[2]s"
+    name                           = "acctest-APP-%[1]d"
+    oauth2AllowIdTokenImplicitFlow = true
+    signInAudience                 = "AzureADMyOrg"
+
+    appRoles = [{
+      allowedMemberTypes = ["User"]
+      description        = "Administrators"
+      displayName        = "Admin"
+      id                 = "%[3]s"
+      isEnabled          = true
+      value              = "Admin"
+    }]
+
+    oauth2Permissions = [{
+      adminConsentDescription = "Access the application"
+      adminConsentDisplayName = "Access"
+      id                      = "%[4]s"
+      isEnabled               = true
+      type                    = "User"
+      value                   = "user_impersonation"
+    }]
+
+    replyUrlsWithType = [
+      { type = "Web", url = "https://app.hashitown-%[1]d.com/signin" },
+      { type = "Spa", url = "https://spa.hashitown-%[1]d.com/" },
+    ]
+
+    requiredResourceAccess = [{
+      resourceAppId  = "00000003-0000-0000-c000-000000000000"
+      resourceAccess = [{ id = "e1fe6dd8-ba31-4d61-89e7-88639da4683d", type = "Scope" }]
+    }]
+  })
+}
+`, data.RandomInteger, data.UUID(), data.UUID(), data.UUID())
+}
+
+func (ApplicationManifestDataSource) microsoftGraph(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_application_manifest" "test" {
+  manifest_json = jsonencode({
+    displayName    = "acctest-APP-%[1]d"
+    signInAudience = "AzureADMyOrg"
+
+    api = {
+      requestedAccessTokenVersion = 2
+      oauth2PermissionScopes = [{
+        adminConsentDescription = "Access the application"
+        adminConsentDisplayName = "Access"
+        id                      = "%[2]s"
+        isEnabled               = true
+        type                    = "User"
+        value                   = "user_impersonation"
+      }]
+    }
+
+    optionalClaims = {
+      idToken = [{ name = "email", essential = false }]
+    }
+
+    web = {
+      redirectUris = ["https://app.hashitown-%[1]d.com/signin"]
+    }
+  })
+}
+`, data.RandomInteger, data.UUID())
+}
+
+func (ApplicationManifestDataSource) existingApplication(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  app_role {
+    allowed_member_types = ["User"]
+    description          = "Administrators"
+    display_name         = "Admin"
+    id                   = "%[2]s"
+    value                = "Admin"
+  }
+}
+
+data "azuread_application_manifest" "test" {
+  object_id = azuread_application.test.object_id
+}
+
+data "azuread_application_manifest" "reparsed" {
+  manifest_json = data.azuread_application_manifest.test.manifest_json
+}
+`, data.RandomInteger, data.UUID())
+}
@@ -5,8 +5,8 @@ package applications
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
+	"fmt"
 	"log"
 	"strings"
 	"time"
@@ -31,6 +31,8 @@ func applicationPasswordResource() *pluginsdk.Resource {
 		ReadContext:   applicationPasswordResourceRead,
 		DeleteContext: applicationPasswordResourceDelete,
 
+		CustomizeDiff: applicationPasswordResourceCustomizeDiff,
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(15 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -119,6 +121,65 @@ func applicationPasswordResource() *pluginsdk.Resource {
 	}
 }
 
+func applicationPasswordResourceCustomizeDiff(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+	// All arguments force a new password, so policies only need to be evaluated when a password is being created
+	if diff.Id() != "" && !diff.HasChanges("application_id", "start_date", "end_date", "end_date_relative", "rotate_when_changed") {
+		return nil
+	}
+
+	// Policies can only be evaluated at plan time when the application and the requested dates are known, otherwise
+	// they are evaluated when the password is created
+	config := diff.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	for _, attr := range []string{"application_id", "start_date", "end_date"} {
+		if !config.GetAttr(attr).IsWhollyKnown() {
+			return nil
+		}
+	}
+
+	applicationId, err := stable.ParseApplicationID(diff.Get("application_id").(string))
+	if err != nil {
+		return nil
+	}
+
+	data := make(map[string]interface{})
+	for _, attr := range []string{"start_date", "end_date"} {
+		if v := config.GetAttr(attr); !v.IsNull() {
+			data[attr] = v.AsString()
+		}
+	}
+	credential, err := credentials.PasswordCredential(data)
+	if err != nil {
+		return nil
+	}
+
+	client := meta.(*clients.Client).Applications.ApplicationClient
+	policiesClient := meta.(*clients.Client).Policies
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	resp, err := client.GetApplication(ctx, *applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil || resp.Model == nil || resp.Model.Id == nil {
+		// The application may not exist yet, in which case policies are evaluated when the password is created
+		log.Printf("[DEBUG] Unable to retrieve %s to evaluate app management policies for password: %v", applicationId, err)
+		return nil
+	}
+
+	restrictions, diags := applicationPasswordPolicyRestrictions(ctx, policiesClient.ApplicationAppManagementPolicyClient, policiesClient.DefaultAppManagementPolicyClient, applicationId.ApplicationId)
+	if diags != nil {
+		log.Printf("[DEBUG] Unable to evaluate app management policies for password for %s, they will be evaluated when the password is created", applicationId)
+		return nil
+	}
+
+	if err = applicationPasswordRestrictionsViolation(restrictions, *resp.Model, *credential); err != nil {
+		return fmt.Errorf("password for %s would be rejected by an app management policy: %v", applicationId, err)
+	}
+
+	return nil
+}
+
 func applicationPasswordResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics { //nolint
 	client := meta.(*clients.Client).Applications.ApplicationClient
 
@@ -155,6 +216,12 @@ func applicationPasswordResourceCreate(ctx context.Context, d *pluginsdk.Resourc
 		return tf.ErrorDiagF(errors.New("nil application or application with nil ID was returned"), "API error retrieving %s", applicationId)
 	}
 
+	policiesClient := meta.(*clients.Client).Policies
+	policyWarnings, err := applicationPasswordPolicyViolation(ctx, policiesClient.ApplicationAppManagementPolicyClient, policiesClient.DefaultAppManagementPolicyClient, *app, *credential)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "end_date", "Password for %s would be rejected by an app management policy", applicationId)
+	}
+
 	request := application.AddPasswordRequest{
 		PasswordCredential: credential,
 	}
@@ -213,7 +280,7 @@ func applicationPasswordResourceCreate(ctx context.Context, d *pluginsdk.Resourc
 	d.SetId(id.String())
 	d.Set("value", newCredential.SecretText.GetOrZero())
 
-	return applicationPasswordResourceRead(ctx, d, meta)
+	return append(policyWarnings, applicationPasswordResourceRead(ctx, d, meta)...)
 }
 
 func applicationPasswordResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics { //nolint
@@ -250,14 +317,12 @@ func applicationPasswordResourceRead(ctx context.Context, d *pluginsdk.ResourceD
 
 	tf.Set(d, "application_id", applicationId.ID())
 
-	if credential.DisplayName != nil {
-		tf.Set(d, "display_name", credential.DisplayName.GetOrZero())
-	} else if credential.CustomKeyIdentifier != nil {
-		displayName, err := base64.StdEncoding.DecodeString(credential.CustomKeyIdentifier.GetOrZero())
-		if err != nil {
-			return tf.ErrorDiagPathF(err, "display_name", "Parsing CustomKeyIdentifier")
-		}
-		tf.Set(d, "display_name", string(displayName))
+	displayName, err := credentials.PasswordCredentialDisplayName(*credential)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "display_name", "Parsing display name for password credential %q", id.KeyId)
+	}
+	if displayName != nil {
+		tf.Set(d, "display_name", *displayName)
 	}
 
 	tf.Set(d, "key_id", id.KeyId)
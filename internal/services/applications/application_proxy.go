@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/beta/connectorgroup"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// The SDK does not expose the on-premises publishing profile for Application Proxy, so connector groups and connectors
+// are retrieved using the beta connector group client, which mirrors the equivalent generated operations
+
+type applicationProxyCustomPager struct {
+	NextLink *odata.Link `json:"@odata.nextLink"`
+}
+
+func (p *applicationProxyCustomPager) NextPageLink() *odata.Link {
+	defer func() {
+		p.NextLink = nil
+	}()
+
+	return p.NextLink
+}
+
+// getApplicationProxyObject retrieves a single connector group or connector at the specified path and unmarshals it into model
+func getApplicationProxyObject(ctx context.Context, c *connectorgroup.ConnectorGroupClient, path string, model interface{}) (*http.Response, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       path,
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		if resp != nil {
+			return resp.Response, err
+		}
+		return nil, err
+	}
+
+	if err = resp.Unmarshal(model); err != nil {
+		return resp.Response, err
+	}
+
+	return resp.Response, nil
+}
+
+// listApplicationProxyObjects retrieves a collection of connector groups or connectors at the specified path and unmarshals it into values
+func listApplicationProxyObjects[T any](ctx context.Context, c *connectorgroup.ConnectorGroupClient, path string) (*[]T, *http.Response, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Pager:      &applicationProxyCustomPager{},
+		Path:       path,
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := req.ExecutePaged(ctx)
+	if err != nil {
+		if resp != nil {
+			return nil, resp.Response, err
+		}
+		return nil, nil, err
+	}
+
+	var values struct {
+		Values *[]T `json:"value"`
+	}
+	if err = resp.Unmarshal(&values); err != nil {
+		return nil, resp.Response, err
+	}
+
+	return values.Values, resp.Response, nil
+}
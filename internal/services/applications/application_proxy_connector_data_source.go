@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+)
+
+type ApplicationProxyConnectorDataSourceModel struct {
+	ConnectorGroupIds []string `tfschema:"connector_group_ids"`
+	ExternalIp        string   `tfschema:"external_ip"`
+	MachineName       string   `tfschema:"machine_name"`
+	ObjectId          string   `tfschema:"object_id"`
+	Status            string   `tfschema:"status"`
+	Version           string   `tfschema:"version"`
+}
+
+type ApplicationProxyConnectorDataSource struct{}
+
+var _ sdk.DataSource = ApplicationProxyConnectorDataSource{}
+
+func (r ApplicationProxyConnectorDataSource) ResourceType() string {
+	return "azuread_application_proxy_connector"
+}
+
+func (r ApplicationProxyConnectorDataSource) ModelObject() interface{} {
+	return &ApplicationProxyConnectorDataSourceModel{}
+}
+
+func (r ApplicationProxyConnectorDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"machine_name": {
+			Description:  "The name of the computer on which the connector is installed",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"machine_name", "object_id"},
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"object_id": {
+			Description:  "The object ID of the connector",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"machine_name", "object_id"},
+			ValidateFunc: validation.IsUUID,
+		},
+	}
+}
+
+func (r ApplicationProxyConnectorDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"connector_group_ids": {
+			Description: "A list of object IDs of the connector groups which the connector is a member of",
+			Type:        pluginsdk.TypeList,
+			Computed:    true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"external_ip": {
+			Description: "The external IP address of the connector, as detected by the connector server",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"status": {
+			Description: "The status of the connector",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"version": {
+			Description: "The version of the connector",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func (r ApplicationProxyConnectorDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Applications.ConnectorGroupClientBeta
+
+			var model ApplicationProxyConnectorDataSourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			var connector *beta.Connector
+
+			if model.ObjectId != "" {
+				id := parse.NewApplicationProxyConnectorID(model.ObjectId)
+				connector = &beta.Connector{}
+				if resp, err := getApplicationProxyObject(ctx, client, id.ID(), connector); err != nil {
+					if response.WasNotFound(resp) {
+						return fmt.Errorf("no connector found with object ID: %q", model.ObjectId)
+					}
+					return fmt.Errorf("retrieving %s: %+v", id, err)
+				}
+			} else {
+				connectors, _, err := listApplicationProxyObjects[beta.Connector](ctx, client, "/onPremisesPublishingProfiles/applicationProxy/connectors")
+				if err != nil {
+					return fmt.Errorf("listing connectors: %+v", err)
+				}
+
+				for _, v := range pointer.From(connectors) {
+					if strings.EqualFold(pointer.From(v.MachineName), model.MachineName) {
+						if connector != nil {
+							return fmt.Errorf("more than one connector found with machine name: %q", model.MachineName)
+						}
+						connector = pointer.To(v)
+					}
+				}
+
+				if connector == nil {
+					return fmt.Errorf("no connector found with machine name: %q", model.MachineName)
+				}
+			}
+
+			if connector.Id == nil {
+				return fmt.Errorf("API returned connector with nil object ID")
+			}
+
+			id := parse.NewApplicationProxyConnectorID(*connector.Id)
+
+			connectorGroups, _, err := listApplicationProxyObjects[beta.ConnectorGroup](ctx, client, fmt.Sprintf("%s/memberOf", id.ID()))
+			if err != nil {
+				return fmt.Errorf("listing connector groups for %s: %+v", id, err)
+			}
+
+			state := ApplicationProxyConnectorDataSourceModel{
+				ConnectorGroupIds: make([]string, 0),
+				ExternalIp:        pointer.From(connector.ExternalIp),
+				MachineName:       pointer.From(connector.MachineName),
+				ObjectId:          id.ConnectorId,
+				Status:            string(pointer.From(connector.Status)),
+				Version:           pointer.From(connector.Version),
+			}
+
+			for _, connectorGroup := range pointer.From(connectorGroups) {
+				if connectorGroup.Id != nil {
+					state.ConnectorGroupIds = append(state.ConnectorGroupIds, *connectorGroup.Id)
+				}
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&state)
+		},
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ApplicationProxyConnectorDataSource struct{}
+
+func TestAccApplicationProxyConnectorDataSource_byObjectId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_proxy_connector", "test")
+	r := ApplicationProxyConnectorDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.byObjectId(data),
+			Check:  r.testCheck(data),
+		},
+	})
+}
+
+func TestAccApplicationProxyConnectorDataSource_byMachineName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_proxy_connector", "test")
+	r := ApplicationProxyConnectorDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.byMachineName(data),
+			Check:  r.testCheck(data),
+		},
+	})
+}
+
+func (ApplicationProxyConnectorDataSource) testCheck(data acceptance.TestData) acceptance.TestCheckFunc {
+	return acceptance.ComposeTestCheckFunc(
+		check.That(data.ResourceName).Key("object_id").IsUuid(),
+		check.That(data.ResourceName).Key("machine_name").Exists(),
+		check.That(data.ResourceName).Key("connector_group_ids.#").HasValue("1"),
+		check.That(data.ResourceName).Key("status").Exists(),
+		check.That(data.ResourceName).Key("version").Exists(),
+	)
+}
+
+func (ApplicationProxyConnectorDataSource) template() string {
+	return `
+provider "azuread" {}
+
+data "azuread_application_proxy_connector_group" "test" {
+  name = "Default"
+}
+`
+}
+
+func (r ApplicationProxyConnectorDataSource) byObjectId(_ acceptance.TestData) string {
+	return r.template() + `
+data "azuread_application_proxy_connector" "test" {
+  object_id = data.azuread_application_proxy_connector_group.test.connector_ids[0]
+}
+`
+}
+
+func (r ApplicationProxyConnectorDataSource) byMachineName(_ acceptance.TestData) string {
+	return r.template() + `
+data "azuread_application_proxy_connector" "by_id" {
+  object_id = data.azuread_application_proxy_connector_group.test.connector_ids[0]
+}
+
+data "azuread_application_proxy_connector" "test" {
+  machine_name = data.azuread_application_proxy_connector.by_id.machine_name
+}
+`
+}
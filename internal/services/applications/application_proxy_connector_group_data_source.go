@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+)
+
+type ApplicationProxyConnectorGroupDataSourceModel struct {
+	ConnectorGroupType string   `tfschema:"connector_group_type"`
+	ConnectorIds       []string `tfschema:"connector_ids"`
+	Default            bool     `tfschema:"default"`
+	Name               string   `tfschema:"name"`
+	ObjectId           string   `tfschema:"object_id"`
+	Region             string   `tfschema:"region"`
+}
+
+type ApplicationProxyConnectorGroupDataSource struct{}
+
+var _ sdk.DataSource = ApplicationProxyConnectorGroupDataSource{}
+
+func (r ApplicationProxyConnectorGroupDataSource) ResourceType() string {
+	return "azuread_application_proxy_connector_group"
+}
+
+func (r ApplicationProxyConnectorGroupDataSource) ModelObject() interface{} {
+	return &ApplicationProxyConnectorGroupDataSourceModel{}
+}
+
+func (r ApplicationProxyConnectorGroupDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Description:  "The name of the connector group",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"name", "object_id"},
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"object_id": {
+			Description:  "The object ID of the connector group",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"name", "object_id"},
+			ValidateFunc: validation.IsUUID,
+		},
+	}
+}
+
+func (r ApplicationProxyConnectorGroupDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"connector_group_type": {
+			Description: "The type of hybrid agent in the connector group",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"connector_ids": {
+			Description: "A list of object IDs of the connectors which are members of the connector group",
+			Type:        pluginsdk.TypeList,
+			Computed:    true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"default": {
+			Description: "Whether this is the default connector group",
+			Type:        pluginsdk.TypeBool,
+			Computed:    true,
+		},
+
+		"region": {
+			Description: "The region the connector group is assigned to, for which traffic is optimized",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func (r ApplicationProxyConnectorGroupDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Applications.ConnectorGroupClientBeta
+
+			var model ApplicationProxyConnectorGroupDataSourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			var connectorGroup *beta.ConnectorGroup
+
+			if model.ObjectId != "" {
+				id := parse.NewApplicationProxyConnectorGroupID(model.ObjectId)
+				connectorGroup = &beta.ConnectorGroup{}
+				if resp, err := getApplicationProxyObject(ctx, client, id.ID(), connectorGroup); err != nil {
+					if response.WasNotFound(resp) {
+						return fmt.Errorf("no connector group found with object ID: %q", model.ObjectId)
+					}
+					return fmt.Errorf("retrieving %s: %+v", id, err)
+				}
+			} else {
+				connectorGroups, _, err := listApplicationProxyObjects[beta.ConnectorGroup](ctx, client, "/onPremisesPublishingProfiles/applicationProxy/connectorGroups")
+				if err != nil {
+					return fmt.Errorf("listing connector groups: %+v", err)
+				}
+
+				for _, v := range pointer.From(connectorGroups) {
+					if strings.EqualFold(pointer.From(v.Name), model.Name) {
+						if connectorGroup != nil {
+							return fmt.Errorf("more than one connector group found with name: %q", model.Name)
+						}
+						connectorGroup = pointer.To(v)
+					}
+				}
+
+				if connectorGroup == nil {
+					return fmt.Errorf("no connector group found with name: %q", model.Name)
+				}
+			}
+
+			if connectorGroup.Id == nil {
+				return fmt.Errorf("API returned connector group with nil object ID")
+			}
+
+			id := parse.NewApplicationProxyConnectorGroupID(*connectorGroup.Id)
+
+			connectors, _, err := listApplicationProxyObjects[beta.Connector](ctx, client, fmt.Sprintf("%s/members", id.ID()))
+			if err != nil {
+				return fmt.Errorf("listing connectors for %s: %+v", id, err)
+			}
+
+			state := ApplicationProxyConnectorGroupDataSourceModel{
+				ConnectorGroupType: string(pointer.From(connectorGroup.ConnectorGroupType)),
+				ConnectorIds:       make([]string, 0),
+				Default:            pointer.From(connectorGroup.IsDefault),
+				Name:               pointer.From(connectorGroup.Name),
+				ObjectId:           id.ConnectorGroupId,
+				Region:             string(pointer.From(connectorGroup.Region)),
+			}
+
+			for _, connector := range pointer.From(connectors) {
+				if connector.Id != nil {
+					state.ConnectorIds = append(state.ConnectorIds, *connector.Id)
+				}
+			}
+
+			metadata.SetID(id)
+			return metadata.Encode(&state)
+		},
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ApplicationProxyConnectorGroupDataSource struct{}
+
+func TestAccApplicationProxyConnectorGroupDataSource_byName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_proxy_connector_group", "test")
+	r := ApplicationProxyConnectorGroupDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.byName(data),
+			Check:  r.testCheck(data),
+		},
+	})
+}
+
+func TestAccApplicationProxyConnectorGroupDataSource_byObjectId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_proxy_connector_group", "test")
+	r := ApplicationProxyConnectorGroupDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.byObjectId(data),
+			Check:  r.testCheck(data),
+		},
+	})
+}
+
+func (ApplicationProxyConnectorGroupDataSource) testCheck(data acceptance.TestData) acceptance.TestCheckFunc {
+	return acceptance.ComposeTestCheckFunc(
+		check.That(data.ResourceName).Key("name").HasValue("Default"),
+		check.That(data.ResourceName).Key("object_id").IsUuid(),
+		check.That(data.ResourceName).Key("connector_group_type").Exists(),
+		check.That(data.ResourceName).Key("connector_ids.#").Exists(),
+		check.That(data.ResourceName).Key("region").Exists(),
+	)
+}
+
+func (ApplicationProxyConnectorGroupDataSource) byName(_ acceptance.TestData) string {
+	return `
+provider "azuread" {}
+
+data "azuread_application_proxy_connector_group" "test" {
+  name = "Default"
+}
+`
+}
+
+func (ApplicationProxyConnectorGroupDataSource) byObjectId(_ acceptance.TestData) string {
+	return `
+provider "azuread" {}
+
+data "azuread_application_proxy_connector_group" "default" {
+  name = "Default"
+}
+
+data "azuread_application_proxy_connector_group" "test" {
+  object_id = data.azuread_application_proxy_connector_group.default.object_id
+}
+`
+}
@@ -0,0 +1,531 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	applicationBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/beta/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/beta/connectorgroup"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applicationtemplates/stable/applicationtemplate"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/suppress"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+)
+
+type ApplicationProxyModel struct {
+	DisplayName                         string `tfschema:"display_name"`
+	ExternalUrl                         string `tfschema:"external_url"`
+	InternalUrl                         string `tfschema:"internal_url"`
+	PreAuthenticationType               string `tfschema:"pre_authentication_type"`
+	ConnectorGroupId                    string `tfschema:"connector_group_id"`
+	ApplicationServerTimeout            string `tfschema:"application_server_timeout"`
+	BackendCertificateValidationEnabled bool   `tfschema:"backend_certificate_validation_enabled"`
+	TranslateHostHeaderEnabled          bool   `tfschema:"translate_host_header_enabled"`
+	TranslateLinksInBodyEnabled         bool   `tfschema:"translate_links_in_body_enabled"`
+	HttpOnlyCookieEnabled               bool   `tfschema:"http_only_cookie_enabled"`
+	PersistentCookieEnabled             bool   `tfschema:"persistent_cookie_enabled"`
+	SecureCookieEnabled                 bool   `tfschema:"secure_cookie_enabled"`
+
+	ApplicationId            string `tfschema:"application_id"`
+	ApplicationObjectId      string `tfschema:"application_object_id"`
+	ClientId                 string `tfschema:"client_id"`
+	ServicePrincipalId       string `tfschema:"service_principal_id"`
+	ServicePrincipalObjectId string `tfschema:"service_principal_object_id"`
+}
+
+var _ sdk.ResourceWithUpdate = ApplicationProxyResource{}
+
+type ApplicationProxyResource struct{}
+
+func (r ApplicationProxyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateApplicationProxyID
+}
+
+func (r ApplicationProxyResource) ResourceType() string {
+	return "azuread_application_proxy"
+}
+
+func (r ApplicationProxyResource) ModelObject() interface{} {
+	return &ApplicationProxyModel{}
+}
+
+func (r ApplicationProxyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"display_name": {
+			Description:  "The display name for the application",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"external_url": {
+			Description:  "The published external URL for the application",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsHttpsUrl,
+		},
+
+		"internal_url": {
+			Description:  "The internal URL of the application",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsHttpOrHttpsUrl,
+		},
+
+		"pre_authentication_type": {
+			Description:  "The pre-authentication setting for the application",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Default:      string(beta.ExternalAuthenticationType_AadPreAuthentication),
+			ValidateFunc: validation.StringInSlice(beta.PossibleValuesForExternalAuthenticationType(), false),
+		},
+
+		"connector_group_id": {
+			Description:  "The ID of the connector group used to publish the application",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"application_server_timeout": {
+			Description:      "The duration the connector waits for a response from the backend application before closing the connection",
+			Type:             pluginsdk.TypeString,
+			Optional:         true,
+			Default:          ApplicationProxyServerTimeoutDefault,
+			ValidateFunc:     validation.StringInSlice(possibleValuesForApplicationProxyServerTimeout, true),
+			DiffSuppressFunc: suppress.CaseDifference,
+		},
+
+		"backend_certificate_validation_enabled": {
+			Description: "Whether backend SSL certificate validation is enabled for the application",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+
+		"translate_host_header_enabled": {
+			Description: "Whether the application should translate URLs in response headers",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+
+		"translate_links_in_body_enabled": {
+			Description: "Whether the application should translate URLs in the application body",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+
+		"http_only_cookie_enabled": {
+			Description: "Whether the HTTPOnly cookie flag should be set in HTTP response headers",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+
+		"persistent_cookie_enabled": {
+			Description: "Whether the Persistent cookie flag should be set in HTTP response headers",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+
+		"secure_cookie_enabled": {
+			Description: "Whether the Secure cookie flag should be set in HTTP response headers",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+	}
+}
+
+func (r ApplicationProxyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"application_id": {
+			Description: "The resource ID for this application",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"application_object_id": {
+			Description: "The object ID for this application",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"client_id": {
+			Description: "The client ID (application ID) for this application",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"service_principal_id": {
+			Description: "The resource ID for this service principal",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"service_principal_object_id": {
+			Description: "The object ID for this service principal",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func (r ApplicationProxyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Applications.ApplicationTemplateClient
+			clientBeta := metadata.Client.Applications.ApplicationClientBeta
+
+			var model ApplicationProxyModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// Application Proxy applications are instantiated from the custom (non-gallery) application template, and
+			// are subsequently configured for on-premises publishing
+			templateId := stable.NewApplicationTemplateID(applicationProxyTemplateId)
+
+			request := applicationtemplate.InstantiateRequest{
+				DisplayName: nullable.Value(model.DisplayName),
+			}
+
+			resp, err := client.Instantiate(ctx, templateId, request, applicationtemplate.DefaultInstantiateOperationOptions())
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", templateId, err)
+			}
+			if resp.Model == nil {
+				return fmt.Errorf("creating %s: model was nil", templateId)
+			}
+			if resp.Model.Application == nil || resp.Model.Application.Id == nil {
+				return fmt.Errorf("creating %s: application was nil", templateId)
+			}
+
+			id := parse.NewApplicationProxyID(*resp.Model.Application.Id)
+			applicationId := beta.NewApplicationID(id.ApplicationId)
+
+			if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+				resp, err := clientBeta.GetApplication(ctx, applicationId, applicationBeta.DefaultGetApplicationOperationOptions())
+				if err != nil {
+					if response.WasNotFound(resp.HttpResponse) {
+						return pointer.To(false), nil
+					}
+					return nil, err
+				}
+				return pointer.To(resp.Model != nil), nil
+			}); err != nil {
+				return fmt.Errorf("creating %s: timed out waiting for replication of new application", templateId)
+			}
+
+			metadata.SetID(id)
+
+			tf.LockByName(applicationResourceName, id.ApplicationId)
+			defer tf.UnlockByName(applicationResourceName, id.ApplicationId)
+
+			properties := beta.Application{
+				OnPremisesPublishing: r.expandOnPremisesPublishing(model),
+			}
+
+			if _, err = clientBeta.UpdateApplication(ctx, applicationId, properties, applicationBeta.DefaultUpdateApplicationOperationOptions()); err != nil {
+				return fmt.Errorf("configuring on-premises publishing for %s: %+v", id, err)
+			}
+
+			if err = r.updateUrls(ctx, metadata, *id, "", model.ExternalUrl); err != nil {
+				return err
+			}
+
+			if model.ConnectorGroupId != "" {
+				if err = r.setConnectorGroup(ctx, metadata, *id, model.ConnectorGroupId); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ApplicationProxyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			clientBeta := metadata.Client.Applications.ApplicationClientBeta
+			connectorGroupClient := metadata.Client.Applications.ConnectorGroupClientBeta
+			servicePrincipalClient := metadata.Client.Applications.ServicePrincipalClient
+
+			id, err := parse.ParseApplicationProxyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model ApplicationProxyModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			applicationId := beta.NewApplicationID(id.ApplicationId)
+
+			// The onPremisesPublishing property is only returned when explicitly selected
+			options := applicationBeta.GetApplicationOperationOptions{
+				Select: pointer.To([]string{"appId", "displayName", "onPremisesPublishing"}),
+			}
+
+			resp, err := clientBeta.GetApplication(ctx, applicationId, options)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if resp.Model == nil {
+				return fmt.Errorf("retrieving %s: model was nil", id)
+			}
+
+			app := resp.Model
+			if app.OnPremisesPublishing == nil || app.OnPremisesPublishing.ExternalUrl.GetOrZero() == "" {
+				log.Printf("[DEBUG] %s is no longer configured for on-premises publishing - removing from state!", id)
+				return metadata.MarkAsGone(id)
+			}
+
+			onPremisesPublishing := app.OnPremisesPublishing
+			state := ApplicationProxyModel{
+				DisplayName:                         app.DisplayName.GetOrZero(),
+				ExternalUrl:                         onPremisesPublishing.ExternalUrl.GetOrZero(),
+				InternalUrl:                         onPremisesPublishing.InternalUrl.GetOrZero(),
+				PreAuthenticationType:               string(pointer.From(onPremisesPublishing.ExternalAuthenticationType)),
+				ApplicationServerTimeout:            onPremisesPublishing.ApplicationServerTimeout.GetOrZero(),
+				BackendCertificateValidationEnabled: onPremisesPublishing.IsBackendCertificateValidationEnabled.GetOrZero(),
+				TranslateHostHeaderEnabled:          onPremisesPublishing.IsTranslateHostHeaderEnabled.GetOrZero(),
+				TranslateLinksInBodyEnabled:         onPremisesPublishing.IsTranslateLinksInBodyEnabled.GetOrZero(),
+				HttpOnlyCookieEnabled:               onPremisesPublishing.IsHttpOnlyCookieEnabled.GetOrZero(),
+				PersistentCookieEnabled:             onPremisesPublishing.IsPersistentCookieEnabled.GetOrZero(),
+				SecureCookieEnabled:                 onPremisesPublishing.IsSecureCookieEnabled.GetOrZero(),
+				ApplicationId:                       stable.NewApplicationID(id.ApplicationId).ID(),
+				ApplicationObjectId:                 id.ApplicationId,
+				ClientId:                            app.AppId.GetOrZero(),
+			}
+
+			connectorGroupResp, err := connectorGroupClient.GetConnectorGroup(ctx, applicationId, connectorgroup.DefaultGetConnectorGroupOperationOptions())
+			if err != nil && !response.WasNotFound(connectorGroupResp.HttpResponse) {
+				return fmt.Errorf("retrieving connector group for %s: %+v", id, err)
+			}
+			if connectorGroup := connectorGroupResp.Model; connectorGroup != nil {
+				// Applications which are not assigned a connector group use the default connector group, which is only
+				// recorded when it is configured
+				if model.ConnectorGroupId != "" || !pointer.From(connectorGroup.IsDefault) {
+					state.ConnectorGroupId = pointer.From(connectorGroup.Id)
+				}
+			}
+
+			servicePrincipalOptions := serviceprincipal.ListServicePrincipalsOperationOptions{
+				Filter: pointer.To(fmt.Sprintf("appId eq '%s'", odata.EscapeSingleQuote(state.ClientId))),
+			}
+			servicePrincipalsResp, err := servicePrincipalClient.ListServicePrincipals(ctx, servicePrincipalOptions)
+			if err != nil {
+				return fmt.Errorf("retrieving service principal for %s: %+v", id, err)
+			}
+			if servicePrincipalsResp.Model != nil && len(*servicePrincipalsResp.Model) > 0 {
+				if servicePrincipalObjectId := pointer.From((*servicePrincipalsResp.Model)[0].Id); servicePrincipalObjectId != "" {
+					state.ServicePrincipalId = stable.NewServicePrincipalID(servicePrincipalObjectId).ID()
+					state.ServicePrincipalObjectId = servicePrincipalObjectId
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ApplicationProxyResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			clientBeta := metadata.Client.Applications.ApplicationClientBeta
+			rd := metadata.ResourceData
+
+			id, err := parse.ParseApplicationProxyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model ApplicationProxyModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			tf.LockByName(applicationResourceName, id.ApplicationId)
+			defer tf.UnlockByName(applicationResourceName, id.ApplicationId)
+
+			applicationId := beta.NewApplicationID(id.ApplicationId)
+			properties := beta.Application{}
+			hasChanges := false
+
+			if rd.HasChange("display_name") {
+				properties.DisplayName = nullable.Value(model.DisplayName)
+				hasChanges = true
+			}
+
+			if rd.HasChanges("external_url", "internal_url", "pre_authentication_type", "application_server_timeout", "backend_certificate_validation_enabled", "translate_host_header_enabled", "translate_links_in_body_enabled", "http_only_cookie_enabled", "persistent_cookie_enabled", "secure_cookie_enabled") {
+				properties.OnPremisesPublishing = r.expandOnPremisesPublishing(model)
+				hasChanges = true
+			}
+
+			if hasChanges {
+				if _, err = clientBeta.UpdateApplication(ctx, applicationId, properties, applicationBeta.DefaultUpdateApplicationOperationOptions()); err != nil {
+					return fmt.Errorf("updating %s: %+v", id, err)
+				}
+			}
+
+			if rd.HasChange("external_url") {
+				oldExternalUrl, _ := rd.GetChange("external_url")
+				if err = r.updateUrls(ctx, metadata, *id, oldExternalUrl.(string), model.ExternalUrl); err != nil {
+					return err
+				}
+			}
+
+			if rd.HasChange("connector_group_id") {
+				if model.ConnectorGroupId != "" {
+					err = r.setConnectorGroup(ctx, metadata, *id, model.ConnectorGroupId)
+				} else {
+					err = r.removeConnectorGroup(ctx, metadata, *id)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ApplicationProxyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Applications.ApplicationClient
+
+			id, err := parse.ParseApplicationProxyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			tf.LockByName(applicationResourceName, id.ApplicationId)
+			defer tf.UnlockByName(applicationResourceName, id.ApplicationId)
+
+			applicationId := stable.NewApplicationID(id.ApplicationId)
+
+			if _, err = client.DeleteApplication(ctx, applicationId, application.DefaultDeleteApplicationOperationOptions()); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ApplicationProxyResource) expandOnPremisesPublishing(model ApplicationProxyModel) *beta.OnPremisesPublishing {
+	return &beta.OnPremisesPublishing{
+		ApplicationServerTimeout:              nullable.Value(model.ApplicationServerTimeout),
+		ExternalAuthenticationType:            pointer.To(beta.ExternalAuthenticationType(model.PreAuthenticationType)),
+		ExternalUrl:                           nullable.Value(model.ExternalUrl),
+		InternalUrl:                           nullable.Value(model.InternalUrl),
+		IsBackendCertificateValidationEnabled: nullable.Value(model.BackendCertificateValidationEnabled),
+		IsHttpOnlyCookieEnabled:               nullable.Value(model.HttpOnlyCookieEnabled),
+		IsPersistentCookieEnabled:             nullable.Value(model.PersistentCookieEnabled),
+		IsSecureCookieEnabled:                 nullable.Value(model.SecureCookieEnabled),
+		IsTranslateHostHeaderEnabled:          nullable.Value(model.TranslateHostHeaderEnabled),
+		IsTranslateLinksInBodyEnabled:         nullable.Value(model.TranslateLinksInBodyEnabled),
+	}
+}
+
+// updateUrls sets the identifier URI, redirect URI and home page URL of the application to the external URL, which is
+// required for users to sign in via Application Proxy. Any previous external URL is replaced.
+func (r ApplicationProxyResource) updateUrls(ctx context.Context, metadata sdk.ResourceMetaData, id parse.ApplicationProxyId, oldExternalUrl, newExternalUrl string) error {
+	client := metadata.Client.Applications.ApplicationClient
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	resp, err := client.GetApplication(ctx, applicationId, application.GetApplicationOperationOptions{Select: pointer.To([]string{"identifierUris", "web"})})
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", applicationId, err)
+	}
+	if resp.Model == nil {
+		return fmt.Errorf("retrieving %s: model was nil", applicationId)
+	}
+
+	replaceUrl := func(in *[]string) []string {
+		result := []string{newExternalUrl}
+		for _, v := range pointer.From(in) {
+			if !strings.EqualFold(v, newExternalUrl) && (oldExternalUrl == "" || !strings.EqualFold(v, oldExternalUrl)) {
+				result = append(result, v)
+			}
+		}
+		return result
+	}
+
+	var redirectUris *[]string
+	if resp.Model.Web != nil {
+		redirectUris = resp.Model.Web.RedirectUris
+	}
+
+	properties := stable.Application{
+		IdentifierUris: pointer.To(replaceUrl(resp.Model.IdentifierUris)),
+		Web: &stable.WebApplication{
+			HomePageUrl:  nullable.Value(newExternalUrl),
+			RedirectUris: pointer.To(replaceUrl(redirectUris)),
+		},
+	}
+
+	if _, err = client.UpdateApplication(ctx, applicationId, properties, application.DefaultUpdateApplicationOperationOptions()); err != nil {
+		return fmt.Errorf("setting external URL for %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func (r ApplicationProxyResource) setConnectorGroup(ctx context.Context, metadata sdk.ResourceMetaData, id parse.ApplicationProxyId, connectorGroupId string) error {
+	client := metadata.Client.Applications.ConnectorGroupClientBeta
+
+	ref := beta.ReferenceUpdate{
+		ODataId: pointer.To(client.Client.BaseUri + parse.NewApplicationProxyConnectorGroupID(connectorGroupId).ID()),
+	}
+
+	if _, err := client.SetConnectorGroupRef(ctx, beta.NewApplicationID(id.ApplicationId), ref, connectorgroup.DefaultSetConnectorGroupRefOperationOptions()); err != nil {
+		return fmt.Errorf("assigning connector group %q for %s: %+v", connectorGroupId, id, err)
+	}
+
+	return nil
+}
+
+// removeConnectorGroup unassigns the connector group of the application, which then uses the default connector group
+func (r ApplicationProxyResource) removeConnectorGroup(ctx context.Context, metadata sdk.ResourceMetaData, id parse.ApplicationProxyId) error {
+	client := metadata.Client.Applications.ConnectorGroupClientBeta
+
+	if resp, err := client.RemoveConnectorGroupRef(ctx, beta.NewApplicationID(id.ApplicationId), connectorgroup.DefaultRemoveConnectorGroupRefOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+		return fmt.Errorf("removing connector group for %s: %+v", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	applicationBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/beta/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+)
+
+type ApplicationProxyResource struct{}
+
+func TestAccApplicationProxy_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_proxy", "test")
+	r := ApplicationProxyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("application_object_id").Exists(),
+				check.That(data.ResourceName).Key("client_id").Exists(),
+				check.That(data.ResourceName).Key("connector_group_id").IsEmpty(),
+				check.That(data.ResourceName).Key("pre_authentication_type").HasValue("aadPreAuthentication"),
+				check.That(data.ResourceName).Key("service_principal_object_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplicationProxy_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_proxy", "test")
+	r := ApplicationProxyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("connector_group_id").IsUuid(),
+				check.That(data.ResourceName).Key("pre_authentication_type").HasValue("passthru"),
+				check.That(data.ResourceName).Key("http_only_cookie_enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("translate_links_in_body_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("connector_group_id").IsEmpty(),
+				check.That(data.ResourceName).Key("http_only_cookie_enabled").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ApplicationProxyResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Applications.ApplicationClientBeta
+
+	id, err := parse.ParseApplicationProxyID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := applicationBeta.GetApplicationOperationOptions{
+		Select: pointer.To([]string{"onPremisesPublishing"}),
+	}
+
+	resp, err := client.GetApplication(ctx, beta.NewApplicationID(id.ApplicationId), options)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return pointer.To(resp.Model != nil && resp.Model.OnPremisesPublishing != nil), nil
+}
+
+func (ApplicationProxyResource) template(data acceptance.TestData) string {
+	return `
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+data "azuread_application_proxy_connector_group" "test" {
+  name = "Default"
+}
+
+locals {
+  tenant_prefix = split(".", data.azuread_domains.test.domains[0].domain_name)[0]
+}
+`
+}
+
+func (r ApplicationProxyResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_proxy" "test" {
+  display_name = "acctest-AppProxy-%[2]d"
+  internal_url = "https://acctest-%[2]d.internal/"
+  external_url = "https://acctest%[2]d-${local.tenant_prefix}.msappproxy.net/"
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ApplicationProxyResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_proxy" "test" {
+  display_name       = "acctest-AppProxy-complete-%[2]d"
+  internal_url       = "http://acctest-%[2]d.internal/app/"
+  external_url       = "https://acctest%[2]d-${local.tenant_prefix}.msappproxy.net/"
+  connector_group_id = data.azuread_application_proxy_connector_group.test.object_id
+
+  pre_authentication_type                = "passthru"
+  application_server_timeout             = "Long"
+  backend_certificate_validation_enabled = false
+  translate_host_header_enabled          = false
+  translate_links_in_body_enabled        = true
+  http_only_cookie_enabled               = true
+  persistent_cookie_enabled              = true
+  secure_cookie_enabled                  = false
+}
+`, r.template(data), data.RandomInteger)
+}
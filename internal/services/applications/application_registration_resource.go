@@ -41,9 +41,22 @@ type ApplicationRegistrationModel struct {
 	SignInAudience                     string   `tfschema:"sign_in_audience"`
 	SupportUrl                         string   `tfschema:"support_url"`
 	TermsOfServiceUrl                  string   `tfschema:"terms_of_service_url"`
+
+	ServicePrincipalLockConfiguration []ApplicationRegistrationServicePrincipalLockConfigurationModel `tfschema:"service_principal_lock_configuration"`
+}
+
+type ApplicationRegistrationServicePrincipalLockConfigurationModel struct {
+	AllProperties              bool `tfschema:"all_properties"`
+	CredentialsWithUsageSign   bool `tfschema:"credentials_with_usage_sign"`
+	CredentialsWithUsageVerify bool `tfschema:"credentials_with_usage_verify"`
+	Enabled                    bool `tfschema:"enabled"`
+	TokenEncryptionKeyId       bool `tfschema:"token_encryption_key_id"`
 }
 
-var _ sdk.ResourceWithUpdate = ApplicationRegistrationResource{}
+var (
+	_ sdk.ResourceWithUpdate        = ApplicationRegistrationResource{}
+	_ sdk.ResourceWithCustomizeDiff = ApplicationRegistrationResource{}
+)
 
 type ApplicationRegistrationResource struct{}
 
@@ -147,6 +160,47 @@ func (r ApplicationRegistrationResource) Arguments() map[string]*pluginsdk.Schem
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
 
+		"service_principal_lock_configuration": {
+			Description: "Locks sensitive properties of the service principals created for the application in other tenants",
+			Type:        pluginsdk.TypeList,
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"all_properties": {
+						Description: "Whether all sensitive properties of the service principal are locked, including credentials and the token encryption key ID",
+						Type:        pluginsdk.TypeBool,
+						Optional:    true,
+					},
+
+					"credentials_with_usage_sign": {
+						Description: "Whether key and password credentials with usage `Sign` are locked",
+						Type:        pluginsdk.TypeBool,
+						Optional:    true,
+					},
+
+					"credentials_with_usage_verify": {
+						Description: "Whether key and password credentials with usage `Verify` are locked",
+						Type:        pluginsdk.TypeBool,
+						Optional:    true,
+					},
+
+					"enabled": {
+						Description: "Whether the service principal lock configuration is enabled",
+						Type:        pluginsdk.TypeBool,
+						Required:    true,
+					},
+
+					"token_encryption_key_id": {
+						Description: "Whether the token encryption key ID is locked",
+						Type:        pluginsdk.TypeBool,
+						Optional:    true,
+					},
+				},
+			},
+		},
+
 		"sign_in_audience": {
 			Description:  "The Microsoft account types that are supported for the current application",
 			Type:         pluginsdk.TypeString,
@@ -199,6 +253,26 @@ func (r ApplicationRegistrationResource) Attributes() map[string]*pluginsdk.Sche
 	}
 }
 
+func (r ApplicationRegistrationResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// The lock configuration is computed, so it is only validated when configured
+			config := metadata.ResourceDiff.GetRawConfig().GetAttr("service_principal_lock_configuration")
+			if !config.IsKnown() || config.IsNull() || config.LengthInt() == 0 {
+				return nil
+			}
+
+			var model ApplicationRegistrationModel
+			if err := metadata.DecodeDiff(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			return applicationValidateServicePrincipalLockConfiguration(model.SignInAudience, expandApplicationRegistrationServicePrincipalLockConfiguration(model.ServicePrincipalLockConfiguration))
+		},
+	}
+}
+
 func (r ApplicationRegistrationResource) Create() sdk.ResourceFunc {
 	return sdk.ResourceFunc{
 		Timeout: 10 * time.Minute,
@@ -240,6 +314,11 @@ func (r ApplicationRegistrationResource) Create() sdk.ResourceFunc {
 				},
 			}
 
+			// When not specified, the lock configuration is determined by the API
+			if len(model.ServicePrincipalLockConfiguration) > 0 {
+				properties.ServicePrincipalLockConfiguration = expandApplicationRegistrationServicePrincipalLockConfiguration(model.ServicePrincipalLockConfiguration)
+			}
+
 			resp, err := client.CreateApplication(ctx, properties, application.DefaultCreateApplicationOperationOptions())
 			if err != nil {
 				return fmt.Errorf("creating applicatoin: %+v", err)
@@ -292,6 +371,8 @@ func (r ApplicationRegistrationResource) Read() sdk.ResourceFunc {
 				PublisherDomain:            app.PublisherDomain.GetOrZero(),
 				ServiceManagementReference: app.ServiceManagementReference.GetOrZero(),
 				SignInAudience:             app.SignInAudience.GetOrZero(),
+
+				ServicePrincipalLockConfiguration: flattenApplicationRegistrationServicePrincipalLockConfiguration(app.ServicePrincipalLockConfiguration),
 			}
 
 			if api := app.Api; api != nil {
@@ -372,6 +453,10 @@ func (r ApplicationRegistrationResource) Update() sdk.ResourceFunc {
 				properties.ServiceManagementReference = nullable.NoZero(model.ServiceManagementReference)
 			}
 
+			if rd.HasChange("service_principal_lock_configuration") {
+				properties.ServicePrincipalLockConfiguration = expandApplicationRegistrationServicePrincipalLockConfiguration(model.ServicePrincipalLockConfiguration)
+			}
+
 			if rd.HasChange("sign_in_audience") {
 				properties.SignInAudience = nullable.Value(model.SignInAudience)
 			}
@@ -461,3 +546,31 @@ func (r ApplicationRegistrationResource) Delete() sdk.ResourceFunc {
 		},
 	}
 }
+
+func expandApplicationRegistrationServicePrincipalLockConfiguration(in []ApplicationRegistrationServicePrincipalLockConfigurationModel) *stable.ServicePrincipalLockConfiguration {
+	if len(in) == 0 {
+		return nil
+	}
+
+	return &stable.ServicePrincipalLockConfiguration{
+		AllProperties:              nullable.Value(in[0].AllProperties),
+		CredentialsWithUsageSign:   nullable.Value(in[0].CredentialsWithUsageSign),
+		CredentialsWithUsageVerify: nullable.Value(in[0].CredentialsWithUsageVerify),
+		IsEnabled:                  pointer.To(in[0].Enabled),
+		TokenEncryptionKeyId:       nullable.Value(in[0].TokenEncryptionKeyId),
+	}
+}
+
+func flattenApplicationRegistrationServicePrincipalLockConfiguration(in *stable.ServicePrincipalLockConfiguration) []ApplicationRegistrationServicePrincipalLockConfigurationModel {
+	if in == nil {
+		return []ApplicationRegistrationServicePrincipalLockConfigurationModel{}
+	}
+
+	return []ApplicationRegistrationServicePrincipalLockConfigurationModel{{
+		AllProperties:              in.AllProperties.GetOrZero(),
+		CredentialsWithUsageSign:   in.CredentialsWithUsageSign.GetOrZero(),
+		CredentialsWithUsageVerify: in.CredentialsWithUsageVerify.GetOrZero(),
+		Enabled:                    pointer.From(in.IsEnabled),
+		TokenEncryptionKeyId:       in.TokenEncryptionKeyId.GetOrZero(),
+	}}
+}
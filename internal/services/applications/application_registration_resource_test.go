@@ -87,6 +87,23 @@ func TestAccApplicationRegistration_update(t *testing.T) {
 	})
 }
 
+func TestAccApplicationRegistration_servicePrincipalLockConfiguration(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_registration", "test")
+	r := ApplicationRegistrationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.servicePrincipalLockConfiguration(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("service_principal_lock_configuration.0.enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("service_principal_lock_configuration.0.all_properties").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (r ApplicationRegistrationResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.Applications.ApplicationClient
 
@@ -144,3 +161,19 @@ resource "azuread_application_registration" "test" {
 }
 `, data.RandomInteger)
 }
+
+func (ApplicationRegistrationResource) servicePrincipalLockConfiguration(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application_registration" "test" {
+  display_name     = "acctest-AppRegistration-%[1]d"
+  sign_in_audience = "AzureADMultipleOrgs"
+
+  service_principal_lock_configuration {
+    enabled        = true
+    all_properties = true
+  }
+}
+`, data.RandomInteger)
+}
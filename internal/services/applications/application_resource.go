@@ -479,15 +479,50 @@ func applicationResource() *pluginsdk.Resource {
 				},
 			},
 
+			"request_signature_verification": {
+				Description: "Settings for verifying the signature of SAML authentication requests sent by the application",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"allowed_weak_algorithms": {
+							Description: "Weak signing algorithms which are accepted when verifying signed authentication requests",
+							Type:        pluginsdk.TypeSet,
+							Optional:    true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringInSlice(stable.PossibleValuesForWeakAlgorithms(), false),
+							},
+						},
+
+						"signed_request_required": {
+							Description: "Whether authentication requests for the application must be signed",
+							Type:        pluginsdk.TypeBool,
+							Required:    true,
+						},
+					},
+				},
+			},
+
 			"required_resource_access": {
 				Type:     pluginsdk.TypeSet,
 				Optional: true,
+				Computed: true, // API names and permission values are resolved to IDs in CustomizeDiff
 				Elem: &pluginsdk.Resource{
 					Schema: map[string]*pluginsdk.Schema{
 						"resource_app_id": {
 							Description: "",
 							Type:        pluginsdk.TypeString,
-							Required:    true,
+							Optional:    true,
+							Computed:    true,
+						},
+
+						"resource_app_name": {
+							Description:  "The name of the API, either a well-known published API such as `Microsoft Graph`, or the display name of its service principal",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
 						},
 
 						"resource_access": {
@@ -499,7 +534,8 @@ func applicationResource() *pluginsdk.Resource {
 									"id": {
 										Description:  "",
 										Type:         pluginsdk.TypeString,
-										Required:     true,
+										Optional:     true,
+										Computed:     true,
 										ValidateFunc: validation.IsUUID,
 									},
 
@@ -509,6 +545,13 @@ func applicationResource() *pluginsdk.Resource {
 										Required:     true,
 										ValidateFunc: validation.StringInSlice(possibleValuesForResourceAccessType, false),
 									},
+
+									"value": {
+										Description:  "The value of the app role or delegated permission scope, e.g. `User.Read.All`",
+										Type:         pluginsdk.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
 								},
 							},
 						},
@@ -522,6 +565,47 @@ func applicationResource() *pluginsdk.Resource {
 				Optional:    true,
 			},
 
+			"service_principal_lock_configuration": {
+				Description: "Locks sensitive properties of the service principals created for the application in other tenants",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"all_properties": {
+							Description: "Whether all sensitive properties of the service principal are locked, including credentials and the token encryption key ID",
+							Type:        pluginsdk.TypeBool,
+							Optional:    true,
+						},
+
+						"credentials_with_usage_sign": {
+							Description: "Whether key and password credentials with usage `Sign` are locked",
+							Type:        pluginsdk.TypeBool,
+							Optional:    true,
+						},
+
+						"credentials_with_usage_verify": {
+							Description: "Whether key and password credentials with usage `Verify` are locked",
+							Type:        pluginsdk.TypeBool,
+							Optional:    true,
+						},
+
+						"enabled": {
+							Description: "Whether the service principal lock configuration is enabled",
+							Type:        pluginsdk.TypeBool,
+							Required:    true,
+						},
+
+						"token_encryption_key_id": {
+							Description: "Whether the token encryption key ID is locked",
+							Type:        pluginsdk.TypeBool,
+							Optional:    true,
+						},
+					},
+				},
+			},
+
 			"sign_in_audience": {
 				Description:  "The Microsoft account types that are supported for the current application",
 				Type:         pluginsdk.TypeString,
@@ -584,6 +668,14 @@ func applicationResource() *pluginsdk.Resource {
 				Optional:    true,
 			},
 
+			"token_encryption_key_id": {
+				Description:  "The key ID of a certificate with usage `Encrypt`, which is used to encrypt tokens issued for the application",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
 			"web": {
 				Type:             pluginsdk.TypeList,
 				Optional:         true,
@@ -720,11 +812,43 @@ func applicationResourceCustomizeDiff(ctx context.Context, diff *pluginsdk.Resou
 		diff.SetNewComputed("oauth2_permission_scope_ids")
 	}
 
+	// Certificates can only be added to an existing application, so a new application cannot yet have an encryption key
+	if diff.Id() == "" && diff.Get("token_encryption_key_id").(string) != "" {
+		return fmt.Errorf("`token_encryption_key_id` cannot be set when creating an application, since the application does not yet have any certificates. Use the `azuread_application_token_encryption_key` resource instead")
+	}
+
 	// If the logo image changes, the CDN URL will change
 	if diff.HasChange("logo_image") {
 		diff.SetNewComputed("logo_url")
 	}
 
+	// Resolve any APIs specified by name and any permissions specified by value, so that their IDs are known at plan time
+	if config := diff.GetRawConfig().GetAttr("required_resource_access"); config.IsWhollyKnown() {
+		requiredResourceAccess := requiredResourceAccessFromConfig(config)
+		if requiredResourceAccessUsesNames(requiredResourceAccess) {
+			resolved, err := resolveApplicationRequiredResourceAccess(ctx, meta.(*clients.Client).Applications.ServicePrincipalClient, requiredResourceAccess)
+			if err != nil {
+				return fmt.Errorf("resolving `required_resource_access`: %v", err)
+			}
+			if err = diff.SetNew("required_resource_access", resolved); err != nil {
+				return err
+			}
+		} else if len(requiredResourceAccess) == 0 && diff.Get("required_resource_access").(*pluginsdk.Set).Len() > 0 {
+			// The property is computed, so removal of all blocks must be planned explicitly
+			if err := diff.SetNew("required_resource_access", []interface{}{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The lock configuration is computed, so it is only validated when configured
+	if config := diff.GetRawConfig().GetAttr("service_principal_lock_configuration"); config.IsKnown() && !config.IsNull() && config.LengthInt() > 0 {
+		lock := expandApplicationServicePrincipalLockConfiguration(diff.Get("service_principal_lock_configuration").([]interface{}))
+		if err := applicationValidateServicePrincipalLockConfiguration(diff.Get("sign_in_audience").(string), lock); err != nil {
+			return err
+		}
+	}
+
 	// The following validation is taken from https://docs.microsoft.com/en-gb/azure/active-directory/develop/supported-accounts-validation
 	// These apply only when personal account sign-ins are enabled for an application, and are enforced at plan time to avoid breaking existing
 	// applications that change from AAD (corporate) account sign-ins to personal account sign-ins
@@ -1127,6 +1251,12 @@ func applicationResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, m
 
 	api := expandApplicationApi(d.Get("api").([]interface{}))
 
+	// Resolve any APIs or permissions which could not be resolved at plan time
+	requiredResourceAccess, err := resolveApplicationRequiredResourceAccess(ctx, servicePrincipalsClient, d.Get("required_resource_access").(*pluginsdk.Set).List())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "required_resource_access", "Could not resolve API permissions")
+	}
+
 	// API bug: cannot set `acceptMappedClaims` when holding the Application.ReadWrite.OwnedBy role
 	// See https://github.com/hashicorp/terraform-provider-azuread/issues/914
 	var acceptMappedClaims nullable.Type[bool]
@@ -1149,17 +1279,23 @@ func applicationResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, m
 			SupportUrl:          nullable.NoZero(d.Get("support_url").(string)),
 			TermsOfServiceUrl:   nullable.NoZero(d.Get("terms_of_service_url").(string)),
 		},
-		IsDeviceOnlyAuthSupported:  nullable.Value(d.Get("device_only_auth_enabled").(bool)),
-		IsFallbackPublicClient:     nullable.Value(d.Get("fallback_public_client_enabled").(bool)),
-		Notes:                      nullable.NoZero(d.Get("notes").(string)),
-		OptionalClaims:             expandApplicationOptionalClaims(d.Get("optional_claims").([]interface{})),
-		PublicClient:               expandApplicationPublicClient(d.Get("public_client").([]interface{})),
-		RequiredResourceAccess:     expandApplicationRequiredResourceAccess(d.Get("required_resource_access").(*pluginsdk.Set).List()),
-		ServiceManagementReference: nullable.NoZero(d.Get("service_management_reference").(string)),
-		SignInAudience:             nullable.Value(d.Get("sign_in_audience").(string)),
-		Spa:                        expandApplicationSpa(d.Get("single_page_application").([]interface{})),
-		Tags:                       &tags,
-		Web:                        expandApplicationWeb(d.Get("web").([]interface{})),
+		IsDeviceOnlyAuthSupported:    nullable.Value(d.Get("device_only_auth_enabled").(bool)),
+		IsFallbackPublicClient:       nullable.Value(d.Get("fallback_public_client_enabled").(bool)),
+		Notes:                        nullable.NoZero(d.Get("notes").(string)),
+		OptionalClaims:               expandApplicationOptionalClaims(d.Get("optional_claims").([]interface{})),
+		PublicClient:                 expandApplicationPublicClient(d.Get("public_client").([]interface{})),
+		RequestSignatureVerification: expandApplicationRequestSignatureVerification(d.Get("request_signature_verification").([]interface{})),
+		RequiredResourceAccess:       expandApplicationRequiredResourceAccess(requiredResourceAccess),
+		ServiceManagementReference:   nullable.NoZero(d.Get("service_management_reference").(string)),
+		SignInAudience:               nullable.Value(d.Get("sign_in_audience").(string)),
+		Spa:                          expandApplicationSpa(d.Get("single_page_application").([]interface{})),
+		Tags:                         &tags,
+		Web:                          expandApplicationWeb(d.Get("web").([]interface{})),
+	}
+
+	// When not specified, the lock configuration is determined by the API
+	if v := d.Get("service_principal_lock_configuration").([]interface{}); len(v) > 0 {
+		properties.ServicePrincipalLockConfiguration = expandApplicationServicePrincipalLockConfiguration(v)
 	}
 
 	// Generate an application password, if specified
@@ -1316,6 +1452,7 @@ func applicationResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, m
 	clientBeta := meta.(*clients.Client).Applications.ApplicationClientBeta
 	logoClient := meta.(*clients.Client).Applications.ApplicationLogoClient
 	ownerClient := meta.(*clients.Client).Applications.ApplicationOwnerClient
+	servicePrincipalsClient := meta.(*clients.Client).Applications.ServicePrincipalClient
 
 	id, err := stable.ParseApplicationID(d.Id())
 	if err != nil {
@@ -1505,6 +1642,35 @@ func applicationResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, m
 		Web:                        expandApplicationWeb(d.Get("web").([]interface{})),
 	}
 
+	if d.HasChange("service_principal_lock_configuration") {
+		properties.ServicePrincipalLockConfiguration = expandApplicationServicePrincipalLockConfiguration(d.Get("service_principal_lock_configuration").([]interface{}))
+	}
+
+	if d.HasChange("request_signature_verification") {
+		properties.RequestSignatureVerification = expandApplicationRequestSignatureVerification(d.Get("request_signature_verification").([]interface{}))
+		if properties.RequestSignatureVerification == nil {
+			properties.RequestSignatureVerification = &stable.RequestSignatureVerification{
+				IsSignedRequestRequired: pointer.To(false),
+			}
+		}
+	}
+
+	if d.HasChange("token_encryption_key_id") {
+		if keyId := d.Get("token_encryption_key_id").(string); keyId != "" {
+			resp, err := client.GetApplication(ctx, *id, application.GetApplicationOperationOptions{Select: pointer.To([]string{"keyCredentials"})})
+			if err != nil {
+				return tf.ErrorDiagF(err, "Retrieving %s", id)
+			}
+			if resp.Model == nil {
+				return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+			}
+			if err = applicationValidateTokenEncryptionKeyId(resp.Model.KeyCredentials, keyId); err != nil {
+				return tf.ErrorDiagPathF(err, "token_encryption_key_id", "Validating token encryption key for %s", id)
+			}
+			properties.TokenEncryptionKeyId = nullable.Value(keyId)
+		}
+	}
+
 	api := expandApplicationApi(d.Get("api").([]interface{}))
 
 	if d.HasChange("app_role") {
@@ -1534,7 +1700,11 @@ func applicationResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, m
 	}
 
 	if d.HasChange("required_resource_access") {
-		properties.RequiredResourceAccess = expandApplicationRequiredResourceAccess(d.Get("required_resource_access").(*pluginsdk.Set).List())
+		requiredResourceAccess, err := resolveApplicationRequiredResourceAccess(ctx, servicePrincipalsClient, d.Get("required_resource_access").(*pluginsdk.Set).List())
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "required_resource_access", "Could not resolve API permissions")
+		}
+		properties.RequiredResourceAccess = expandApplicationRequiredResourceAccess(requiredResourceAccess)
 	}
 
 	properties.Api = api
@@ -1641,12 +1811,15 @@ func applicationResourceRead(ctx context.Context, d *pluginsdk.ResourceData, met
 	tf.Set(d, "optional_claims", flattenApplicationOptionalClaims(app.OptionalClaims))
 	tf.Set(d, "public_client", flattenApplicationPublicClient(app.PublicClient))
 	tf.Set(d, "publisher_domain", app.PublisherDomain.GetOrZero())
-	tf.Set(d, "required_resource_access", flattenApplicationRequiredResourceAccess(app.RequiredResourceAccess))
+	tf.Set(d, "request_signature_verification", flattenApplicationRequestSignatureVerification(app.RequestSignatureVerification))
+	tf.Set(d, "required_resource_access", mergeApplicationRequiredResourceAccessNames(flattenApplicationRequiredResourceAccess(app.RequiredResourceAccess), d.Get("required_resource_access").(*pluginsdk.Set).List()))
 	tf.Set(d, "service_management_reference", app.ServiceManagementReference.GetOrZero())
+	tf.Set(d, "service_principal_lock_configuration", flattenApplicationServicePrincipalLockConfiguration(app.ServicePrincipalLockConfiguration))
 	tf.Set(d, "sign_in_audience", app.SignInAudience.GetOrZero())
 	tf.Set(d, "single_page_application", flattenApplicationSpa(app.Spa))
 	tf.Set(d, "tags", tf.FlattenStringSlicePtr(app.Tags))
 	tf.Set(d, "template_id", app.ApplicationTemplateId.GetOrZero())
+	tf.Set(d, "token_encryption_key_id", app.TokenEncryptionKeyId.GetOrZero())
 	tf.Set(d, "web", flattenApplicationWeb(app.Web))
 
 	if app.Api != nil {
@@ -662,6 +662,105 @@ func TestAccApplication_passwordNotSet(t *testing.T) {
 	})
 }
 
+func TestAccApplication_requiredResourceAccessByName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.requiredResourceAccessByName(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("required_resource_access.#").HasValue("1"),
+				check.That(data.ResourceName).Key("required_resource_access.0.resource_app_id").HasValue("00000003-0000-0000-c000-000000000000"),
+				check.That(data.ResourceName).Key("required_resource_access.0.resource_access.#").HasValue("2"),
+			),
+		},
+		data.ImportStep("required_resource_access"),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("required_resource_access.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAccApplication_servicePrincipalLockConfiguration(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.servicePrincipalLockConfiguration(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("service_principal_lock_configuration.0.enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("service_principal_lock_configuration.0.credentials_with_usage_verify").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.servicePrincipalLockConfiguration(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("service_principal_lock_configuration.0.enabled").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplication_servicePrincipalLockConfigurationSingleTenant(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.servicePrincipalLockConfigurationSingleTenant(data),
+			ExpectError: regexp.MustCompile("`service_principal_lock_configuration` has no effect when `sign_in_audience` is \"AzureADMyOrg\""),
+		},
+	})
+}
+
+func TestAccApplication_requestSignatureVerification(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.requestSignatureVerification(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("request_signature_verification.0.signed_request_required").HasValue("true"),
+				check.That(data.ResourceName).Key("request_signature_verification.0.allowed_weak_algorithms.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("request_signature_verification.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplication_tokenEncryptionKeyId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.tokenEncryptionKeyId(data),
+			ExpectError: regexp.MustCompile("`token_encryption_key_id` cannot be set when creating an application"),
+		},
+	})
+}
+
 func (r ApplicationResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.Applications.ApplicationClient
 
@@ -1699,3 +1798,87 @@ resource "azuread_application" "test" {
 }
 `, data.RandomInteger, startDate, endDate)
 }
+
+func (ApplicationResource) requiredResourceAccessByName(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  required_resource_access {
+    resource_app_name = "Microsoft Graph"
+
+    resource_access {
+      value = "User.Read.All"
+      type  = "Role"
+    }
+
+    resource_access {
+      value = "User.Read"
+      type  = "Scope"
+    }
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ApplicationResource) servicePrincipalLockConfiguration(data acceptance.TestData, enabled bool) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name     = "acctest-APP-%[1]d"
+  sign_in_audience = "AzureADMultipleOrgs"
+
+  service_principal_lock_configuration {
+    enabled                       = %[2]t
+    credentials_with_usage_sign   = true
+    credentials_with_usage_verify = true
+    token_encryption_key_id       = true
+  }
+}
+`, data.RandomInteger, enabled)
+}
+
+func (ApplicationResource) servicePrincipalLockConfigurationSingleTenant(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name     = "acctest-APP-%[1]d"
+  sign_in_audience = "AzureADMyOrg"
+
+  service_principal_lock_configuration {
+    enabled        = true
+    all_properties = true
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ApplicationResource) requestSignatureVerification(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  request_signature_verification {
+    allowed_weak_algorithms = ["rsaSha1"]
+    signed_request_required = true
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ApplicationResource) tokenEncryptionKeyId(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name            = "acctest-APP-%[1]d"
+  token_encryption_key_id = "%[2]s"
+}
+`, data.RandomInteger, data.RandomID)
+}
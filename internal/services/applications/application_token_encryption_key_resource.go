@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+)
+
+type ApplicationTokenEncryptionKeyModel struct {
+	ApplicationId string `tfschema:"application_id"`
+	KeyId         string `tfschema:"key_id"`
+}
+
+var (
+	_ sdk.Resource           = ApplicationTokenEncryptionKeyResource{}
+	_ sdk.ResourceWithUpdate = ApplicationTokenEncryptionKeyResource{}
+)
+
+type ApplicationTokenEncryptionKeyResource struct{}
+
+func (r ApplicationTokenEncryptionKeyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateTokenEncryptionKeyID
+}
+
+func (r ApplicationTokenEncryptionKeyResource) ResourceType() string {
+	return "azuread_application_token_encryption_key"
+}
+
+func (r ApplicationTokenEncryptionKeyResource) ModelObject() interface{} {
+	return &ApplicationTokenEncryptionKeyModel{}
+}
+
+func (r ApplicationTokenEncryptionKeyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"application_id": {
+			Description:  "The resource ID of the application for which tokens should be encrypted",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: stable.ValidateApplicationID,
+		},
+
+		"key_id": {
+			Description:  "The key ID of a certificate with usage `Encrypt`, which is used to encrypt tokens issued for the application",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+	}
+}
+
+func (r ApplicationTokenEncryptionKeyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r ApplicationTokenEncryptionKeyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model ApplicationTokenEncryptionKeyModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			applicationId, err := stable.ParseApplicationID(model.ApplicationId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewTokenEncryptionKeyID(applicationId.ApplicationId)
+
+			tf.LockByName(applicationResourceName, id.ApplicationId)
+			defer tf.UnlockByName(applicationResourceName, id.ApplicationId)
+
+			if err = r.setTokenEncryptionKey(ctx, metadata, *applicationId, model.KeyId); err != nil {
+				return fmt.Errorf("setting %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ApplicationTokenEncryptionKeyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Applications.ApplicationClient
+
+			id, err := parse.ParseTokenEncryptionKeyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			applicationId := stable.NewApplicationID(id.ApplicationId)
+
+			resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if resp.Model == nil {
+				return fmt.Errorf("retrieving %s: result was nil", id)
+			}
+			if resp.Model.TokenEncryptionKeyId.GetOrZero() == "" {
+				return metadata.MarkAsGone(id)
+			}
+
+			state := ApplicationTokenEncryptionKeyModel{
+				ApplicationId: applicationId.ID(),
+				KeyId:         resp.Model.TokenEncryptionKeyId.GetOrZero(),
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ApplicationTokenEncryptionKeyResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ParseTokenEncryptionKeyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model ApplicationTokenEncryptionKeyModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			tf.LockByName(applicationResourceName, id.ApplicationId)
+			defer tf.UnlockByName(applicationResourceName, id.ApplicationId)
+
+			if metadata.ResourceData.HasChange("key_id") {
+				if err = r.setTokenEncryptionKey(ctx, metadata, stable.NewApplicationID(id.ApplicationId), model.KeyId); err != nil {
+					return fmt.Errorf("updating %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ApplicationTokenEncryptionKeyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Applications.ApplicationClient
+
+			id, err := parse.ParseTokenEncryptionKeyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			applicationId := stable.NewApplicationID(id.ApplicationId)
+
+			tf.LockByName(applicationResourceName, id.ApplicationId)
+			defer tf.UnlockByName(applicationResourceName, id.ApplicationId)
+
+			properties := stable.Application{}
+			properties.TokenEncryptionKeyId.SetNull()
+
+			if _, err = client.UpdateApplication(ctx, applicationId, properties, application.DefaultUpdateApplicationOperationOptions()); err != nil {
+				return fmt.Errorf("unsetting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// setTokenEncryptionKey validates that the specified key ID refers to a certificate with usage `Encrypt` belonging to
+// the application, before configuring the application to encrypt tokens using it
+func (r ApplicationTokenEncryptionKeyResource) setTokenEncryptionKey(ctx context.Context, metadata sdk.ResourceMetaData, applicationId stable.ApplicationId, keyId string) error {
+	client := metadata.Client.Applications.ApplicationClient
+
+	resp, err := client.GetApplication(ctx, applicationId, application.GetApplicationOperationOptions{Select: pointer.To([]string{"keyCredentials"})})
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", applicationId, err)
+	}
+	if resp.Model == nil {
+		return fmt.Errorf("retrieving %s: result was nil", applicationId)
+	}
+
+	if err = applicationValidateTokenEncryptionKeyId(resp.Model.KeyCredentials, keyId); err != nil {
+		return err
+	}
+
+	properties := stable.Application{
+		TokenEncryptionKeyId: nullable.Value(keyId),
+	}
+
+	if _, err = client.UpdateApplication(ctx, applicationId, properties, application.DefaultUpdateApplicationOperationOptions()); err != nil {
+		return err
+	}
+
+	return nil
+}
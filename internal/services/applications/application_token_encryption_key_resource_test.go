@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+)
+
+type ApplicationTokenEncryptionKeyResource struct{}
+
+func TestAccApplicationTokenEncryptionKey_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_token_encryption_key", "test")
+	r := ApplicationTokenEncryptionKeyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("application_id").Exists(),
+				check.That(data.ResourceName).Key("key_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplicationTokenEncryptionKey_verifyCertificate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_token_encryption_key", "test")
+	r := ApplicationTokenEncryptionKeyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.verifyCertificate(data),
+			ExpectError: regexp.MustCompile("token encryption requires a certificate with usage \"Encrypt\""),
+		},
+	})
+}
+
+func (r ApplicationTokenEncryptionKeyResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Applications.ApplicationClient
+
+	id, err := parse.ParseTokenEncryptionKeyID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	applicationId := stable.NewApplicationID(id.ApplicationId)
+
+	resp, err := client.GetApplication(ctx, applicationId, application.DefaultGetApplicationOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", applicationId, err)
+	}
+
+	app := resp.Model
+	if app == nil {
+		return nil, fmt.Errorf("retrieving %s: model was nil", applicationId)
+	}
+
+	return pointer.To(app.TokenEncryptionKeyId.GetOrZero() != ""), nil
+}
+
+func (ApplicationTokenEncryptionKeyResource) template(data acceptance.TestData, usage string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application_registration" "test" {
+  display_name = "acctest-TokenEncryptionKey-%[1]d"
+}
+
+resource "azuread_application_certificate" "test" {
+  application_id = azuread_application_registration.test.id
+  type           = "AsymmetricX509Cert"
+  usage          = "%[2]s"
+  value          = <<EOT
+%[3]s
+EOT
+}
+`, data.RandomInteger, usage, applicationCertificatePem)
+}
+
+func (r ApplicationTokenEncryptionKeyResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_token_encryption_key" "test" {
+  application_id = azuread_application_registration.test.id
+  key_id         = azuread_application_certificate.test.key_id
+}
+`, r.template(data, "Encrypt"))
+}
+
+func (r ApplicationTokenEncryptionKeyResource) verifyCertificate(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_token_encryption_key" "test" {
+  application_id = azuread_application_registration.test.id
+  key_id         = azuread_application_certificate.test.key_id
+}
+`, r.template(data, "Verify"))
+}
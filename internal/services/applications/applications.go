@@ -6,6 +6,7 @@ package applications
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -15,12 +16,15 @@ import (
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	applicationAppManagementPolicy "github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/appmanagementpolicy"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/defaultappmanagementpolicy"
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
 	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
 	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/applications"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/credentials"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/iso8601"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 )
@@ -390,6 +394,24 @@ func applicationValidateRolesScopes(appRoles, oauth2Permissions []interface{}) e
 	return nil
 }
 
+// applicationValidateServicePrincipalLockConfiguration validates a service principal lock configuration for an
+// application having the specified sign-in audience
+func applicationValidateServicePrincipalLockConfiguration(signInAudience string, lock *stable.ServicePrincipalLockConfiguration) error {
+	if lock == nil || !pointer.From(lock.IsEnabled) {
+		return nil
+	}
+
+	if signInAudience == SignInAudienceAzureADMyOrg {
+		return fmt.Errorf("`service_principal_lock_configuration` has no effect when `sign_in_audience` is %q, since the service principal for a single tenant application can only be managed in the tenant where the application is registered", SignInAudienceAzureADMyOrg)
+	}
+
+	if !lock.AllProperties.GetOrZero() && !lock.CredentialsWithUsageSign.GetOrZero() && !lock.CredentialsWithUsageVerify.GetOrZero() && !lock.TokenEncryptionKeyId.GetOrZero() {
+		return errors.New("at least one of `all_properties`, `credentials_with_usage_sign`, `credentials_with_usage_verify` or `token_encryption_key_id` must be true when `service_principal_lock_configuration` is enabled")
+	}
+
+	return nil
+}
+
 func expandApplicationApi(input []interface{}) (result *stable.ApiApplication) {
 	result = &stable.ApiApplication{
 		AcceptMappedClaims:          nullable.Value(false),
@@ -575,6 +597,52 @@ func expandApplicationOptionalClaim(in []interface{}) *[]stable.OptionalClaim {
 	return &result
 }
 
+// applicationValidateTokenEncryptionKeyId validates that the specified key ID refers to an existing key credential
+// which can be used for token encryption
+func applicationValidateTokenEncryptionKeyId(keyCredentials *[]stable.KeyCredential, keyId string) error {
+	credential := credentials.GetKeyCredential(keyCredentials, keyId)
+	if credential == nil {
+		return fmt.Errorf("no certificate was found with key ID %q", keyId)
+	}
+	if usage := credential.Usage.GetOrZero(); !strings.EqualFold(usage, credentials.KeyCredentialUsageEncrypt) {
+		return fmt.Errorf("the certificate with key ID %q has usage %q, but token encryption requires a certificate with usage %q", keyId, usage, credentials.KeyCredentialUsageEncrypt)
+	}
+	return nil
+}
+
+func expandApplicationRequestSignatureVerification(input []interface{}) *stable.RequestSignatureVerification {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	in := input[0].(map[string]interface{})
+	result := stable.RequestSignatureVerification{
+		IsSignedRequestRequired: pointer.To(in["signed_request_required"].(bool)),
+	}
+
+	// Weak algorithms are a flags enum, so multiple values are comma separated
+	if v, ok := in["allowed_weak_algorithms"].(*pluginsdk.Set); ok && v.Len() > 0 {
+		result.AllowedWeakAlgorithms = pointer.To(stable.WeakAlgorithms(strings.Join(tf.ExpandStringSlice(v.List()), ",")))
+	}
+
+	return &result
+}
+
+func expandApplicationServicePrincipalLockConfiguration(input []interface{}) *stable.ServicePrincipalLockConfiguration {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	in := input[0].(map[string]interface{})
+	return &stable.ServicePrincipalLockConfiguration{
+		AllProperties:              nullable.Value(in["all_properties"].(bool)),
+		CredentialsWithUsageSign:   nullable.Value(in["credentials_with_usage_sign"].(bool)),
+		CredentialsWithUsageVerify: nullable.Value(in["credentials_with_usage_verify"].(bool)),
+		IsEnabled:                  pointer.To(in["enabled"].(bool)),
+		TokenEncryptionKeyId:       nullable.Value(in["token_encryption_key_id"].(bool)),
+	}
+}
+
 func expandApplicationPublicClient(input []interface{}) (result *stable.PublicClientApplication) {
 	result = &stable.PublicClientApplication{
 		RedirectUris: &[]string{},
@@ -763,6 +831,41 @@ func flattenApplicationPublicClient(in *stable.PublicClientApplication) []map[st
 	}}
 }
 
+func flattenApplicationRequestSignatureVerification(in *stable.RequestSignatureVerification) []map[string]interface{} {
+	if in == nil {
+		return []map[string]interface{}{}
+	}
+
+	allowedWeakAlgorithms := make([]string, 0)
+	if in.AllowedWeakAlgorithms != nil && *in.AllowedWeakAlgorithms != "" {
+		allowedWeakAlgorithms = strings.Split(string(*in.AllowedWeakAlgorithms), ",")
+	}
+
+	// An unset configuration is returned by the API as not requiring signed requests
+	if !pointer.From(in.IsSignedRequestRequired) && len(allowedWeakAlgorithms) == 0 {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{{
+		"allowed_weak_algorithms": allowedWeakAlgorithms,
+		"signed_request_required": pointer.From(in.IsSignedRequestRequired),
+	}}
+}
+
+func flattenApplicationServicePrincipalLockConfiguration(in *stable.ServicePrincipalLockConfiguration) []map[string]interface{} {
+	if in == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{{
+		"all_properties":                in.AllProperties.GetOrZero(),
+		"credentials_with_usage_sign":   in.CredentialsWithUsageSign.GetOrZero(),
+		"credentials_with_usage_verify": in.CredentialsWithUsageVerify.GetOrZero(),
+		"enabled":                       pointer.From(in.IsEnabled),
+		"token_encryption_key_id":       in.TokenEncryptionKeyId.GetOrZero(),
+	}}
+}
+
 func flattenApplicationRequiredResourceAccess(in *[]stable.RequiredResourceAccess) []map[string]interface{} {
 	if in == nil {
 		return []map[string]interface{}{}
@@ -844,3 +947,136 @@ func flattenApplicationWeb(in *stable.WebApplication) []map[string]interface{} {
 		"implicit_grant": flattenApplicationImplicitGrant(in.ImplicitGrantSettings),
 	}}
 }
+
+// applicationPasswordPolicyRestriction is a password credential restriction, along with a description of the app
+// management policy from which it originates
+type applicationPasswordPolicyRestriction struct {
+	restriction stable.PasswordCredentialConfiguration
+	source      string
+}
+
+// applicationPasswordPolicyViolation evaluates a new password credential against the app management policies in effect
+// for the application, so that a clear diagnostic can be surfaced instead of the opaque error returned by the API. Failures
+// looking up policies are not fatal, since the API remains the authority on whether the credential is permitted, and are
+// instead returned as warnings.
+func applicationPasswordPolicyViolation(ctx context.Context, assignedPolicyClient *applicationAppManagementPolicy.AppManagementPolicyClient, defaultPolicyClient *defaultappmanagementpolicy.DefaultAppManagementPolicyClient, app stable.Application, credential stable.PasswordCredential) (pluginsdk.Diagnostics, error) {
+	if app.Id == nil {
+		return nil, nil
+	}
+
+	restrictions, diags := applicationPasswordPolicyRestrictions(ctx, assignedPolicyClient, defaultPolicyClient, *app.Id)
+	if diags != nil {
+		return diags, nil
+	}
+
+	return nil, applicationPasswordRestrictionsViolation(restrictions, app, credential)
+}
+
+// applicationPasswordPolicyRestrictions retrieves the password credential restrictions in effect for an application. An
+// explicitly assigned and enabled policy takes precedence over the tenant default policy, which applies when no enabled
+// policy is assigned to the application.
+func applicationPasswordPolicyRestrictions(ctx context.Context, assignedPolicyClient *applicationAppManagementPolicy.AppManagementPolicyClient, defaultPolicyClient *defaultappmanagementpolicy.DefaultAppManagementPolicyClient, applicationId string) ([]applicationPasswordPolicyRestriction, pluginsdk.Diagnostics) {
+	resp, err := assignedPolicyClient.ListAppManagementPolicies(ctx, stable.NewApplicationID(applicationId), applicationAppManagementPolicy.DefaultListAppManagementPoliciesOperationOptions())
+	if err != nil {
+		return nil, applicationPasswordPolicyLookupWarning(fmt.Sprintf("Unable to list app management policies for application with object ID %q: %v", applicationId, err))
+	}
+
+	if resp.Model != nil {
+		if restrictions, enabled := applicationAssignedPasswordPolicyRestrictions(*resp.Model); enabled {
+			return restrictions, nil
+		}
+	}
+
+	defaultResp, err := defaultPolicyClient.GetDefaultAppManagementPolicy(ctx, defaultappmanagementpolicy.DefaultGetDefaultAppManagementPolicyOperationOptions())
+	if err != nil {
+		return nil, applicationPasswordPolicyLookupWarning(fmt.Sprintf("Unable to retrieve the default app management policy: %v", err))
+	}
+
+	restrictions := make([]applicationPasswordPolicyRestriction, 0)
+	if policy := defaultResp.Model; policy != nil && pointer.From(policy.IsEnabled) && policy.ApplicationRestrictions != nil {
+		for _, restriction := range pointer.From(policy.ApplicationRestrictions.PasswordCredentials) {
+			restrictions = append(restrictions, applicationPasswordPolicyRestriction{restriction: restriction, source: "the default app management policy"})
+		}
+	}
+
+	return restrictions, nil
+}
+
+// applicationAssignedPasswordPolicyRestrictions returns the password credential restrictions of the enabled policies
+// assigned to an application, and whether any assigned policy is enabled
+func applicationAssignedPasswordPolicyRestrictions(policies []stable.AppManagementPolicy) ([]applicationPasswordPolicyRestriction, bool) {
+	restrictions := make([]applicationPasswordPolicyRestriction, 0)
+	enabled := false
+
+	for _, policy := range policies {
+		if !pointer.From(policy.IsEnabled) {
+			continue
+		}
+		enabled = true
+
+		if policy.Restrictions == nil {
+			continue
+		}
+		source := fmt.Sprintf("app management policy %q", policy.DisplayName.GetOrZero())
+		for _, restriction := range pointer.From(policy.Restrictions.PasswordCredentials) {
+			restrictions = append(restrictions, applicationPasswordPolicyRestriction{restriction: restriction, source: source})
+		}
+	}
+
+	return restrictions, enabled
+}
+
+// applicationPasswordRestrictionsViolation returns an error describing the first restriction that would cause the API
+// to reject the password credential
+func applicationPasswordRestrictionsViolation(restrictions []applicationPasswordPolicyRestriction, app stable.Application, credential stable.PasswordCredential) error {
+	startDate := time.Now()
+	if v := credential.StartDateTime.GetOrZero(); v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			startDate = t
+		}
+	}
+
+	// When no end date is specified, the API defaults to a lifetime of two years
+	endDate := startDate.AddDate(2, 0, 0)
+	if v := credential.EndDateTime.GetOrZero(); v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			endDate = t
+		}
+	}
+
+	createdDate, _ := time.Parse(time.RFC3339, app.CreatedDateTime.GetOrZero())
+
+	for _, policyRestriction := range restrictions {
+		restriction, policyName := policyRestriction.restriction, policyRestriction.source
+
+		if v := restriction.RestrictForAppsCreatedAfterDateTime.GetOrZero(); v != "" && !createdDate.IsZero() {
+			if after, err := time.Parse(time.RFC3339, v); err == nil && createdDate.Before(after) {
+				continue
+			}
+		}
+
+		switch pointer.From(restriction.RestrictionType) {
+		case stable.AppCredentialRestrictionType_PasswordAddition:
+			return fmt.Errorf("passwords cannot be added to this application, as this is blocked by %s", policyName)
+
+		case stable.AppCredentialRestrictionType_PasswordLifetime:
+			maxLifetime, err := iso8601.ParseDuration(restriction.MaxLifetime.GetOrZero())
+			if err != nil {
+				continue
+			}
+			if lifetime := endDate.Sub(startDate); lifetime > maxLifetime {
+				return fmt.Errorf("the requested password lifetime ending %s exceeds the maximum lifetime of %s permitted by %s, the `end_date` must be no later than %s", endDate.Format(time.RFC3339), restriction.MaxLifetime.GetOrZero(), policyName, startDate.Add(maxLifetime).Format(time.RFC3339))
+			}
+		}
+	}
+
+	return nil
+}
+
+func applicationPasswordPolicyLookupWarning(detail string) pluginsdk.Diagnostics {
+	return pluginsdk.Diagnostics{{
+		Severity: pluginsdk.DiagWarning,
+		Summary:  "Could not evaluate app management policies for password",
+		Detail:   detail + ". The password may be rejected by the API if it does not comply with an app management policy.",
+	}}
+}
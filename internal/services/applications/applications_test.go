@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+func testPasswordLifetimeRestriction(maxLifetime string) stable.PasswordCredentialConfiguration {
+	return stable.PasswordCredentialConfiguration{
+		RestrictionType: pointer.To(stable.AppCredentialRestrictionType_PasswordLifetime),
+		MaxLifetime:     nullable.Value(maxLifetime),
+	}
+}
+
+func TestApplicationAssignedPasswordPolicyRestrictions(t *testing.T) {
+	testData := []struct {
+		name            string
+		policies        []stable.AppManagementPolicy
+		expectedEnabled bool
+		expectedSources []string
+	}{
+		{
+			name:            "no policies",
+			policies:        nil,
+			expectedEnabled: false,
+		},
+		{
+			name: "all policies disabled",
+			policies: []stable.AppManagementPolicy{
+				{
+					DisplayName: nullable.Value("disabled"),
+					IsEnabled:   pointer.To(false),
+					Restrictions: &stable.CustomAppManagementConfiguration{
+						PasswordCredentials: &[]stable.PasswordCredentialConfiguration{testPasswordLifetimeRestriction("P30D")},
+					},
+				},
+			},
+			expectedEnabled: false,
+		},
+		{
+			name: "enabled policy without password restrictions",
+			policies: []stable.AppManagementPolicy{
+				{
+					DisplayName: nullable.Value("enabled"),
+					IsEnabled:   pointer.To(true),
+				},
+			},
+			expectedEnabled: true,
+		},
+		{
+			name: "enabled and disabled policies",
+			policies: []stable.AppManagementPolicy{
+				{
+					DisplayName: nullable.Value("disabled"),
+					IsEnabled:   pointer.To(false),
+					Restrictions: &stable.CustomAppManagementConfiguration{
+						PasswordCredentials: &[]stable.PasswordCredentialConfiguration{testPasswordLifetimeRestriction("P30D")},
+					},
+				},
+				{
+					DisplayName: nullable.Value("enabled"),
+					IsEnabled:   pointer.To(true),
+					Restrictions: &stable.CustomAppManagementConfiguration{
+						PasswordCredentials: &[]stable.PasswordCredentialConfiguration{testPasswordLifetimeRestriction("P90D")},
+					},
+				},
+			},
+			expectedEnabled: true,
+			expectedSources: []string{`app management policy "enabled"`},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %s", v.name)
+
+		restrictions, enabled := applicationAssignedPasswordPolicyRestrictions(v.policies)
+		if enabled != v.expectedEnabled {
+			t.Errorf("expected enabled to be %t, got %t", v.expectedEnabled, enabled)
+		}
+		if len(restrictions) != len(v.expectedSources) {
+			t.Fatalf("expected %d restrictions, got %d", len(v.expectedSources), len(restrictions))
+		}
+		for i, source := range v.expectedSources {
+			if restrictions[i].source != source {
+				t.Errorf("expected restriction %d to originate from %s, got %s", i, source, restrictions[i].source)
+			}
+		}
+	}
+}
+
+func TestApplicationPasswordRestrictionsViolation(t *testing.T) {
+	app := stable.Application{
+		CreatedDateTime: nullable.Value("2024-01-01T00:00:00Z"),
+	}
+
+	testData := []struct {
+		name          string
+		restrictions  []stable.PasswordCredentialConfiguration
+		endDate       string
+		expectedError string
+	}{
+		{
+			name:    "no restrictions",
+			endDate: "2030-01-01T00:00:00Z",
+		},
+		{
+			name:         "lifetime within maximum",
+			restrictions: []stable.PasswordCredentialConfiguration{testPasswordLifetimeRestriction("P90D")},
+			endDate:      "2025-03-01T00:00:00Z",
+		},
+		{
+			name:          "lifetime exceeds maximum",
+			restrictions:  []stable.PasswordCredentialConfiguration{testPasswordLifetimeRestriction("P90D")},
+			endDate:       "2025-06-01T00:00:00Z",
+			expectedError: "exceeds the maximum lifetime of P90D",
+		},
+		{
+			name:          "default lifetime exceeds maximum",
+			restrictions:  []stable.PasswordCredentialConfiguration{testPasswordLifetimeRestriction("P1Y")},
+			expectedError: "exceeds the maximum lifetime of P1Y",
+		},
+		{
+			name: "restriction only applies to newer applications",
+			restrictions: []stable.PasswordCredentialConfiguration{
+				{
+					RestrictionType:                     pointer.To(stable.AppCredentialRestrictionType_PasswordLifetime),
+					MaxLifetime:                         nullable.Value("P90D"),
+					RestrictForAppsCreatedAfterDateTime: nullable.Value("2024-06-01T00:00:00Z"),
+				},
+			},
+			endDate: "2025-06-01T00:00:00Z",
+		},
+		{
+			name: "password addition blocked",
+			restrictions: []stable.PasswordCredentialConfiguration{
+				{
+					RestrictionType: pointer.To(stable.AppCredentialRestrictionType_PasswordAddition),
+				},
+			},
+			endDate:       "2025-03-01T00:00:00Z",
+			expectedError: "passwords cannot be added",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %s", v.name)
+
+		restrictions := make([]applicationPasswordPolicyRestriction, 0)
+		for _, restriction := range v.restrictions {
+			restrictions = append(restrictions, applicationPasswordPolicyRestriction{restriction: restriction, source: "the default app management policy"})
+		}
+
+		credential := stable.PasswordCredential{
+			StartDateTime: nullable.Value("2025-01-01T00:00:00Z"),
+		}
+		if v.endDate != "" {
+			credential.EndDateTime = nullable.Value(v.endDate)
+		}
+
+		err := applicationPasswordRestrictionsViolation(restrictions, app, credential)
+		if v.expectedError == "" {
+			if err != nil {
+				t.Errorf("expected no error, got %+v", err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("expected an error containing %q, got none", v.expectedError)
+		} else if !strings.Contains(err.Error(), v.expectedError) {
+			t.Errorf("expected an error containing %q, got %q", v.expectedError, err.Error())
+		}
+	}
+}
@@ -5,6 +5,7 @@ package client
 
 import (
 	applicationBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/beta/application"
+	connectorGroupBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/beta/connectorgroup"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/federatedidentitycredential"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/logo"
@@ -22,6 +23,7 @@ type Client struct {
 	ApplicationOwnerClient                 *owner.OwnerClient
 	ApplicationFederatedIdentityCredential *federatedidentitycredential.FederatedIdentityCredentialClient
 	ApplicationTemplateClient              *applicationtemplate.ApplicationTemplateClient
+	ConnectorGroupClientBeta               *connectorGroupBeta.ConnectorGroupClient
 	ServicePrincipalClient                 *serviceprincipal.ServicePrincipalClient
 }
 
@@ -63,6 +65,13 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(applicationTemplateClient.Client)
 
+	// Application Proxy is only available in the beta API
+	connectorGroupClientBeta, err := connectorGroupBeta.NewConnectorGroupClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(connectorGroupClientBeta.Client)
+
 	directoryObjectClient, err := directoryobject.NewDirectoryObjectClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
@@ -82,6 +91,7 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		ApplicationOwnerClient:                 applicationOwnerClient,
 		ApplicationFederatedIdentityCredential: applicationFederatedIdentityCredentialClient,
 		ApplicationTemplateClient:              applicationTemplateClient,
+		ConnectorGroupClientBeta:               connectorGroupClientBeta,
 		ServicePrincipalClient:                 servicePrincipalClient,
 	}, nil
 }
@@ -7,6 +7,16 @@ const (
 	applicationResourceName = "azuread_application"
 )
 
+// The custom (non-gallery) application template, from which Application Proxy applications are instantiated
+const applicationProxyTemplateId = "8adf8e6e-67b2-4cf2-a259-e3dc5476c621"
+
+const (
+	ApplicationProxyServerTimeoutDefault = "Default"
+	ApplicationProxyServerTimeoutLong    = "Long"
+)
+
+var possibleValuesForApplicationProxyServerTimeout = []string{ApplicationProxyServerTimeoutDefault, ApplicationProxyServerTimeoutLong}
+
 const (
 	AppRoleAllowedMemberTypeApplication = "Application"
 	AppRoleAllowedMemberTypeUser        = "User"
@@ -14,6 +24,22 @@ const (
 
 var possibleValuesForAppRoleAllowedMemberType = []string{AppRoleAllowedMemberTypeApplication, AppRoleAllowedMemberTypeUser}
 
+const (
+	FederatedIdentityCredentialGitHubEntityTypeBranch      = "branch"
+	FederatedIdentityCredentialGitHubEntityTypeEnvironment = "environment"
+	FederatedIdentityCredentialGitHubEntityTypePullRequest = "pull_request"
+	FederatedIdentityCredentialGitHubEntityTypeTag         = "tag"
+)
+
+var possibleValuesForFederatedIdentityCredentialGitHubEntityType = []string{FederatedIdentityCredentialGitHubEntityTypeBranch, FederatedIdentityCredentialGitHubEntityTypeEnvironment, FederatedIdentityCredentialGitHubEntityTypePullRequest, FederatedIdentityCredentialGitHubEntityTypeTag}
+
+const (
+	FederatedIdentityCredentialGitLabRefTypeBranch = "branch"
+	FederatedIdentityCredentialGitLabRefTypeTag    = "tag"
+)
+
+var possibleValuesForFederatedIdentityCredentialGitLabRefType = []string{FederatedIdentityCredentialGitLabRefTypeBranch, FederatedIdentityCredentialGitLabRefTypeTag}
+
 const (
 	GroupMembershipClaimAll              = "All"
 	GroupMembershipClaimNone             = "None"
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+// The SDK does not support flexible federated identity credentials, which are only available with the Beta API, so the
+// following model and functions are used to manage credentials having a claims matching expression
+
+type flexibleFederatedIdentityCredential struct {
+	Audiences                []string                     `json:"audiences"`
+	ClaimsMatchingExpression *federatedIdentityExpression `json:"claimsMatchingExpression,omitempty"`
+	Description              nullable.Type[string]        `json:"description,omitempty"`
+	Id                       *string                      `json:"id,omitempty"`
+	Issuer                   string                       `json:"issuer"`
+	Name                     string                       `json:"name"`
+	Subject                  nullable.Type[string]        `json:"subject,omitempty"`
+}
+
+type federatedIdentityExpression struct {
+	LanguageVersion int    `json:"languageVersion"`
+	Value           string `json:"value"`
+}
+
+func createFlexibleFederatedIdentityCredential(ctx context.Context, c *msgraph.Client, applicationId stable.ApplicationId, credential flexibleFederatedIdentityCredential) (*flexibleFederatedIdentityCredential, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusCreated,
+		},
+		HttpMethod: http.MethodPost,
+		Path:       fmt.Sprintf("%s/federatedIdentityCredentials", applicationId.ID()),
+	}
+
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = req.Marshal(credential); err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var model flexibleFederatedIdentityCredential
+	if err = resp.Unmarshal(&model); err != nil {
+		return nil, err
+	}
+
+	return &model, nil
+}
+
+func updateFlexibleFederatedIdentityCredential(ctx context.Context, c *msgraph.Client, id stable.ApplicationIdFederatedIdentityCredentialId, credential flexibleFederatedIdentityCredential) error {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPatch,
+		Path:       id.ID(),
+	}
+
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if err = req.Marshal(credential); err != nil {
+		return err
+	}
+
+	_, err = req.Execute(ctx)
+	return err
+}
+
+func getFlexibleFederatedIdentityCredential(ctx context.Context, c *msgraph.Client, id stable.ApplicationIdFederatedIdentityCredentialId) (*flexibleFederatedIdentityCredential, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       id.ID(),
+	}
+
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var model flexibleFederatedIdentityCredential
+	if err = resp.Unmarshal(&model); err != nil {
+		return nil, err
+	}
+
+	return &model, nil
+}
+
+func expandFederatedIdentityExpression(in []interface{}) *federatedIdentityExpression {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	expression := in[0].(map[string]interface{})
+	return &federatedIdentityExpression{
+		LanguageVersion: expression["language_version"].(int),
+		Value:           expression["value"].(string),
+	}
+}
+
+func flattenFederatedIdentityExpression(in *federatedIdentityExpression) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"language_version": in.LanguageVersion,
+		"value":            in.Value,
+	}}
+}
+
+// federatedIdentityCredentialPreset describes the issuer and subject, or claims matching expression, for a federated
+// identity credential trusting a well-known workload identity provider
+type federatedIdentityCredentialPreset struct {
+	Issuer                   string
+	Subject                  string
+	ClaimsMatchingExpression string
+}
+
+// newFederatedIdentityCredentialPreset returns a preset for the specified subject. When the subject contains a `*`
+// wildcard, a claims matching expression is returned instead, since subjects are matched exactly
+func newFederatedIdentityCredentialPreset(issuer, subject string) federatedIdentityCredentialPreset {
+	if strings.Contains(subject, "*") {
+		return federatedIdentityCredentialPreset{
+			Issuer:                   issuer,
+			ClaimsMatchingExpression: fmt.Sprintf("claims['sub'] matches '%s'", subject),
+		}
+	}
+
+	return federatedIdentityCredentialPreset{
+		Issuer:  issuer,
+		Subject: subject,
+	}
+}
+
+// gitHubActionsFederatedIdentityCredentialPreset returns a preset for GitHub Actions workflows, where entityType is one
+// of `branch`, `environment`, `pull_request` or `tag`
+// See https://docs.github.com/en/actions/security-for-github-actions/security-hardening-your-deployments/about-security-hardening-with-openid-connect#example-subject-claims
+func gitHubActionsFederatedIdentityCredentialPreset(issuer, organization, repository, entityType, entityValue string) federatedIdentityCredentialPreset {
+	subject := fmt.Sprintf("repo:%s/%s", organization, repository)
+
+	switch entityType {
+	case FederatedIdentityCredentialGitHubEntityTypeBranch:
+		subject += fmt.Sprintf(":ref:refs/heads/%s", entityValue)
+	case FederatedIdentityCredentialGitHubEntityTypeEnvironment:
+		subject += fmt.Sprintf(":environment:%s", entityValue)
+	case FederatedIdentityCredentialGitHubEntityTypePullRequest:
+		subject += ":pull_request"
+	case FederatedIdentityCredentialGitHubEntityTypeTag:
+		subject += fmt.Sprintf(":ref:refs/tags/%s", entityValue)
+	}
+
+	return newFederatedIdentityCredentialPreset(issuer, subject)
+}
+
+// gitLabFederatedIdentityCredentialPreset returns a preset for GitLab CI/CD pipelines, where refType is one of `branch`
+// or `tag`
+// See https://docs.gitlab.com/ee/ci/secrets/id_token_authentication.html#token-payload
+func gitLabFederatedIdentityCredentialPreset(issuer, projectPath, refType, ref string) federatedIdentityCredentialPreset {
+	return newFederatedIdentityCredentialPreset(issuer, fmt.Sprintf("project_path:%s:ref_type:%s:ref:%s", projectPath, refType, ref))
+}
+
+// terraformCloudFederatedIdentityCredentialPreset returns a preset for HCP Terraform runs, where runPhase is one of
+// `plan` or `apply`
+// See https://developer.hashicorp.com/terraform/cloud-docs/workspaces/dynamic-provider-credentials/workload-identity-tokens
+func terraformCloudFederatedIdentityCredentialPreset(issuer, organization, project, workspace, runPhase string) federatedIdentityCredentialPreset {
+	return newFederatedIdentityCredentialPreset(issuer, fmt.Sprintf("organization:%s:project:%s:workspace:%s:run_phase:%s", organization, project, workspace, runPhase))
+}
+
+// kubernetesFederatedIdentityCredentialPreset returns a preset for a Kubernetes service account, such as with Azure
+// Kubernetes Service workload identity, where the issuer is the OIDC issuer URL of the cluster
+// See https://learn.microsoft.com/en-us/azure/aks/workload-identity-overview
+func kubernetesFederatedIdentityCredentialPreset(issuer, namespace, serviceAccount string) federatedIdentityCredentialPreset {
+	return newFederatedIdentityCredentialPreset(issuer, fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount))
+}
@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+const (
+	ApplicationManifestFormatAADGraph       = "AADGraph"
+	ApplicationManifestFormatMicrosoftGraph = "MicrosoftGraph"
+)
+
+// applicationManifestAADGraph is the legacy application manifest format, as exported from the Azure Portal prior to the
+// retirement of the Azure AD Graph API. Properties which share their format with Microsoft Graph reuse the SDK models.
+type applicationManifestAADGraph struct {
+	AcceptMappedClaims             *bool                           `json:"acceptMappedClaims"`
+	AccessTokenAcceptedVersion     *int64                          `json:"accessTokenAcceptedVersion"`
+	AllowPublicClient              *bool                           `json:"allowPublicClient"`
+	AppId                          *string                         `json:"appId"`
+	AppRoles                       *[]stable.AppRole               `json:"appRoles"`
+	GroupMembershipClaims          *string                         `json:"groupMembershipClaims"`
+	Id                             *string                         `json:"id"`
+	IdentifierUris                 *[]string                       `json:"identifierUris"`
+	InformationalUrls              *applicationManifestAADGraphUrl `json:"informationalUrls"`
+	KnownClientApplications        *[]string                       `json:"knownClientApplications"`
+	LogoutUrl                      *string                         `json:"logoutUrl"`
+	Name                           *string                         `json:"name"`
+	Notes                          *string                         `json:"notes"`
+	OAuth2AllowIdTokenImplicitFlow *bool                           `json:"oauth2AllowIdTokenImplicitFlow"`
+	OAuth2AllowImplicitFlow        *bool                           `json:"oauth2AllowImplicitFlow"`
+	OAuth2Permissions              *[]stable.PermissionScope       `json:"oauth2Permissions"`
+	OptionalClaims                 *stable.OptionalClaims          `json:"optionalClaims"`
+	PreAuthorizedApplications      *[]struct {
+		AppId         *string   `json:"appId"`
+		PermissionIds *[]string `json:"permissionIds"`
+	} `json:"preAuthorizedApplications"`
+	ReplyUrlsWithType *[]struct {
+		Type string `json:"type"`
+		Url  string `json:"url"`
+	} `json:"replyUrlsWithType"`
+	RequiredResourceAccess *[]stable.RequiredResourceAccess `json:"requiredResourceAccess"`
+	SignInAudience         *string                          `json:"signInAudience"`
+	SignInUrl              *string                          `json:"signInUrl"`
+	Tags                   *[]string                        `json:"tags"`
+	TokenEncryptionKeyId   *string                          `json:"tokenEncryptionKeyId"`
+}
+
+type applicationManifestAADGraphUrl struct {
+	Marketing      *string `json:"marketing"`
+	Privacy        *string `json:"privacy"`
+	Support        *string `json:"support"`
+	TermsOfService *string `json:"termsOfService"`
+}
+
+// applicationManifestFormat detects the format of an application manifest. Legacy manifests are identified by the
+// presence of any top-level property which only exists in the AAD Graph format.
+func applicationManifestFormat(manifest map[string]json.RawMessage) string {
+	for _, k := range []string{"accessTokenAcceptedVersion", "allowPublicClient", "informationalUrls", "oauth2Permissions", "replyUrlsWithType"} {
+		if _, ok := manifest[k]; ok {
+			return ApplicationManifestFormatAADGraph
+		}
+	}
+	return ApplicationManifestFormatMicrosoftGraph
+}
+
+// parseApplicationManifest parses an application manifest in either the legacy AAD Graph format or the Microsoft
+// Graph format, returning the detected format and the resulting application
+func parseApplicationManifest(input string) (string, *stable.Application, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(input), &raw); err != nil {
+		return "", nil, fmt.Errorf("parsing manifest: %+v", err)
+	}
+
+	format := applicationManifestFormat(raw)
+
+	if format == ApplicationManifestFormatMicrosoftGraph {
+		var app stable.Application
+		if err := json.Unmarshal([]byte(input), &app); err != nil {
+			return "", nil, fmt.Errorf("parsing %s manifest: %+v", format, err)
+		}
+		return format, &app, nil
+	}
+
+	var manifest applicationManifestAADGraph
+	if err := json.Unmarshal([]byte(input), &manifest); err != nil {
+		return "", nil, fmt.Errorf("parsing %s manifest: %+v", format, err)
+	}
+
+	return format, expandApplicationManifestAADGraph(manifest), nil
+}
+
+// expandApplicationManifestAADGraph converts a legacy AAD Graph manifest to the equivalent Microsoft Graph application
+func expandApplicationManifestAADGraph(in applicationManifestAADGraph) *stable.Application {
+	app := stable.Application{
+		AppId:                  nullable.NoZero(pointer.From(in.AppId)),
+		AppRoles:               in.AppRoles,
+		DisplayName:            nullable.NoZero(pointer.From(in.Name)),
+		GroupMembershipClaims:  nullable.NoZero(pointer.From(in.GroupMembershipClaims)),
+		Id:                     in.Id,
+		IdentifierUris:         in.IdentifierUris,
+		Notes:                  nullable.NoZero(pointer.From(in.Notes)),
+		OptionalClaims:         in.OptionalClaims,
+		RequiredResourceAccess: in.RequiredResourceAccess,
+		SignInAudience:         nullable.NoZero(pointer.From(in.SignInAudience)),
+		Tags:                   in.Tags,
+		TokenEncryptionKeyId:   nullable.NoZero(pointer.From(in.TokenEncryptionKeyId)),
+		Api: &stable.ApiApplication{
+			KnownClientApplications: in.KnownClientApplications,
+			OAuth2PermissionScopes:  in.OAuth2Permissions,
+		},
+		PublicClient: &stable.PublicClientApplication{
+			RedirectUris: &[]string{},
+		},
+		Spa: &stable.SpaApplication{
+			RedirectUris: &[]string{},
+		},
+		Web: &stable.WebApplication{
+			HomePageUrl:  nullable.NoZero(pointer.From(in.SignInUrl)),
+			LogoutUrl:    nullable.NoZero(pointer.From(in.LogoutUrl)),
+			RedirectUris: &[]string{},
+			ImplicitGrantSettings: &stable.ImplicitGrantSettings{
+				EnableAccessTokenIssuance: nullable.Value(pointer.From(in.OAuth2AllowImplicitFlow)),
+				EnableIdTokenIssuance:     nullable.Value(pointer.From(in.OAuth2AllowIdTokenImplicitFlow)),
+			},
+		},
+	}
+
+	if in.AcceptMappedClaims != nil {
+		app.Api.AcceptMappedClaims = nullable.Value(*in.AcceptMappedClaims)
+	}
+	if in.AccessTokenAcceptedVersion != nil {
+		app.Api.RequestedAccessTokenVersion = nullable.Value(*in.AccessTokenAcceptedVersion)
+	}
+	if in.AllowPublicClient != nil {
+		app.IsFallbackPublicClient = nullable.Value(*in.AllowPublicClient)
+	}
+
+	if in.InformationalUrls != nil {
+		app.Info = &stable.InformationalUrl{
+			MarketingUrl:        nullable.NoZero(pointer.From(in.InformationalUrls.Marketing)),
+			PrivacyStatementUrl: nullable.NoZero(pointer.From(in.InformationalUrls.Privacy)),
+			SupportUrl:          nullable.NoZero(pointer.From(in.InformationalUrls.Support)),
+			TermsOfServiceUrl:   nullable.NoZero(pointer.From(in.InformationalUrls.TermsOfService)),
+		}
+	}
+
+	if in.PreAuthorizedApplications != nil {
+		preAuthorizedApplications := make([]stable.PreAuthorizedApplication, 0)
+		for _, p := range *in.PreAuthorizedApplications {
+			preAuthorizedApplications = append(preAuthorizedApplications, stable.PreAuthorizedApplication{
+				AppId:                  nullable.NoZero(pointer.From(p.AppId)),
+				DelegatedPermissionIds: p.PermissionIds,
+			})
+		}
+		app.Api.PreAuthorizedApplications = &preAuthorizedApplications
+	}
+
+	// Reply URLs were a single list in the legacy format, which are now separated by platform
+	if in.ReplyUrlsWithType != nil {
+		for _, replyUrl := range *in.ReplyUrlsWithType {
+			switch replyUrl.Type {
+			case "InstalledClient":
+				*app.PublicClient.RedirectUris = append(*app.PublicClient.RedirectUris, replyUrl.Url)
+			case "Spa":
+				*app.Spa.RedirectUris = append(*app.Spa.RedirectUris, replyUrl.Url)
+			default:
+				*app.Web.RedirectUris = append(*app.Web.RedirectUris, replyUrl.Url)
+			}
+		}
+	}
+
+	return &app
+}
+
+// flattenApplicationManifest returns the Microsoft Graph manifest for an application, which includes the read-only
+// client ID of the application that is otherwise omitted when marshaling the model
+func flattenApplicationManifest(app stable.Application) (string, error) {
+	app.OmitDiscriminatedValue = true
+
+	encoded, err := json.Marshal(app)
+	if err != nil {
+		return "", err
+	}
+
+	var manifest map[string]interface{}
+	if err = json.Unmarshal(encoded, &manifest); err != nil {
+		return "", err
+	}
+
+	if appId := app.AppId.GetOrZero(); appId != "" {
+		manifest["appId"] = appId
+	}
+
+	encoded, err = json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,329 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applications
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+)
+
+const testApplicationManifestAADGraph = `{
+  "id": "00000000-0000-0000-0000-000000000001",
+  "appId": "00000000-0000-0000-0000-000000000002",
+  "name": "acctest-manifest",
+  "acceptMappedClaims": true,
+  "accessTokenAcceptedVersion": 2,
+  "allowPublicClient": true,
+  "groupMembershipClaims": "SecurityGroup",
+  "identifierUris": ["api://acctest-manifest"],
+  "informationalUrls": {
+    "marketing": "https://example.com/marketing",
+    "privacy": "https://example.com/privacy",
+    "support": null,
+    "termsOfService": "https://example.com/terms"
+  },
+  "knownClientApplications": ["00000000-0000-0000-0000-000000000003"],
+  "logoutUrl": "https://example.com/logout",
+  "notes": "Some notes",
+  "oauth2AllowIdTokenImplicitFlow": true,
+  "oauth2AllowImplicitFlow": false,
+  "oauth2Permissions": [
+    {
+      "id": "00000000-0000-0000-0000-000000000004",
+      "value": "user_impersonation",
+      "type": "User",
+      "isEnabled": true
+    }
+  ],
+  "preAuthorizedApplications": [
+    {
+      "appId": "00000000-0000-0000-0000-000000000005",
+      "permissionIds": ["00000000-0000-0000-0000-000000000004"]
+    }
+  ],
+  "replyUrlsWithType": [
+    {"url": "https://example.com/auth", "type": "Web"},
+    {"url": "https://example.com/spa", "type": "Spa"},
+    {"url": "myapp://auth", "type": "InstalledClient"}
+  ],
+  "signInAudience": "AzureADMyOrg",
+  "signInUrl": "https://example.com",
+  "tags": ["HideApp"]
+}`
+
+const testApplicationManifestMicrosoftGraph = `{
+  "id": "00000000-0000-0000-0000-000000000001",
+  "appId": "00000000-0000-0000-0000-000000000002",
+  "displayName": "acctest-manifest",
+  "groupMembershipClaims": "SecurityGroup",
+  "identifierUris": ["api://acctest-manifest"],
+  "isFallbackPublicClient": true,
+  "signInAudience": "AzureADMyOrg",
+  "api": {
+    "requestedAccessTokenVersion": 2,
+    "knownClientApplications": ["00000000-0000-0000-0000-000000000003"]
+  },
+  "web": {
+    "homePageUrl": "https://example.com",
+    "redirectUris": ["https://example.com/auth"]
+  },
+  "tags": ["HideApp"]
+}`
+
+func TestParseApplicationManifest(t *testing.T) {
+	cases := []struct {
+		TestName       string
+		Input          string
+		ExpectedFormat string
+		Expected       func(t *testing.T, app *stable.Application)
+		Error          bool
+	}{
+		{
+			TestName:       "AADGraph",
+			Input:          testApplicationManifestAADGraph,
+			ExpectedFormat: ApplicationManifestFormatAADGraph,
+			Expected: func(t *testing.T, app *stable.Application) {
+				if v := app.DisplayName.GetOrZero(); v != "acctest-manifest" {
+					t.Fatalf("expected display name %q, got %q", "acctest-manifest", v)
+				}
+				if v := app.Api.RequestedAccessTokenVersion.GetOrZero(); v != 2 {
+					t.Fatalf("expected requested access token version 2, got %d", v)
+				}
+				if expected := []string{"https://example.com/auth"}; !reflect.DeepEqual(expected, pointer.From(app.Web.RedirectUris)) {
+					t.Fatalf("unexpected web redirect URIs\nexpected: %v\nactual:   %v", expected, pointer.From(app.Web.RedirectUris))
+				}
+			},
+		},
+		{
+			TestName:       "MicrosoftGraph",
+			Input:          testApplicationManifestMicrosoftGraph,
+			ExpectedFormat: ApplicationManifestFormatMicrosoftGraph,
+			Expected: func(t *testing.T, app *stable.Application) {
+				if v := app.DisplayName.GetOrZero(); v != "acctest-manifest" {
+					t.Fatalf("expected display name %q, got %q", "acctest-manifest", v)
+				}
+				if v := app.IsFallbackPublicClient.GetOrZero(); !v {
+					t.Fatalf("expected fallback public client to be true")
+				}
+				if v := app.Web.HomePageUrl.GetOrZero(); v != "https://example.com" {
+					t.Fatalf("expected home page URL %q, got %q", "https://example.com", v)
+				}
+			},
+		},
+		{
+			TestName:       "MicrosoftGraphEmpty",
+			Input:          `{}`,
+			ExpectedFormat: ApplicationManifestFormatMicrosoftGraph,
+			Expected: func(t *testing.T, app *stable.Application) {
+				if app.DisplayName.IsSet() {
+					t.Fatalf("expected display name to be unset, got %q", app.DisplayName.GetOrZero())
+				}
+			},
+		},
+		{
+			TestName: "InvalidJSON",
+			Input:    `{"displayName":`,
+			Error:    true,
+		},
+		{
+			TestName: "NotAnObject",
+			Input:    `["displayName"]`,
+			Error:    true,
+		},
+		{
+			TestName: "InvalidAADGraphProperty",
+			Input:    `{"allowPublicClient": "yes"}`,
+			Error:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			format, app, err := parseApplicationManifest(tc.Input)
+			if tc.Error {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if format != tc.ExpectedFormat {
+				t.Fatalf("expected format %q, got %q", tc.ExpectedFormat, format)
+			}
+			tc.Expected(t, app)
+		})
+	}
+}
+
+func TestExpandApplicationManifestAADGraph(t *testing.T) {
+	cases := []struct {
+		TestName string
+		Input    string
+		Expected func(t *testing.T, app *stable.Application)
+	}{
+		{
+			TestName: "Complete",
+			Input:    testApplicationManifestAADGraph,
+			Expected: func(t *testing.T, app *stable.Application) {
+				if v := pointer.From(app.Id); v != "00000000-0000-0000-0000-000000000001" {
+					t.Fatalf("unexpected object ID %q", v)
+				}
+				if v := app.AppId.GetOrZero(); v != "00000000-0000-0000-0000-000000000002" {
+					t.Fatalf("unexpected client ID %q", v)
+				}
+				if v := app.Api.AcceptMappedClaims.GetOrZero(); !v {
+					t.Fatalf("expected accept mapped claims to be true")
+				}
+				if v := app.IsFallbackPublicClient.GetOrZero(); !v {
+					t.Fatalf("expected fallback public client to be true")
+				}
+				if v := app.Notes.GetOrZero(); v != "Some notes" {
+					t.Fatalf("expected notes %q, got %q", "Some notes", v)
+				}
+
+				if v := app.Info.MarketingUrl.GetOrZero(); v != "https://example.com/marketing" {
+					t.Fatalf("unexpected marketing URL %q", v)
+				}
+				if !app.Info.SupportUrl.IsNull() {
+					t.Fatalf("expected support URL to be null, got %q", app.Info.SupportUrl.GetOrZero())
+				}
+
+				scopes := pointer.From(app.Api.OAuth2PermissionScopes)
+				if len(scopes) != 1 || scopes[0].Value.GetOrZero() != "user_impersonation" {
+					t.Fatalf("unexpected permission scopes: %+v", scopes)
+				}
+
+				preAuthorized := pointer.From(app.Api.PreAuthorizedApplications)
+				if len(preAuthorized) != 1 || preAuthorized[0].AppId.GetOrZero() != "00000000-0000-0000-0000-000000000005" {
+					t.Fatalf("unexpected pre-authorized applications: %+v", preAuthorized)
+				}
+				if expected := []string{"00000000-0000-0000-0000-000000000004"}; !reflect.DeepEqual(expected, pointer.From(preAuthorized[0].DelegatedPermissionIds)) {
+					t.Fatalf("unexpected delegated permission IDs: %v", pointer.From(preAuthorized[0].DelegatedPermissionIds))
+				}
+
+				if expected := []string{"https://example.com/auth"}; !reflect.DeepEqual(expected, pointer.From(app.Web.RedirectUris)) {
+					t.Fatalf("unexpected web redirect URIs: %v", pointer.From(app.Web.RedirectUris))
+				}
+				if expected := []string{"https://example.com/spa"}; !reflect.DeepEqual(expected, pointer.From(app.Spa.RedirectUris)) {
+					t.Fatalf("unexpected SPA redirect URIs: %v", pointer.From(app.Spa.RedirectUris))
+				}
+				if expected := []string{"myapp://auth"}; !reflect.DeepEqual(expected, pointer.From(app.PublicClient.RedirectUris)) {
+					t.Fatalf("unexpected public client redirect URIs: %v", pointer.From(app.PublicClient.RedirectUris))
+				}
+
+				if v := app.Web.HomePageUrl.GetOrZero(); v != "https://example.com" {
+					t.Fatalf("unexpected home page URL %q", v)
+				}
+				if v := app.Web.LogoutUrl.GetOrZero(); v != "https://example.com/logout" {
+					t.Fatalf("unexpected logout URL %q", v)
+				}
+				if v := app.Web.ImplicitGrantSettings.EnableIdTokenIssuance.GetOrZero(); !v {
+					t.Fatalf("expected ID token issuance to be enabled")
+				}
+				if v := app.Web.ImplicitGrantSettings.EnableAccessTokenIssuance.GetOrZero(); v {
+					t.Fatalf("expected access token issuance to be disabled")
+				}
+			},
+		},
+		{
+			TestName: "Minimal",
+			Input:    `{"name": "acctest-manifest", "replyUrlsWithType": []}`,
+			Expected: func(t *testing.T, app *stable.Application) {
+				if v := app.DisplayName.GetOrZero(); v != "acctest-manifest" {
+					t.Fatalf("expected display name %q, got %q", "acctest-manifest", v)
+				}
+				if app.Info != nil {
+					t.Fatalf("expected informational URLs to be nil, got %+v", app.Info)
+				}
+				if app.Api.PreAuthorizedApplications != nil {
+					t.Fatalf("expected pre-authorized applications to be nil")
+				}
+				if app.Api.RequestedAccessTokenVersion.IsSet() || app.IsFallbackPublicClient.IsSet() {
+					t.Fatalf("expected unspecified properties to be unset")
+				}
+				for name, uris := range map[string]*[]string{"public client": app.PublicClient.RedirectUris, "SPA": app.Spa.RedirectUris, "web": app.Web.RedirectUris} {
+					if uris == nil || len(*uris) != 0 {
+						t.Fatalf("expected empty %s redirect URIs, got %v", name, uris)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			var manifest applicationManifestAADGraph
+			if err := json.Unmarshal([]byte(tc.Input), &manifest); err != nil {
+				t.Fatalf("unmarshaling manifest: %v", err)
+			}
+
+			tc.Expected(t, expandApplicationManifestAADGraph(manifest))
+		})
+	}
+}
+
+func TestFlattenApplicationManifestRoundTrip(t *testing.T) {
+	cases := []struct {
+		TestName string
+		Input    string
+	}{
+		{
+			TestName: "AADGraph",
+			Input:    testApplicationManifestAADGraph,
+		},
+		{
+			TestName: "MicrosoftGraph",
+			Input:    testApplicationManifestMicrosoftGraph,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			_, app, err := parseApplicationManifest(tc.Input)
+			if err != nil {
+				t.Fatalf("parsing manifest: %v", err)
+			}
+
+			flattened, err := flattenApplicationManifest(*app)
+			if err != nil {
+				t.Fatalf("flattening manifest: %v", err)
+			}
+
+			var manifest map[string]interface{}
+			if err = json.Unmarshal([]byte(flattened), &manifest); err != nil {
+				t.Fatalf("decoding flattened manifest: %v", err)
+			}
+			if _, ok := manifest["@odata.type"]; ok {
+				t.Fatalf("expected flattened manifest to omit the OData type")
+			}
+			if v := manifest["appId"]; v != "00000000-0000-0000-0000-000000000002" {
+				t.Fatalf("expected flattened manifest to include the client ID, got %v", v)
+			}
+
+			// The flattened manifest is in the Microsoft Graph format, and must parse to the same application
+			format, roundTripped, err := parseApplicationManifest(flattened)
+			if err != nil {
+				t.Fatalf("parsing flattened manifest: %v", err)
+			}
+			if format != ApplicationManifestFormatMicrosoftGraph {
+				t.Fatalf("expected flattened manifest to have format %q, got %q", ApplicationManifestFormatMicrosoftGraph, format)
+			}
+
+			reflattened, err := flattenApplicationManifest(*roundTripped)
+			if err != nil {
+				t.Fatalf("flattening round-tripped manifest: %v", err)
+			}
+			if reflattened != flattened {
+				t.Fatalf("manifest did not round trip\nexpected: %s\nactual:   %s", flattened, reflattened)
+			}
+		})
+	}
+}
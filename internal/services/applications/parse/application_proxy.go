@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type ApplicationProxyId struct {
+	ApplicationId string
+}
+
+func NewApplicationProxyID(applicationId string) *ApplicationProxyId {
+	return &ApplicationProxyId{
+		ApplicationId: applicationId,
+	}
+}
+
+// ParseApplicationProxyID parses 'input' into an ApplicationProxyId
+func ParseApplicationProxyID(input string) (*ApplicationProxyId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&ApplicationProxyId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	var ok bool
+	id := &ApplicationProxyId{}
+
+	if id.ApplicationId, ok = parsed.Parsed["applicationId"]; !ok {
+		return nil, resourceids.NewSegmentNotSpecifiedError(id, "applicationId", *parsed)
+	}
+
+	return id, nil
+}
+
+// ValidateApplicationProxyID checks that 'input' can be parsed as an Application ID
+func ValidateApplicationProxyID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := ParseApplicationProxyID(v)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+
+	return validation.IsUUID(id.ApplicationId, "ID")
+}
+
+func (id *ApplicationProxyId) ID() string {
+	fmtString := "/applications/%s/onPremisesPublishing"
+	return fmt.Sprintf(fmtString, id.ApplicationId)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this ID
+func (id *ApplicationProxyId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("applications", "applications", "applications"),
+		resourceids.UserSpecifiedSegment("applicationId", "00000000-0000-0000-0000-000000000000"),
+		resourceids.StaticSegment("onPremisesPublishing", "onPremisesPublishing", "onPremisesPublishing"),
+	}
+}
+
+func (id *ApplicationProxyId) String() string {
+	return fmt.Sprintf("Application Proxy (Application ID: %q)", id.ApplicationId)
+}
+
+func (id *ApplicationProxyId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.ApplicationId, ok = input.Parsed["applicationId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "applicationId", input)
+	}
+
+	return nil
+}
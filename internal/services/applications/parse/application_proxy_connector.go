@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type ApplicationProxyConnectorId struct {
+	ConnectorId string
+}
+
+func NewApplicationProxyConnectorID(connectorId string) *ApplicationProxyConnectorId {
+	return &ApplicationProxyConnectorId{
+		ConnectorId: connectorId,
+	}
+}
+
+// ParseApplicationProxyConnectorID parses 'input' into an ApplicationProxyConnectorId
+func ParseApplicationProxyConnectorID(input string) (*ApplicationProxyConnectorId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&ApplicationProxyConnectorId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	var ok bool
+	id := &ApplicationProxyConnectorId{}
+
+	if id.ConnectorId, ok = parsed.Parsed["connectorId"]; !ok {
+		return nil, resourceids.NewSegmentNotSpecifiedError(id, "connectorId", *parsed)
+	}
+
+	return id, nil
+}
+
+// ValidateApplicationProxyConnectorID checks that 'input' can be parsed as an Application Proxy Connector ID
+func ValidateApplicationProxyConnectorID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := ParseApplicationProxyConnectorID(v)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+
+	return validation.IsUUID(id.ConnectorId, "ID")
+}
+
+func (id *ApplicationProxyConnectorId) ID() string {
+	fmtString := "/onPremisesPublishingProfiles/applicationProxy/connectors/%s"
+	return fmt.Sprintf(fmtString, id.ConnectorId)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this ID
+func (id *ApplicationProxyConnectorId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("onPremisesPublishingProfiles", "onPremisesPublishingProfiles", "onPremisesPublishingProfiles"),
+		resourceids.StaticSegment("applicationProxy", "applicationProxy", "applicationProxy"),
+		resourceids.StaticSegment("connectors", "connectors", "connectors"),
+		resourceids.UserSpecifiedSegment("connectorId", "00000000-0000-0000-0000-000000000000"),
+	}
+}
+
+func (id *ApplicationProxyConnectorId) String() string {
+	return fmt.Sprintf("Application Proxy Connector (Connector ID: %q)", id.ConnectorId)
+}
+
+func (id *ApplicationProxyConnectorId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.ConnectorId, ok = input.Parsed["connectorId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "connectorId", input)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type ApplicationProxyConnectorGroupId struct {
+	ConnectorGroupId string
+}
+
+func NewApplicationProxyConnectorGroupID(connectorGroupId string) *ApplicationProxyConnectorGroupId {
+	return &ApplicationProxyConnectorGroupId{
+		ConnectorGroupId: connectorGroupId,
+	}
+}
+
+// ParseApplicationProxyConnectorGroupID parses 'input' into an ApplicationProxyConnectorGroupId
+func ParseApplicationProxyConnectorGroupID(input string) (*ApplicationProxyConnectorGroupId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&ApplicationProxyConnectorGroupId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	var ok bool
+	id := &ApplicationProxyConnectorGroupId{}
+
+	if id.ConnectorGroupId, ok = parsed.Parsed["connectorGroupId"]; !ok {
+		return nil, resourceids.NewSegmentNotSpecifiedError(id, "connectorGroupId", *parsed)
+	}
+
+	return id, nil
+}
+
+// ValidateApplicationProxyConnectorGroupID checks that 'input' can be parsed as an Application Proxy Connector Group ID
+func ValidateApplicationProxyConnectorGroupID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := ParseApplicationProxyConnectorGroupID(v)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+
+	return validation.IsUUID(id.ConnectorGroupId, "ID")
+}
+
+func (id *ApplicationProxyConnectorGroupId) ID() string {
+	fmtString := "/onPremisesPublishingProfiles/applicationProxy/connectorGroups/%s"
+	return fmt.Sprintf(fmtString, id.ConnectorGroupId)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this ID
+func (id *ApplicationProxyConnectorGroupId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("onPremisesPublishingProfiles", "onPremisesPublishingProfiles", "onPremisesPublishingProfiles"),
+		resourceids.StaticSegment("applicationProxy", "applicationProxy", "applicationProxy"),
+		resourceids.StaticSegment("connectorGroups", "connectorGroups", "connectorGroups"),
+		resourceids.UserSpecifiedSegment("connectorGroupId", "00000000-0000-0000-0000-000000000000"),
+	}
+}
+
+func (id *ApplicationProxyConnectorGroupId) String() string {
+	return fmt.Sprintf("Application Proxy Connector Group (Connector Group ID: %q)", id.ConnectorGroupId)
+}
+
+func (id *ApplicationProxyConnectorGroupId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.ConnectorGroupId, ok = input.Parsed["connectorGroupId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "connectorGroupId", input)
+	}
+
+	return nil
+}
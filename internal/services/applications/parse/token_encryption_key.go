@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type TokenEncryptionKeyId struct {
+	ApplicationId string
+}
+
+func NewTokenEncryptionKeyID(applicationId string) *TokenEncryptionKeyId {
+	return &TokenEncryptionKeyId{
+		ApplicationId: applicationId,
+	}
+}
+
+// ParseTokenEncryptionKeyID parses 'input' into an TokenEncryptionKeyId
+func ParseTokenEncryptionKeyID(input string) (*TokenEncryptionKeyId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&TokenEncryptionKeyId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	var ok bool
+	id := &TokenEncryptionKeyId{}
+
+	if id.ApplicationId, ok = parsed.Parsed["applicationId"]; !ok {
+		return nil, resourceids.NewSegmentNotSpecifiedError(id, "applicationId", *parsed)
+	}
+
+	return id, nil
+}
+
+// ValidateTokenEncryptionKeyID checks that 'input' can be parsed as an Application ID
+func ValidateTokenEncryptionKeyID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := ParseTokenEncryptionKeyID(v)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+
+	return validation.IsUUID(id.ApplicationId, "ID")
+}
+
+func (id *TokenEncryptionKeyId) ID() string {
+	fmtString := "/applications/%s/tokenEncryptionKey"
+	return fmt.Sprintf(fmtString, id.ApplicationId)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this ID
+func (id *TokenEncryptionKeyId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("applications", "applications", "applications"),
+		resourceids.UserSpecifiedSegment("applicationId", "00000000-0000-0000-0000-000000000000"),
+		resourceids.StaticSegment("tokenEncryptionKey", "tokenEncryptionKey", "tokenEncryptionKey"),
+	}
+}
+
+func (id *TokenEncryptionKeyId) String() string {
+	return fmt.Sprintf("Token Encryption Key (Application ID: %q)", id.ApplicationId)
+}
+
+func (id *TokenEncryptionKeyId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.ApplicationId, ok = input.Parsed["applicationId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "applicationId", input)
+	}
+
+	return nil
+}
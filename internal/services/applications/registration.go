@@ -30,9 +30,12 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azuread_application":                   applicationDataSource(),
-		"azuread_application_published_app_ids": applicationPublishedAppIdsDataSource(),
-		"azuread_application_template":          applicationTemplateDataSource(),
+		"azuread_application":                                      applicationDataSource(),
+		"azuread_application_credentials":                          applicationCredentialsDataSource(),
+		"azuread_application_federated_identity_credential_preset": applicationFederatedIdentityCredentialPresetDataSource(),
+		"azuread_application_manifest":                             applicationManifestDataSource(),
+		"azuread_application_published_app_ids":                    applicationPublishedAppIdsDataSource(),
+		"azuread_application_template":                             applicationTemplateDataSource(),
 	}
 }
 
@@ -49,7 +52,10 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 
 // DataSources returns the typed DataSources supported by this service
 func (r Registration) DataSources() []sdk.DataSource {
-	return []sdk.DataSource{}
+	return []sdk.DataSource{
+		ApplicationProxyConnectorDataSource{},
+		ApplicationProxyConnectorGroupDataSource{},
+	}
 }
 
 // Resources returns the typed Resources supported by this service
@@ -64,7 +70,9 @@ func (r Registration) Resources() []sdk.Resource {
 		ApplicationOptionalClaimsResource{},
 		ApplicationOwnerResource{},
 		ApplicationPermissionScopeResource{},
+		ApplicationProxyResource{},
 		ApplicationRedirectUrisResource{},
 		ApplicationRegistrationResource{},
+		ApplicationTokenEncryptionKeyResource{},
 	}
 }
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+)
+
+var (
+	claimsMatchingExpressionClause    = regexp.MustCompile(`^claims\['([A-Za-z0-9_.:-]+)'\]\s+(\S+)\s+'([^']*)'`)
+	claimsMatchingExpressionConjunct  = regexp.MustCompile(`^\s+and\s+`)
+	claimsMatchingExpressionOperators = []string{"eq", "matches"}
+)
+
+// ClaimsMatchingExpression checks whether a value is a valid claims matching expression for a flexible federated identity
+// credential, using version 1 of the expression language. An expression consists of one or more clauses joined with
+// `and`, where each clause compares a claim with a single-quoted value using either the `eq` or `matches` operator, e.g.
+// `claims['sub'] matches 'repo:contoso/contoso-repo:ref:refs/heads/*'`.
+// See https://learn.microsoft.com/en-us/entra/workload-id/workload-identities-flexible-federated-identity-credentials
+func ClaimsMatchingExpression(i interface{}, path cty.Path) (ret pluginsdk.Diagnostics) {
+	v, ok := i.(string)
+	if !ok {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Expected a string value",
+			AttributePath: path,
+		})
+		return
+	}
+
+	if strings.TrimSpace(v) == "" {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Expression must not be empty",
+			AttributePath: path,
+		})
+		return
+	}
+
+	if len(v) > 600 {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Expression must be 600 characters or less in length",
+			AttributePath: path,
+		})
+	}
+
+	remaining := strings.TrimSpace(v)
+	for {
+		match := claimsMatchingExpressionClause.FindStringSubmatch(remaining)
+		if match == nil {
+			ret = append(ret, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid claims matching expression",
+				Detail:        fmt.Sprintf("Expected a clause in the form `claims['<name>'] <operator> '<value>'` at %q", remaining),
+				AttributePath: path,
+			})
+			return
+		}
+
+		if operator := match[2]; !slices.Contains(claimsMatchingExpressionOperators, strings.ToLower(operator)) {
+			ret = append(ret, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid operator in claims matching expression",
+				Detail:        fmt.Sprintf("Operator %q is not supported, expected one of: %s", operator, strings.Join(claimsMatchingExpressionOperators, ", ")),
+				AttributePath: path,
+			})
+		}
+
+		if match[3] == "" {
+			ret = append(ret, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid value in claims matching expression",
+				Detail:        fmt.Sprintf("The value for claim %q must not be empty", match[1]),
+				AttributePath: path,
+			})
+		}
+
+		remaining = remaining[len(match[0]):]
+		if remaining == "" {
+			break
+		}
+
+		conjunct := claimsMatchingExpressionConjunct.FindString(remaining)
+		if conjunct == "" {
+			ret = append(ret, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid claims matching expression",
+				Detail:        fmt.Sprintf("Expected `and` or the end of the expression at %q", strings.TrimSpace(remaining)),
+				AttributePath: path,
+			})
+			return
+		}
+		remaining = remaining[len(conjunct):]
+	}
+
+	return // nolint:nakedret
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestClaimsMatchingExpression(t *testing.T) {
+	cases := []struct {
+		Value    string
+		TestName string
+		ErrCount int
+	}{
+		{
+			Value:    "claims['sub'] matches 'repo:contoso/contoso-repo:ref:refs/heads/*'",
+			TestName: "Valid_Matches",
+			ErrCount: 0,
+		},
+		{
+			Value:    "claims['sub'] eq 'repo:contoso/contoso-repo:environment:Production'",
+			TestName: "Valid_Eq",
+			ErrCount: 0,
+		},
+		{
+			Value:    "claims['sub'] matches 'repo:contoso/*:environment:Production' and claims['job_workflow_ref'] matches 'contoso/workflows/.github/workflows/deploy.yml@*'",
+			TestName: "Valid_And",
+			ErrCount: 0,
+		},
+		{
+			Value:    "  claims['sub']   matches   'repo:contoso/contoso-repo:pull_request'  ",
+			TestName: "Valid_Whitespace",
+			ErrCount: 0,
+		},
+		{
+			Value:    "claims['sub'] matches 'foo and bar'",
+			TestName: "Valid_ValueContainsAnd",
+			ErrCount: 0,
+		},
+		{
+			Value:    "",
+			TestName: "Invalid_Empty",
+			ErrCount: 1,
+		},
+		{
+			Value:    "claims['sub'] contains 'repo:contoso/*'",
+			TestName: "Invalid_Operator",
+			ErrCount: 1,
+		},
+		{
+			Value:    "claims['sub'] matches ''",
+			TestName: "Invalid_EmptyValue",
+			ErrCount: 1,
+		},
+		{
+			Value:    "claims[sub] matches 'repo:contoso/*'",
+			TestName: "Invalid_UnquotedClaim",
+			ErrCount: 1,
+		},
+		{
+			Value:    "claims['sub'] matches \"repo:contoso/*\"",
+			TestName: "Invalid_DoubleQuotedValue",
+			ErrCount: 1,
+		},
+		{
+			Value:    "claims['sub'] matches 'repo:contoso/*' or claims['sub'] matches 'repo:fabrikam/*'",
+			TestName: "Invalid_Or",
+			ErrCount: 1,
+		},
+		{
+			Value:    "claims['sub'] matches 'repo:contoso/*' and",
+			TestName: "Invalid_TrailingAnd",
+			ErrCount: 1,
+		},
+		{
+			Value:    "claims['sub'] matches '" + strings.Repeat("a", 600) + "'",
+			TestName: "Invalid_MaxLength",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			diags := ClaimsMatchingExpression(tc.Value, cty.Path{})
+
+			if len(diags) != tc.ErrCount {
+				t.Fatalf("Expected ClaimsMatchingExpression to have %d not %d errors for %q", tc.ErrCount, len(diags), tc.TestName)
+			}
+		})
+	}
+}
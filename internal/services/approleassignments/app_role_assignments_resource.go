@@ -0,0 +1,428 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directoryobjects/stable/directoryobject"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/approleassignedto"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/applications"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/approleassignments/parse"
+)
+
+const appRoleAssignmentsResourceName = "azuread_app_role_assignments"
+
+// appRoleAssignmentsBatchSize is the maximum number of assignments which are created or deleted concurrently
+const appRoleAssignmentsBatchSize = 20
+
+func appRoleAssignmentsResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: appRoleAssignmentsResourceCreate,
+		ReadContext:   appRoleAssignmentsResourceRead,
+		UpdateContext: appRoleAssignmentsResourceUpdate,
+		DeleteContext: appRoleAssignmentsResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(15 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(15 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(15 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := parse.ValidateAppRoleAssignmentsID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return fmt.Errorf(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"resource_object_id": {
+				Description:  "The object ID of the service principal representing the resource",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"app_role_value": {
+				Description:  "The value of the app role to be assigned, as exposed by the resource service principal",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"principal_object_ids": {
+				Description: "The object IDs of the users, groups or service principals to be assigned the app role",
+				Type:        pluginsdk.TypeSet,
+				Required:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+
+			"authoritative": {
+				Description: "Whether this resource manages all assignments of the app role, removing any assignments for principals not specified in `principal_object_ids`",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"app_role_id": {
+				Description: "The ID of the assigned app role",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"missing_principal_object_ids": {
+				Description: "The object IDs of principals specified in `principal_object_ids` which no longer exist, and which are therefore not assigned the app role",
+				Type:        pluginsdk.TypeSet,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func appRoleAssignmentsResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	servicePrincipalClient := meta.(*clients.Client).AppRoleAssignments.ServicePrincipalClient
+
+	resourceId := d.Get("resource_object_id").(string)
+	appRoleValue := d.Get("app_role_value").(string)
+
+	resp, err := servicePrincipalClient.GetServicePrincipal(ctx, stable.NewServicePrincipalID(resourceId), serviceprincipal.DefaultGetServicePrincipalOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagPathF(err, "resource_object_id", "Service principal not found for resource (Object ID: %q)", resourceId)
+		}
+		return tf.ErrorDiagF(err, "Could not retrieve service principal for resource (Object ID: %q)", resourceId)
+	}
+	if resp.Model == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Could not retrieve service principal for resource (Object ID: %q)", resourceId)
+	}
+
+	appRoleId, ok := applications.FlattenAppRoleIDs(resp.Model.AppRoles)[appRoleValue]
+	if !ok {
+		return tf.ErrorDiagPathF(nil, "app_role_value", "App role with value %q was not found for service principal with object ID %q", appRoleValue, resourceId)
+	}
+
+	id := parse.NewAppRoleAssignmentsID(resourceId, appRoleId)
+
+	tf.LockByName(appRoleAssignmentsResourceName, id.ID())
+	defer tf.UnlockByName(appRoleAssignmentsResourceName, id.ID())
+
+	// The ID is set before creating any assignments, so that any partial failures are tracked in state
+	d.SetId(id.ID())
+
+	desired := tf.ExpandStringSlice(d.Get("principal_object_ids").(*pluginsdk.Set).List())
+	if err = appRoleAssignmentsReconcile(ctx, meta, *id, desired, nil, d.Get("authoritative").(bool)); err != nil {
+		return tf.ErrorDiagF(err, "Creating %s", id)
+	}
+
+	return appRoleAssignmentsResourceRead(ctx, d, meta)
+}
+
+func appRoleAssignmentsResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	id, err := parse.ParseAppRoleAssignmentsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing App Role Assignments ID")
+	}
+
+	tf.LockByName(appRoleAssignmentsResourceName, id.ID())
+	defer tf.UnlockByName(appRoleAssignmentsResourceName, id.ID())
+
+	oldPrincipals, newPrincipals := d.GetChange("principal_object_ids")
+	desired := tf.ExpandStringSlice(newPrincipals.(*pluginsdk.Set).List())
+	removed := tf.ExpandStringSlice(oldPrincipals.(*pluginsdk.Set).Difference(newPrincipals.(*pluginsdk.Set)).List())
+
+	if err = appRoleAssignmentsReconcile(ctx, meta, *id, desired, removed, d.Get("authoritative").(bool)); err != nil {
+		return tf.ErrorDiagF(err, "Updating %s", id)
+	}
+
+	return appRoleAssignmentsResourceRead(ctx, d, meta)
+}
+
+func appRoleAssignmentsResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	servicePrincipalClient := meta.(*clients.Client).AppRoleAssignments.ServicePrincipalClient
+
+	id, err := parse.ParseAppRoleAssignmentsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing App Role Assignments ID")
+	}
+
+	resp, err := servicePrincipalClient.GetServicePrincipal(ctx, stable.NewServicePrincipalID(id.ServicePrincipalId), serviceprincipal.DefaultGetServicePrincipalOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] Resource service principal for %s was not found - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Could not retrieve service principal for resource (Object ID: %q)", id.ServicePrincipalId)
+	}
+	if resp.Model == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Could not retrieve service principal for resource (Object ID: %q)", id.ServicePrincipalId)
+	}
+
+	appRoleValue := ""
+	for value, appRoleId := range applications.FlattenAppRoleIDs(resp.Model.AppRoles) {
+		if strings.EqualFold(appRoleId, id.AppRoleId) {
+			appRoleValue = value
+			break
+		}
+	}
+	if appRoleValue == "" {
+		log.Printf("[DEBUG] App role for %s was not found - removing from state!", id)
+		d.SetId("")
+		return nil
+	}
+
+	assignments, err := appRoleAssignmentsList(ctx, meta, *id)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	// Principals which are no longer assigned the app role are checked for existence, so that principals which have been
+	// deleted are reported rather than being perpetually reassigned
+	statePrincipals := tf.ExpandStringSlice(d.Get("principal_object_ids").(*pluginsdk.Set).List())
+	unassigned := make([]string, 0)
+	for _, principalId := range statePrincipals {
+		if _, ok := assignments[strings.ToLower(principalId)]; !ok {
+			unassigned = append(unassigned, principalId)
+		}
+	}
+
+	missing, err := appRoleAssignmentsMissingPrincipals(ctx, meta, unassigned)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Checking for missing principals for %s", id)
+	}
+
+	principals := make([]string, 0)
+	if d.Get("authoritative").(bool) || len(statePrincipals) == 0 {
+		// All assigned principals are tracked when authoritative, or when importing
+		for _, assignment := range assignments {
+			principals = append(principals, assignment.PrincipalId.GetOrZero())
+		}
+	} else {
+		for _, principalId := range statePrincipals {
+			if _, ok := assignments[strings.ToLower(principalId)]; ok {
+				principals = append(principals, principalId)
+			}
+		}
+	}
+	principals = append(principals, missing...)
+
+	tf.Set(d, "app_role_id", id.AppRoleId)
+	tf.Set(d, "app_role_value", appRoleValue)
+	tf.Set(d, "missing_principal_object_ids", missing)
+	tf.Set(d, "principal_object_ids", principals)
+	tf.Set(d, "resource_object_id", id.ServicePrincipalId)
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return pluginsdk.Diagnostics{{
+			Severity: pluginsdk.DiagWarning,
+			Summary:  fmt.Sprintf("Some principals for %s no longer exist", id),
+			Detail:   fmt.Sprintf("The following principals could not be found and are not assigned the app role %q. Consider removing them from `principal_object_ids`: %s", appRoleValue, strings.Join(missing, ", ")),
+		}}
+	}
+
+	return nil
+}
+
+func appRoleAssignmentsResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	id, err := parse.ParseAppRoleAssignmentsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing App Role Assignments ID")
+	}
+
+	tf.LockByName(appRoleAssignmentsResourceName, id.ID())
+	defer tf.UnlockByName(appRoleAssignmentsResourceName, id.ID())
+
+	// When authoritative, all assignments of the app role are removed
+	removed := tf.ExpandStringSlice(d.Get("principal_object_ids").(*pluginsdk.Set).List())
+	if err = appRoleAssignmentsReconcile(ctx, meta, *id, nil, removed, d.Get("authoritative").(bool)); err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Deleting %s", id)
+	}
+
+	return nil
+}
+
+// appRoleAssignmentsList returns the assignments of an app role, keyed by the lower-cased object ID of the principal
+func appRoleAssignmentsList(ctx context.Context, meta interface{}, id parse.AppRoleAssignmentsId) (map[string]stable.AppRoleAssignment, error) {
+	client := meta.(*clients.Client).AppRoleAssignments.AppRoleAssignedToClient
+
+	resp, err := client.ListAppRoleAssignedTos(ctx, stable.NewServicePrincipalID(id.ServicePrincipalId), approleassignedto.DefaultListAppRoleAssignedTosOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing app role assignments: %+v", err)
+	}
+
+	result := make(map[string]stable.AppRoleAssignment)
+	if resp.Model != nil {
+		for _, assignment := range *resp.Model {
+			if strings.EqualFold(pointer.From(assignment.AppRoleId), id.AppRoleId) && assignment.PrincipalId.GetOrZero() != "" {
+				result[strings.ToLower(assignment.PrincipalId.GetOrZero())] = assignment
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// appRoleAssignmentsMissingPrincipals returns the specified principals which do not exist
+func appRoleAssignmentsMissingPrincipals(ctx context.Context, meta interface{}, principalIds []string) ([]string, error) {
+	client := meta.(*clients.Client).AppRoleAssignments.DirectoryObjectClient
+
+	missing := make([]string, 0)
+
+	// Up to 1000 objects can be retrieved in a single request
+	for start := 0; start < len(principalIds); start += 1000 {
+		ids := principalIds[start:min(start+1000, len(principalIds))]
+
+		resp, err := client.ListGetsByIds(ctx, directoryobject.ListGetsByIdsRequest{Ids: &ids}, directoryobject.DefaultListGetsByIdsOperationOptions())
+		if err != nil {
+			return nil, fmt.Errorf("retrieving principals: %+v", err)
+		}
+
+		found := make(map[string]bool)
+		if resp.Model != nil {
+			for _, o := range *resp.Model {
+				found[strings.ToLower(pointer.From(o.DirectoryObject().Id))] = true
+			}
+		}
+
+		for _, principalId := range ids {
+			if !found[strings.ToLower(principalId)] {
+				missing = append(missing, principalId)
+			}
+		}
+	}
+
+	return missing, nil
+}
+
+// appRoleAssignmentsReconcile assigns the app role to the desired principals, and removes assignments for the removed
+// principals. When authoritative, assignments for any other principals are also removed. Principals which do not exist
+// are skipped.
+func appRoleAssignmentsReconcile(ctx context.Context, meta interface{}, id parse.AppRoleAssignmentsId, desired, removed []string, authoritative bool) error {
+	client := meta.(*clients.Client).AppRoleAssignments.AppRoleAssignedToClient
+
+	assignments, err := appRoleAssignmentsList(ctx, meta, id)
+	if err != nil {
+		return err
+	}
+
+	desiredPrincipals := make(map[string]bool)
+	unassigned := make([]string, 0)
+	for _, principalId := range desired {
+		desiredPrincipals[strings.ToLower(principalId)] = true
+		if _, ok := assignments[strings.ToLower(principalId)]; !ok {
+			unassigned = append(unassigned, principalId)
+		}
+	}
+
+	missing, err := appRoleAssignmentsMissingPrincipals(ctx, meta, unassigned)
+	if err != nil {
+		return err
+	}
+
+	toCreate := tf.Difference(unassigned, missing)
+
+	toDelete := make([]string, 0)
+	if authoritative {
+		for principalId := range assignments {
+			if !desiredPrincipals[principalId] {
+				toDelete = append(toDelete, principalId)
+			}
+		}
+	} else {
+		for _, principalId := range removed {
+			if _, ok := assignments[strings.ToLower(principalId)]; ok && !desiredPrincipals[strings.ToLower(principalId)] {
+				toDelete = append(toDelete, strings.ToLower(principalId))
+			}
+		}
+	}
+
+	servicePrincipalId := stable.NewServicePrincipalID(id.ServicePrincipalId)
+
+	if err = appRoleAssignmentsInBatches(toCreate, func(principalId string) error {
+		properties := stable.AppRoleAssignment{
+			AppRoleId:   pointer.To(id.AppRoleId),
+			PrincipalId: nullable.Value(principalId),
+			ResourceId:  nullable.Value(id.ServicePrincipalId),
+		}
+
+		options := approleassignedto.CreateAppRoleAssignedToOperationOptions{
+			RetryFunc: func(resp *http.Response, o *odata.OData) (bool, error) {
+				if response.WasNotFound(resp) {
+					return true, nil
+				} else if response.WasBadRequest(resp) && o != nil && o.Error != nil {
+					return o.Error.Match("Not a valid reference update"), nil
+				}
+				return false, nil
+			},
+		}
+
+		if _, err := client.CreateAppRoleAssignedTo(ctx, servicePrincipalId, properties, options); err != nil {
+			return fmt.Errorf("assigning app role to principal %q: %+v", principalId, err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return appRoleAssignmentsInBatches(toDelete, func(principalId string) error {
+		assignmentId := stable.NewServicePrincipalIdAppRoleAssignedToID(id.ServicePrincipalId, pointer.From(assignments[principalId].Id))
+		if resp, err := client.DeleteAppRoleAssignedTo(ctx, assignmentId, approleassignedto.DefaultDeleteAppRoleAssignedToOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+			return fmt.Errorf("removing app role assignment for principal %q: %+v", principalId, err)
+		}
+		return nil
+	})
+}
+
+// appRoleAssignmentsInBatches invokes f for each principal, with up to appRoleAssignmentsBatchSize invocations running
+// concurrently, and returns any resulting errors
+func appRoleAssignmentsInBatches(principalIds []string, f func(principalId string) error) error {
+	errs := make([]error, len(principalIds))
+
+	for start := 0; start < len(principalIds); start += appRoleAssignmentsBatchSize {
+		var wg sync.WaitGroup
+		for i := start; i < min(start+appRoleAssignmentsBatchSize, len(principalIds)); i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = f(principalIds[i])
+			}(i)
+		}
+		wg.Wait()
+	}
+
+	return errors.Join(errs...)
+}
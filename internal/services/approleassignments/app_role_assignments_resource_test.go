@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/approleassignedto"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/approleassignments/parse"
+)
+
+type AppRoleAssignmentsResource struct{}
+
+func TestAccAppRoleAssignments_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_app_role_assignments", "test")
+	r := AppRoleAssignmentsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("app_role_id").IsUuid(),
+				check.That(data.ResourceName).Key("principal_object_ids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("missing_principal_object_ids.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccAppRoleAssignments_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_app_role_assignments", "test")
+	r := AppRoleAssignmentsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("principal_object_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.authoritative(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("principal_object_ids.#").HasValue("3"),
+			),
+		},
+		data.ImportStep("authoritative"),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("principal_object_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccAppRoleAssignments_missingPrincipal(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_app_role_assignments", "test")
+	r := AppRoleAssignmentsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.missingPrincipal(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("principal_object_ids.#").HasValue("2"),
+				check.That(data.ResourceName).Key("missing_principal_object_ids.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (r AppRoleAssignmentsResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.AppRoleAssignments.AppRoleAssignedToClient
+
+	id, err := parse.ParseAppRoleAssignmentsID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.ListAppRoleAssignedTos(ctx, stable.NewServicePrincipalID(id.ServicePrincipalId), approleassignedto.DefaultListAppRoleAssignedTosOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	if resp.Model != nil {
+		for _, assignment := range *resp.Model {
+			if strings.EqualFold(pointer.From(assignment.AppRoleId), id.AppRoleId) {
+				return pointer.To(true), nil
+			}
+		}
+	}
+
+	return pointer.To(false), nil
+}
+
+func (AppRoleAssignmentsResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_application" "internal" {
+  display_name = "acctest-AppRoleAssignments-internal-%[1]d"
+
+  app_role {
+    allowed_member_types = ["User"]
+    description          = "Readers can read all the things"
+    display_name         = "Reader"
+    enabled              = true
+    id                   = "%[2]s"
+    value                = "Reader.All"
+  }
+}
+
+resource "azuread_service_principal" "internal" {
+  client_id = azuread_application.internal.client_id
+}
+
+resource "azuread_user" "test" {
+  count = 3
+
+  display_name        = "acctest-AppRoleAssignments-${count.index}-%[1]d"
+  password            = "%[3]s"
+  user_principal_name = "acctest-AppRoleAssignments-${count.index}-%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+}
+`, data.RandomInteger, data.UUID(), data.RandomPassword)
+}
+
+func (r AppRoleAssignmentsResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_app_role_assignments" "test" {
+  resource_object_id   = azuread_service_principal.internal.object_id
+  app_role_value       = "Reader.All"
+  principal_object_ids = [azuread_user.test[0].object_id]
+}
+`, r.template(data))
+}
+
+func (r AppRoleAssignmentsResource) authoritative(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_app_role_assignments" "test" {
+  resource_object_id   = azuread_service_principal.internal.object_id
+  app_role_value       = "Reader.All"
+  principal_object_ids = azuread_user.test[*].object_id
+  authoritative        = true
+}
+`, r.template(data))
+}
+
+func (r AppRoleAssignmentsResource) missingPrincipal(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_app_role_assignments" "test" {
+  resource_object_id = azuread_service_principal.internal.object_id
+  app_role_value     = "Reader.All"
+
+  principal_object_ids = [
+    azuread_user.test[0].object_id,
+    "%[2]s",
+  ]
+}
+`, r.template(data), data.UUID())
+}
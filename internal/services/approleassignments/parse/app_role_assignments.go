@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type AppRoleAssignmentsId struct {
+	ServicePrincipalId string
+	AppRoleId          string
+}
+
+func NewAppRoleAssignmentsID(servicePrincipalId, appRoleId string) *AppRoleAssignmentsId {
+	return &AppRoleAssignmentsId{
+		ServicePrincipalId: servicePrincipalId,
+		AppRoleId:          appRoleId,
+	}
+}
+
+// ParseAppRoleAssignmentsID parses 'input' into an AppRoleAssignmentsId
+func ParseAppRoleAssignmentsID(input string) (*AppRoleAssignmentsId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&AppRoleAssignmentsId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	id := &AppRoleAssignmentsId{}
+	if err = id.FromParseResult(*parsed); err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+// ValidateAppRoleAssignmentsID checks that 'input' can be parsed as an App Role Assignments ID
+func ValidateAppRoleAssignmentsID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := ParseAppRoleAssignmentsID(v)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+
+	if warnings, errors = validation.IsUUID(id.ServicePrincipalId, "ID"); len(errors) > 0 {
+		return
+	}
+
+	return validation.IsUUID(id.AppRoleId, "ID")
+}
+
+func (id *AppRoleAssignmentsId) ID() string {
+	fmtString := "/servicePrincipals/%s/appRoles/%s/assignments"
+	return fmt.Sprintf(fmtString, id.ServicePrincipalId, id.AppRoleId)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this ID
+func (id *AppRoleAssignmentsId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("servicePrincipals", "servicePrincipals", "servicePrincipals"),
+		resourceids.UserSpecifiedSegment("servicePrincipalId", "00000000-0000-0000-0000-000000000000"),
+		resourceids.StaticSegment("appRoles", "appRoles", "appRoles"),
+		resourceids.UserSpecifiedSegment("appRoleId", "11111111-1111-1111-1111-111111111111"),
+		resourceids.StaticSegment("assignments", "assignments", "assignments"),
+	}
+}
+
+func (id *AppRoleAssignmentsId) String() string {
+	return fmt.Sprintf("App Role Assignments (Service Principal ID: %q, App Role ID: %q)", id.ServicePrincipalId, id.AppRoleId)
+}
+
+func (id *AppRoleAssignmentsId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.ServicePrincipalId, ok = input.Parsed["servicePrincipalId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "servicePrincipalId", input)
+	}
+
+	if id.AppRoleId, ok = input.Parsed["appRoleId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "appRoleId", input)
+	}
+
+	return nil
+}
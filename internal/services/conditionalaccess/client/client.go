@@ -4,8 +4,14 @@
 package client
 
 import (
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessauthenticationcontextclassreference"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessnamedlocation"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesspolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesstemplate"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identitygovernance/stable/termsofuseagreement"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identitygovernance/stable/termsofuseagreementacceptance"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identitygovernance/stable/termsofuseagreementfile"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
 	"github.com/hashicorp/terraform-provider-azuread/internal/common"
 )
 
@@ -18,16 +24,35 @@ import (
 // breaking a policy in this way, is to delete and recreate it, which is wholly undesirable for a critical security resource.
 
 type Client struct {
-	PolicyClient        *conditionalaccesspolicy.ConditionalAccessPolicyClient
+	AuthenticationContextClient *conditionalaccessauthenticationcontextclassreference.ConditionalAccessAuthenticationContextClassReferenceClient
+
+	// IdentityProtectionClient is used for the Identity Protection API, for which the SDK does not yet provide clients
+	IdentityProtectionClient *msgraph.Client
+
 	NamedLocationClient *conditionalaccessnamedlocation.ConditionalAccessNamedLocationClient
+
+	// NamedLocationClientBeta is used only for compliant network named locations, which are not available in the Stable API
+	NamedLocationClientBeta *msgraph.Client
+
+	PolicyClient                        *conditionalaccesspolicy.ConditionalAccessPolicyClient
+	TemplateClient                      *conditionalaccesstemplate.ConditionalAccessTemplateClient
+	TermsOfUseAgreementAcceptanceClient *termsofuseagreementacceptance.TermsOfUseAgreementAcceptanceClient
+	TermsOfUseAgreementClient           *termsofuseagreement.TermsOfUseAgreementClient
+	TermsOfUseAgreementFileClient       *termsofuseagreementfile.TermsOfUseAgreementFileClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
-	policyClient, err := conditionalaccesspolicy.NewConditionalAccessPolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
+	authenticationContextClient, err := conditionalaccessauthenticationcontextclassreference.NewConditionalAccessAuthenticationContextClassReferenceClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
 	}
-	o.Configure(policyClient.Client)
+	o.Configure(authenticationContextClient.Client)
+
+	identityProtectionClient, err := msgraph.NewClient(o.Environment.MicrosoftGraph, "identityprotection", msgraph.VersionOnePointZero)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(identityProtectionClient)
 
 	namedLocationClient, err := conditionalaccessnamedlocation.NewConditionalAccessNamedLocationClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
@@ -35,8 +60,51 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(namedLocationClient.Client)
 
+	namedLocationClientBeta, err := msgraph.NewClient(o.Environment.MicrosoftGraph, "conditionalaccessnamedlocation", msgraph.VersionBeta)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(namedLocationClientBeta)
+
+	policyClient, err := conditionalaccesspolicy.NewConditionalAccessPolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(policyClient.Client)
+
+	templateClient, err := conditionalaccesstemplate.NewConditionalAccessTemplateClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(templateClient.Client)
+
+	termsOfUseAgreementAcceptanceClient, err := termsofuseagreementacceptance.NewTermsOfUseAgreementAcceptanceClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(termsOfUseAgreementAcceptanceClient.Client)
+
+	termsOfUseAgreementClient, err := termsofuseagreement.NewTermsOfUseAgreementClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(termsOfUseAgreementClient.Client)
+
+	termsOfUseAgreementFileClient, err := termsofuseagreementfile.NewTermsOfUseAgreementFileClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(termsOfUseAgreementFileClient.Client)
+
 	return &Client{
-		PolicyClient:        policyClient,
-		NamedLocationClient: namedLocationClient,
+		AuthenticationContextClient:         authenticationContextClient,
+		IdentityProtectionClient:            identityProtectionClient,
+		NamedLocationClient:                 namedLocationClient,
+		NamedLocationClientBeta:             namedLocationClientBeta,
+		PolicyClient:                        policyClient,
+		TemplateClient:                      templateClient,
+		TermsOfUseAgreementAcceptanceClient: termsOfUseAgreementAcceptanceClient,
+		TermsOfUseAgreementClient:           termsOfUseAgreementClient,
+		TermsOfUseAgreementFileClient:       termsOfUseAgreementFileClient,
 	}, nil
 }
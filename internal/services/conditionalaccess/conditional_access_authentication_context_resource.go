@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessauthenticationcontextclassreference"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+// Authentication contexts are identified by the predefined IDs `c1` through `c99`
+var authenticationContextIdRegex = regexp.MustCompile(`^c([1-9]|[1-9][0-9])$`)
+
+func conditionalAccessAuthenticationContextResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: conditionalAccessAuthenticationContextResourceCreate,
+		ReadContext:   conditionalAccessAuthenticationContextResourceRead,
+		UpdateContext: conditionalAccessAuthenticationContextResourceUpdate,
+		DeleteContext: conditionalAccessAuthenticationContextResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := stable.ValidateIdentityConditionalAccessAuthenticationContextClassReferenceID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return fmt.Errorf(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"context_id": {
+				Description:  "The ID of the authentication context, from `c1` to `c99`",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(authenticationContextIdRegex, "must be one of `c1` through `c99`"),
+			},
+
+			"display_name": {
+				Description:  "The display name of the authentication context",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"description": {
+				Description:  "The description of the authentication context",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"is_available": {
+				Description: "Whether the authentication context is published for use by applications and services, such as SharePoint sites and Privileged Identity Management",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func conditionalAccessAuthenticationContextResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.AuthenticationContextClient
+
+	id := stable.NewIdentityConditionalAccessAuthenticationContextClassReferenceID(d.Get("context_id").(string))
+
+	resp, err := client.GetConditionalAccessAuthenticationContextClassReference(ctx, id, conditionalaccessauthenticationcontextclassreference.DefaultGetConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+	if err != nil {
+		if !response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagF(err, "Checking for existing %s", id)
+		}
+	} else if resp.Model != nil {
+		return tf.ImportAsExistsDiag("azuread_conditional_access_authentication_context", id.ID())
+	}
+
+	// Authentication contexts have predefined IDs, so they are created by updating them
+	properties := expandConditionalAccessAuthenticationContext(d)
+	if _, err = client.UpdateConditionalAccessAuthenticationContextClassReference(ctx, id, properties, conditionalaccessauthenticationcontextclassreference.DefaultUpdateConditionalAccessAuthenticationContextClassReferenceOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Creating %s", id)
+	}
+
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetConditionalAccessAuthenticationContextClassReference(ctx, id, conditionalaccessauthenticationcontextclassreference.DefaultGetConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(resp.Model != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for creation of %s", id)
+	}
+
+	d.SetId(id.ID())
+
+	return conditionalAccessAuthenticationContextResourceRead(ctx, d, meta)
+}
+
+func conditionalAccessAuthenticationContextResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.AuthenticationContextClient
+
+	id, err := stable.ParseIdentityConditionalAccessAuthenticationContextClassReferenceID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Authentication Context ID")
+	}
+
+	properties := expandConditionalAccessAuthenticationContext(d)
+	if _, err = client.UpdateConditionalAccessAuthenticationContextClassReference(ctx, *id, properties, conditionalaccessauthenticationcontextclassreference.DefaultUpdateConditionalAccessAuthenticationContextClassReferenceOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Updating %s", id)
+	}
+
+	return conditionalAccessAuthenticationContextResourceRead(ctx, d, meta)
+}
+
+func conditionalAccessAuthenticationContextResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.AuthenticationContextClient
+
+	id, err := stable.ParseIdentityConditionalAccessAuthenticationContextClassReferenceID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Authentication Context ID")
+	}
+
+	resp, err := client.GetConditionalAccessAuthenticationContextClassReference(ctx, *id, conditionalaccessauthenticationcontextclassreference.DefaultGetConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	authenticationContext := resp.Model
+	if authenticationContext == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	tf.Set(d, "context_id", id.AuthenticationContextClassReferenceId)
+	tf.Set(d, "description", authenticationContext.Description.GetOrZero())
+	tf.Set(d, "display_name", authenticationContext.DisplayName.GetOrZero())
+	tf.Set(d, "is_available", authenticationContext.IsAvailable.GetOrZero())
+
+	return nil
+}
+
+func conditionalAccessAuthenticationContextResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.AuthenticationContextClient
+
+	id, err := stable.ParseIdentityConditionalAccessAuthenticationContextClassReferenceID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Authentication Context ID")
+	}
+
+	// Published authentication contexts cannot be deleted, so the context must first be made unavailable
+	if d.Get("is_available").(bool) {
+		properties := stable.AuthenticationContextClassReference{
+			IsAvailable: nullable.Value(false),
+		}
+		if resp, err := client.UpdateConditionalAccessAuthenticationContextClassReference(ctx, *id, properties, conditionalaccessauthenticationcontextclassreference.DefaultUpdateConditionalAccessAuthenticationContextClassReferenceOperationOptions()); err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				log.Printf("[DEBUG] %s already deleted", id)
+				return nil
+			}
+			return tf.ErrorDiagF(err, "Updating %s prior to deletion", id)
+		}
+	}
+
+	resp, err := client.DeleteConditionalAccessAuthenticationContextClassReference(ctx, *id, conditionalaccessauthenticationcontextclassreference.DefaultDeleteConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s already deleted", id)
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Deleting %s", id)
+	}
+
+	if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetConditionalAccessAuthenticationContextClassReference(ctx, *id, conditionalaccessauthenticationcontextclassreference.DefaultGetConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(true), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for deletion of %s", id)
+	}
+
+	return nil
+}
+
+func expandConditionalAccessAuthenticationContext(d *pluginsdk.ResourceData) stable.AuthenticationContextClassReference {
+	properties := stable.AuthenticationContextClassReference{
+		Description: nullable.NoZero(d.Get("description").(string)),
+		DisplayName: nullable.Value(d.Get("display_name").(string)),
+		IsAvailable: nullable.Value(d.Get("is_available").(bool)),
+	}
+
+	// An omitted description would leave any existing value in place, so it must be explicitly cleared when removed
+	if d.HasChange("description") && properties.Description.GetOrZero() == "" {
+		properties.Description.SetNull()
+	}
+
+	return properties
+}
+
+// validateConditionalAccessAuthenticationContextsExist ensures that the authentication contexts referenced by a
+// conditional access policy have been created, since the API otherwise accepts any context ID
+func validateConditionalAccessAuthenticationContextsExist(ctx context.Context, client *conditionalaccessauthenticationcontextclassreference.ConditionalAccessAuthenticationContextClassReferenceClient, contextIds []string) error {
+	for _, contextId := range contextIds {
+		id := stable.NewIdentityConditionalAccessAuthenticationContextClassReferenceID(contextId)
+		resp, err := client.GetConditionalAccessAuthenticationContextClassReference(ctx, id, conditionalaccessauthenticationcontextclassreference.DefaultGetConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return fmt.Errorf("authentication context %q was not found, it must be created before it can be referenced by a conditional access policy", contextId)
+			}
+			return fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+	}
+
+	return nil
+}
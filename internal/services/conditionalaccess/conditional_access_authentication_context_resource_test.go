@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessauthenticationcontextclassreference"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type ConditionalAccessAuthenticationContextResource struct{}
+
+func TestAccConditionalAccessAuthenticationContext_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_authentication_context", "test")
+	r := ConditionalAccessAuthenticationContextResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("is_available").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccConditionalAccessAuthenticationContext_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_authentication_context", "test")
+	r := ConditionalAccessAuthenticationContextResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("description").HasValue("Step-up authentication for sensitive sites"),
+				check.That(data.ResourceName).Key("is_available").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("description").HasValue(""),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ConditionalAccessAuthenticationContextResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ConditionalAccess.AuthenticationContextClient
+
+	id, err := stable.ParseIdentityConditionalAccessAuthenticationContextClassReferenceID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetConditionalAccessAuthenticationContextClassReference(ctx, *id, conditionalaccessauthenticationcontextclassreference.DefaultGetConditionalAccessAuthenticationContextClassReferenceOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %+v", id, err)
+	}
+
+	return pointer.To(true), nil
+}
+
+// contextId returns a pseudo-random authentication context ID, since there are only 99 available in each tenant
+func (ConditionalAccessAuthenticationContextResource) contextId(data acceptance.TestData) string {
+	return fmt.Sprintf("c%d", data.RandomInteger%90+10)
+}
+
+func (r ConditionalAccessAuthenticationContextResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_conditional_access_authentication_context" "test" {
+  context_id   = "%[1]s"
+  display_name = "acctest-AUTHCONTEXT-%[2]d"
+}
+`, r.contextId(data), data.RandomInteger)
+}
+
+func (r ConditionalAccessAuthenticationContextResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_conditional_access_authentication_context" "test" {
+  context_id   = "%[1]s"
+  display_name = "acctest-AUTHCONTEXT-%[2]d"
+  description  = "Step-up authentication for sensitive sites"
+  is_available = true
+}
+`, r.contextId(data), data.RandomInteger)
+}
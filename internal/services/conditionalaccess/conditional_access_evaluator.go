@@ -0,0 +1,485 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+// The evaluator below determines which conditional access policies would apply to a sign-in, without calling the API.
+// Unlike the sign-in evaluation performed by the service, all supplied policies are evaluated as though they were
+// enabled, so that the effect of disabled and report-only policies can be assessed before they are enabled.
+
+// conditionalAccessSignInScenario describes a sign-in to evaluate. Either UserId or ServicePrincipalId should be set.
+type conditionalAccessSignInScenario struct {
+	UserId                   string
+	GroupIds                 []string
+	RoleIds                  []string
+	GuestOrExternalUserTypes []string
+	ExternalTenantId         string
+
+	ServicePrincipalId string
+
+	ApplicationId                        string
+	UserAction                           string
+	AuthenticationContextClassReferences []string
+
+	AuthenticationFlow string
+	ClientAppType      string
+	Platform           string
+	LocationIds        []string
+	TrustedLocation    bool
+
+	InsiderRiskLevel          string
+	ServicePrincipalRiskLevel string
+	SignInRiskLevel           string
+	UserRiskLevel             string
+}
+
+func (s conditionalAccessSignInScenario) isWorkloadIdentity() bool {
+	return s.ServicePrincipalId != ""
+}
+
+// conditionalAccessEvaluationPolicy is a policy to be evaluated, along with the properties not modeled by the SDK
+type conditionalAccessEvaluationPolicy struct {
+	Policy     stable.ConditionalAccessPolicy
+	Extensions conditionalAccessPolicyExtensions
+}
+
+type conditionalAccessEvaluationResult struct {
+	Policy conditionalAccessEvaluationPolicy
+
+	// UnevaluatedConditions lists the configured conditions which cannot be evaluated offline, and which were assumed
+	// to match the sign-in
+	UnevaluatedConditions []string
+}
+
+// conditionalAccessEvaluationControls are the combined controls of all policies which apply to a sign-in. The grant
+// controls referenced by any applicable policy are listed in BuiltInControls, AuthenticationStrengthPolicyIds and
+// TermsOfUse, however these are not necessarily all required; every entry in GrantRequirements must be satisfied, each
+// according to its own operator.
+type conditionalAccessEvaluationControls struct {
+	Blocked                         bool
+	BuiltInControls                 []string
+	AuthenticationStrengthPolicyIds []string
+	TermsOfUse                      []string
+	GrantRequirements               []conditionalAccessGrantRequirement
+
+	SessionControls           *stable.ConditionalAccessSessionControls
+	SessionControlsExtensions conditionalAccessPolicyExtensions
+}
+
+// conditionalAccessGrantRequirement holds the grant controls of a single applicable policy. When Operator is `AND`, all
+// of the controls are required, and when it is `OR`, any one of them is sufficient.
+type conditionalAccessGrantRequirement struct {
+	DisplayName                     string
+	Operator                        string
+	BuiltInControls                 []string
+	AuthenticationStrengthPolicyIds []string
+	TermsOfUse                      []string
+}
+
+// evaluateConditionalAccessPolicies returns the results for the policies which apply to the sign-in scenario
+func evaluateConditionalAccessPolicies(scenario conditionalAccessSignInScenario, policies []conditionalAccessEvaluationPolicy) []conditionalAccessEvaluationResult {
+	result := make([]conditionalAccessEvaluationResult, 0)
+
+	for _, policy := range policies {
+		if applies, unevaluated := evaluateConditionalAccessPolicy(scenario, policy); applies {
+			result = append(result, conditionalAccessEvaluationResult{
+				Policy:                policy,
+				UnevaluatedConditions: unevaluated,
+			})
+		}
+	}
+
+	return result
+}
+
+// evaluateConditionalAccessPolicy determines whether a policy applies to the sign-in scenario. A policy applies when all
+// of its configured conditions are satisfied.
+func evaluateConditionalAccessPolicy(scenario conditionalAccessSignInScenario, policy conditionalAccessEvaluationPolicy) (bool, []string) {
+	conditions := policy.Policy.Conditions
+	if conditions == nil {
+		return false, nil
+	}
+
+	unevaluated := make([]string, 0)
+
+	if !conditionalAccessIdentityMatches(scenario, conditions) {
+		return false, nil
+	}
+
+	if !conditionalAccessApplicationsMatch(scenario, conditions.Applications) {
+		return false, nil
+	}
+	if conditions.Applications.ApplicationFilter != nil {
+		unevaluated = append(unevaluated, "applications.filter")
+	}
+
+	if !conditionalAccessAuthenticationFlowsMatch(scenario, policy.Extensions.AuthenticationFlows) {
+		return false, nil
+	}
+
+	if !conditionalAccessClientAppTypesMatch(scenario, conditions.ClientAppTypes) {
+		return false, nil
+	}
+
+	if devices := conditions.Devices; devices != nil && devices.DeviceFilter != nil && devices.DeviceFilter.Rule != nil {
+		unevaluated = append(unevaluated, "devices.filter")
+	}
+
+	if !conditionalAccessLocationsMatch(scenario, conditions.Locations) {
+		return false, nil
+	}
+
+	if !conditionalAccessPlatformsMatch(scenario, conditions.Platforms) {
+		return false, nil
+	}
+
+	if levels := conditions.InsiderRiskLevels; levels != nil && !conditionalAccessFlagsContain(string(*levels), scenario.InsiderRiskLevel) {
+		return false, nil
+	}
+
+	if !conditionalAccessRiskLevelsMatch(pointer.From(conditions.ServicePrincipalRiskLevels), scenario.ServicePrincipalRiskLevel) ||
+		!conditionalAccessRiskLevelsMatch(conditions.SignInRiskLevels, scenario.SignInRiskLevel) ||
+		!conditionalAccessRiskLevelsMatch(conditions.UserRiskLevels, scenario.UserRiskLevel) {
+		return false, nil
+	}
+
+	return true, unevaluated
+}
+
+// conditionalAccessIdentityMatches determines whether the user or workload identity is in scope of the policy
+func conditionalAccessIdentityMatches(scenario conditionalAccessSignInScenario, conditions *stable.ConditionalAccessConditionSet) bool {
+	var includedServicePrincipals, excludedServicePrincipals []string
+	if clientApplications := conditions.ClientApplications; clientApplications != nil {
+		includedServicePrincipals = pointer.From(clientApplications.IncludeServicePrincipals)
+		excludedServicePrincipals = pointer.From(clientApplications.ExcludeServicePrincipals)
+	}
+
+	// Policies which include service principals only apply to workload identities
+	if scenario.isWorkloadIdentity() {
+		if len(includedServicePrincipals) == 0 {
+			return false
+		}
+		included := slices.Contains(includedServicePrincipals, "ServicePrincipalsInMyTenant") || slices.Contains(includedServicePrincipals, scenario.ServicePrincipalId)
+		excluded := slices.Contains(excludedServicePrincipals, scenario.ServicePrincipalId)
+		return included && !excluded
+	}
+
+	if len(includedServicePrincipals) > 0 {
+		return false
+	}
+
+	users := conditions.Users
+	if users == nil {
+		return false
+	}
+
+	isGuest := len(scenario.GuestOrExternalUserTypes) > 0
+
+	includedUsers := pointer.From(users.IncludeUsers)
+	included := slices.Contains(includedUsers, "All") ||
+		(scenario.UserId != "" && slices.Contains(includedUsers, scenario.UserId)) ||
+		(isGuest && slices.Contains(includedUsers, "GuestsOrExternalUsers")) ||
+		conditionalAccessIntersects(pointer.From(users.IncludeGroups), scenario.GroupIds) ||
+		conditionalAccessIntersects(pointer.From(users.IncludeRoles), scenario.RoleIds) ||
+		conditionalAccessGuestsOrExternalUsersMatch(scenario, users.IncludeGuestsOrExternalUsers)
+
+	excludedUsers := pointer.From(users.ExcludeUsers)
+	excluded := (scenario.UserId != "" && slices.Contains(excludedUsers, scenario.UserId)) ||
+		(isGuest && slices.Contains(excludedUsers, "GuestsOrExternalUsers")) ||
+		conditionalAccessIntersects(pointer.From(users.ExcludeGroups), scenario.GroupIds) ||
+		conditionalAccessIntersects(pointer.From(users.ExcludeRoles), scenario.RoleIds) ||
+		conditionalAccessGuestsOrExternalUsersMatch(scenario, users.ExcludeGuestsOrExternalUsers)
+
+	return included && !excluded
+}
+
+func conditionalAccessGuestsOrExternalUsersMatch(scenario conditionalAccessSignInScenario, in *stable.ConditionalAccessGuestsOrExternalUsers) bool {
+	if in == nil || in.GuestOrExternalUserTypes == nil {
+		return false
+	}
+
+	typeMatched := false
+	for _, userType := range scenario.GuestOrExternalUserTypes {
+		if conditionalAccessFlagsContain(string(*in.GuestOrExternalUserTypes), userType) {
+			typeMatched = true
+			break
+		}
+	}
+	if !typeMatched {
+		return false
+	}
+
+	if in.ExternalTenants != nil {
+		externalTenants := in.ExternalTenants.ConditionalAccessExternalTenants()
+		if pointer.From(externalTenants.MembershipKind) == stable.ConditionalAccessExternalTenantsMembershipKind_Enumerated {
+			return slices.Contains(pointer.From(externalTenants.Members), scenario.ExternalTenantId)
+		}
+	}
+
+	return true
+}
+
+func conditionalAccessApplicationsMatch(scenario conditionalAccessSignInScenario, in stable.ConditionalAccessApplications) bool {
+	if scenario.UserAction != "" {
+		return slices.Contains(pointer.From(in.IncludeUserActions), scenario.UserAction)
+	}
+
+	if len(scenario.AuthenticationContextClassReferences) > 0 {
+		return conditionalAccessIntersects(pointer.From(in.IncludeAuthenticationContextClassReferences), scenario.AuthenticationContextClassReferences)
+	}
+
+	if scenario.ApplicationId == "" {
+		return false
+	}
+
+	includedApplications := pointer.From(in.IncludeApplications)
+	included := slices.Contains(includedApplications, "All") || slices.Contains(includedApplications, scenario.ApplicationId)
+	excluded := slices.Contains(pointer.From(in.ExcludeApplications), scenario.ApplicationId)
+
+	return included && !excluded
+}
+
+func conditionalAccessAuthenticationFlowsMatch(scenario conditionalAccessSignInScenario, in *conditionalAccessAuthenticationFlows) bool {
+	if in == nil || in.TransferMethods == nil || *in.TransferMethods == conditionalAccessTransferMethodNone {
+		return true
+	}
+
+	return conditionalAccessFlagsContain(*in.TransferMethods, scenario.AuthenticationFlow)
+}
+
+func conditionalAccessClientAppTypesMatch(scenario conditionalAccessSignInScenario, in []stable.ConditionalAccessClientApp) bool {
+	if len(in) == 0 || slices.Contains(in, stable.ConditionalAccessClientApp_All) {
+		return true
+	}
+
+	return slices.Contains(in, stable.ConditionalAccessClientApp(scenario.ClientAppType))
+}
+
+func conditionalAccessLocationsMatch(scenario conditionalAccessSignInScenario, in *stable.ConditionalAccessLocations) bool {
+	if in == nil {
+		return true
+	}
+
+	matches := func(locations []string) bool {
+		return slices.Contains(locations, "All") ||
+			(scenario.TrustedLocation && slices.Contains(locations, "AllTrusted")) ||
+			conditionalAccessIntersects(locations, scenario.LocationIds)
+	}
+
+	return matches(pointer.From(in.IncludeLocations)) && !matches(pointer.From(in.ExcludeLocations))
+}
+
+func conditionalAccessPlatformsMatch(scenario conditionalAccessSignInScenario, in *stable.ConditionalAccessPlatforms) bool {
+	if in == nil {
+		return true
+	}
+
+	matches := func(platforms []stable.ConditionalAccessDevicePlatform) bool {
+		if scenario.Platform == "" {
+			return false
+		}
+		return slices.Contains(platforms, stable.ConditionalAccessDevicePlatform_All) ||
+			slices.Contains(platforms, stable.ConditionalAccessDevicePlatform(scenario.Platform))
+	}
+
+	return matches(pointer.From(in.IncludePlatforms)) && !matches(pointer.From(in.ExcludePlatforms))
+}
+
+func conditionalAccessRiskLevelsMatch(in []stable.RiskLevel, level string) bool {
+	if len(in) == 0 {
+		return true
+	}
+
+	return slices.Contains(in, stable.RiskLevel(level))
+}
+
+// conditionalAccessFlagsContain determines whether a comma-separated list of flags contains the specified value
+func conditionalAccessFlagsContain(flags, value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, flag := range strings.Split(flags, ",") {
+		if strings.TrimSpace(flag) == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func conditionalAccessIntersects(a, b []string) bool {
+	for _, v := range b {
+		if v != "" && slices.Contains(a, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// combineConditionalAccessControls combines the controls of the applicable policies. The grant controls of every policy
+// must be satisfied, in accordance with the operator of each policy, and where session controls conflict, the most
+// restrictive control is enforced.
+func combineConditionalAccessControls(results []conditionalAccessEvaluationResult) conditionalAccessEvaluationControls {
+	result := conditionalAccessEvaluationControls{
+		BuiltInControls:                 make([]string, 0),
+		AuthenticationStrengthPolicyIds: make([]string, 0),
+		TermsOfUse:                      make([]string, 0),
+		GrantRequirements:               make([]conditionalAccessGrantRequirement, 0),
+	}
+
+	var sessionControls *stable.ConditionalAccessSessionControls
+
+	for _, r := range results {
+		if grantControls := r.Policy.Policy.GrantControls; grantControls != nil {
+			requirement := conditionalAccessGrantRequirement{
+				DisplayName:                     pointer.From(r.Policy.Policy.DisplayName),
+				Operator:                        grantControls.Operator.GetOrZero(),
+				BuiltInControls:                 make([]string, 0),
+				AuthenticationStrengthPolicyIds: make([]string, 0),
+				TermsOfUse:                      make([]string, 0),
+			}
+
+			for _, control := range pointer.From(grantControls.BuiltInControls) {
+				if control == stable.ConditionalAccessGrantControl_Block {
+					result.Blocked = true
+					continue
+				}
+				requirement.BuiltInControls = conditionalAccessAppendUnique(requirement.BuiltInControls, string(control))
+				result.BuiltInControls = conditionalAccessAppendUnique(result.BuiltInControls, string(control))
+			}
+
+			if grantControls.AuthenticationStrength != nil && grantControls.AuthenticationStrength.Id != nil {
+				id := stable.NewPolicyAuthenticationStrengthPolicyID(*grantControls.AuthenticationStrength.Id).ID()
+				requirement.AuthenticationStrengthPolicyIds = append(requirement.AuthenticationStrengthPolicyIds, id)
+				result.AuthenticationStrengthPolicyIds = conditionalAccessAppendUnique(result.AuthenticationStrengthPolicyIds, id)
+			}
+
+			for _, termsOfUse := range pointer.From(grantControls.TermsOfUse) {
+				requirement.TermsOfUse = conditionalAccessAppendUnique(requirement.TermsOfUse, termsOfUse)
+				result.TermsOfUse = conditionalAccessAppendUnique(result.TermsOfUse, termsOfUse)
+			}
+
+			if len(requirement.BuiltInControls) > 0 || len(requirement.AuthenticationStrengthPolicyIds) > 0 || len(requirement.TermsOfUse) > 0 {
+				sort.Strings(requirement.BuiltInControls)
+				sort.Strings(requirement.TermsOfUse)
+				result.GrantRequirements = append(result.GrantRequirements, requirement)
+			}
+		}
+
+		if in := r.Policy.Policy.SessionControls; in != nil {
+			if sessionControls == nil {
+				sessionControls = &stable.ConditionalAccessSessionControls{}
+			}
+			combineConditionalAccessSessionControls(sessionControls, in)
+		}
+
+		if in := r.Policy.Extensions.ContinuousAccessEvaluation.Get(); in != nil {
+			if sessionControls == nil {
+				sessionControls = &stable.ConditionalAccessSessionControls{}
+			}
+			existing := result.SessionControlsExtensions.ContinuousAccessEvaluation.Get()
+			if existing == nil || conditionalAccessContinuousAccessEvaluationModeStrictness(pointer.From(in.Mode)) > conditionalAccessContinuousAccessEvaluationModeStrictness(pointer.From(existing.Mode)) {
+				result.SessionControlsExtensions.ContinuousAccessEvaluation = nullable.Value(*in)
+			}
+		}
+
+		if in := r.Policy.Extensions.SecureSignInSession.Get(); in != nil && pointer.From(in.IsEnabled) {
+			if sessionControls == nil {
+				sessionControls = &stable.ConditionalAccessSessionControls{}
+			}
+			result.SessionControlsExtensions.SecureSignInSession = nullable.Value(*in)
+		}
+	}
+
+	sort.Strings(result.BuiltInControls)
+	sort.Strings(result.AuthenticationStrengthPolicyIds)
+	sort.Strings(result.TermsOfUse)
+
+	result.SessionControls = sessionControls
+
+	return result
+}
+
+func combineConditionalAccessSessionControls(result, in *stable.ConditionalAccessSessionControls) {
+	if in.ApplicationEnforcedRestrictions != nil && in.ApplicationEnforcedRestrictions.IsEnabled.GetOrZero() {
+		result.ApplicationEnforcedRestrictions = in.ApplicationEnforcedRestrictions
+	}
+
+	if in.CloudAppSecurity != nil && in.CloudAppSecurity.CloudAppSecurityType != nil {
+		if result.CloudAppSecurity == nil || conditionalAccessCloudAppSecurityStrictness(*in.CloudAppSecurity.CloudAppSecurityType) > conditionalAccessCloudAppSecurityStrictness(pointer.From(result.CloudAppSecurity.CloudAppSecurityType)) {
+			result.CloudAppSecurity = in.CloudAppSecurity
+		}
+	}
+
+	if in.DisableResilienceDefaults.GetOrZero() {
+		result.DisableResilienceDefaults = in.DisableResilienceDefaults
+	}
+
+	if in.PersistentBrowser != nil && in.PersistentBrowser.Mode != nil {
+		if result.PersistentBrowser == nil || *in.PersistentBrowser.Mode == stable.PersistentBrowserSessionMode_Never {
+			result.PersistentBrowser = in.PersistentBrowser
+		}
+	}
+
+	if in.SignInFrequency != nil {
+		if result.SignInFrequency == nil || conditionalAccessSignInFrequencyHours(in.SignInFrequency) < conditionalAccessSignInFrequencyHours(result.SignInFrequency) {
+			result.SignInFrequency = in.SignInFrequency
+		}
+	}
+}
+
+// conditionalAccessSignInFrequencyHours returns the sign-in frequency in hours, where a lower value is more restrictive
+func conditionalAccessSignInFrequencyHours(in *stable.SignInFrequencySessionControl) int64 {
+	if pointer.From(in.FrequencyInterval) == stable.SignInFrequencyInterval_EveryTime {
+		return 0
+	}
+
+	value := in.Value.GetOrZero()
+	if value == 0 {
+		// Sign-in frequency is not configured
+		return math.MaxInt64
+	}
+
+	if pointer.From(in.Type) == stable.SigninFrequencyType_Days {
+		return value * 24
+	}
+
+	return value
+}
+
+func conditionalAccessCloudAppSecurityStrictness(in stable.CloudAppSecuritySessionControlType) int {
+	return slices.Index([]stable.CloudAppSecuritySessionControlType{
+		stable.CloudAppSecuritySessionControlType_McasConfigured,
+		stable.CloudAppSecuritySessionControlType_MonitorOnly,
+		stable.CloudAppSecuritySessionControlType_BlockDownloads,
+	}, in)
+}
+
+func conditionalAccessContinuousAccessEvaluationModeStrictness(in string) int {
+	return slices.Index([]string{
+		conditionalAccessContinuousAccessEvaluationModeDisabled,
+		conditionalAccessContinuousAccessEvaluationModeStrictEnforcement,
+		conditionalAccessContinuousAccessEvaluationModeStrictLocation,
+	}, in)
+}
+
+func conditionalAccessAppendUnique(in []string, value string) []string {
+	if slices.Contains(in, value) {
+		return in
+	}
+
+	return append(in, value)
+}
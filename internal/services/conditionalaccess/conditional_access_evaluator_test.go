@@ -0,0 +1,392 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+)
+
+const (
+	testBreakGlassUserId   = "00000000-0000-0000-0000-000000000001"
+	testUserId             = "00000000-0000-0000-0000-000000000002"
+	testGroupId            = "00000000-0000-0000-0000-000000000003"
+	testServicePrincipalId = "00000000-0000-0000-0000-000000000004"
+	testLocationId         = "00000000-0000-0000-0000-000000000005"
+	testApplicationId      = "00000000-0000-0000-0000-000000000006"
+)
+
+func testConditionalAccessEvaluationPolicy(name string, conditions stable.ConditionalAccessConditionSet, builtInControls ...stable.ConditionalAccessGrantControl) conditionalAccessEvaluationPolicy {
+	return conditionalAccessEvaluationPolicy{
+		Policy: stable.ConditionalAccessPolicy{
+			DisplayName: pointer.To(name),
+			Conditions:  &conditions,
+			GrantControls: &stable.ConditionalAccessGrantControls{
+				Operator:        nullable.Value("OR"),
+				BuiltInControls: &builtInControls,
+			},
+		},
+	}
+}
+
+func TestEvaluateConditionalAccessPolicies(t *testing.T) {
+	allUsersExceptBreakGlass := stable.ConditionalAccessUsers{
+		IncludeUsers: &[]string{"All"},
+		ExcludeUsers: &[]string{testBreakGlassUserId},
+	}
+	allApplications := stable.ConditionalAccessApplications{
+		IncludeApplications: &[]string{"All"},
+	}
+
+	policies := []conditionalAccessEvaluationPolicy{
+		testConditionalAccessEvaluationPolicy("require-mfa", stable.ConditionalAccessConditionSet{
+			Applications:   allApplications,
+			ClientAppTypes: []stable.ConditionalAccessClientApp{stable.ConditionalAccessClientApp_All},
+			Users:          &allUsersExceptBreakGlass,
+		}, stable.ConditionalAccessGrantControl_Mfa),
+
+		testConditionalAccessEvaluationPolicy("block-legacy-auth", stable.ConditionalAccessConditionSet{
+			Applications:   allApplications,
+			ClientAppTypes: []stable.ConditionalAccessClientApp{stable.ConditionalAccessClientApp_ExchangeActiveSync, stable.ConditionalAccessClientApp_Other},
+			Users:          &allUsersExceptBreakGlass,
+		}, stable.ConditionalAccessGrantControl_Block),
+
+		testConditionalAccessEvaluationPolicy("untrusted-locations", stable.ConditionalAccessConditionSet{
+			Applications:   allApplications,
+			ClientAppTypes: []stable.ConditionalAccessClientApp{stable.ConditionalAccessClientApp_All},
+			Locations: &stable.ConditionalAccessLocations{
+				IncludeLocations: &[]string{"All"},
+				ExcludeLocations: &[]string{"AllTrusted"},
+			},
+			Users: &stable.ConditionalAccessUsers{
+				IncludeGroups: &[]string{testGroupId},
+			},
+		}, stable.ConditionalAccessGrantControl_CompliantDevice),
+
+		testConditionalAccessEvaluationPolicy("android-high-risk", stable.ConditionalAccessConditionSet{
+			Applications:   allApplications,
+			ClientAppTypes: []stable.ConditionalAccessClientApp{stable.ConditionalAccessClientApp_All},
+			Platforms: &stable.ConditionalAccessPlatforms{
+				IncludePlatforms: &[]stable.ConditionalAccessDevicePlatform{stable.ConditionalAccessDevicePlatform_Android},
+			},
+			SignInRiskLevels: []stable.RiskLevel{stable.RiskLevel_High},
+			Users:            &allUsersExceptBreakGlass,
+		}, stable.ConditionalAccessGrantControl_Block),
+
+		testConditionalAccessEvaluationPolicy("workload-identities", stable.ConditionalAccessConditionSet{
+			Applications:   allApplications,
+			ClientAppTypes: []stable.ConditionalAccessClientApp{stable.ConditionalAccessClientApp_All},
+			ClientApplications: &stable.ConditionalAccessClientApplications{
+				IncludeServicePrincipals: &[]string{"ServicePrincipalsInMyTenant"},
+			},
+			Locations: &stable.ConditionalAccessLocations{
+				IncludeLocations: &[]string{"All"},
+				ExcludeLocations: &[]string{testLocationId},
+			},
+			Users: &stable.ConditionalAccessUsers{
+				IncludeUsers: &[]string{"None"},
+			},
+		}, stable.ConditionalAccessGrantControl_Block),
+
+		testConditionalAccessEvaluationPolicy("register-security-info", stable.ConditionalAccessConditionSet{
+			Applications: stable.ConditionalAccessApplications{
+				IncludeUserActions: &[]string{"urn:user:registersecurityinfo"},
+			},
+			ClientAppTypes: []stable.ConditionalAccessClientApp{stable.ConditionalAccessClientApp_All},
+			Users:          &allUsersExceptBreakGlass,
+		}, stable.ConditionalAccessGrantControl_Mfa),
+	}
+
+	cases := []struct {
+		TestName string
+		Scenario conditionalAccessSignInScenario
+		Expected []string
+	}{
+		{
+			TestName: "BreakGlassAccount",
+			Scenario: conditionalAccessSignInScenario{
+				UserId:        testBreakGlassUserId,
+				ApplicationId: testApplicationId,
+				ClientAppType: "browser",
+			},
+			Expected: []string{},
+		},
+		{
+			TestName: "User",
+			Scenario: conditionalAccessSignInScenario{
+				UserId:        testUserId,
+				ApplicationId: testApplicationId,
+				ClientAppType: "browser",
+			},
+			Expected: []string{"require-mfa"},
+		},
+		{
+			TestName: "LegacyAuthentication",
+			Scenario: conditionalAccessSignInScenario{
+				UserId:        testUserId,
+				ApplicationId: testApplicationId,
+				ClientAppType: "exchangeActiveSync",
+			},
+			Expected: []string{"require-mfa", "block-legacy-auth"},
+		},
+		{
+			TestName: "GroupMemberFromUntrustedLocation",
+			Scenario: conditionalAccessSignInScenario{
+				UserId:        testUserId,
+				GroupIds:      []string{testGroupId},
+				ApplicationId: testApplicationId,
+				ClientAppType: "browser",
+			},
+			Expected: []string{"require-mfa", "untrusted-locations"},
+		},
+		{
+			TestName: "GroupMemberFromTrustedLocation",
+			Scenario: conditionalAccessSignInScenario{
+				UserId:          testUserId,
+				GroupIds:        []string{testGroupId},
+				ApplicationId:   testApplicationId,
+				ClientAppType:   "browser",
+				TrustedLocation: true,
+			},
+			Expected: []string{"require-mfa"},
+		},
+		{
+			TestName: "RiskySignInFromAndroid",
+			Scenario: conditionalAccessSignInScenario{
+				UserId:          testUserId,
+				ApplicationId:   testApplicationId,
+				ClientAppType:   "mobileAppsAndDesktopClients",
+				Platform:        "android",
+				SignInRiskLevel: "high",
+			},
+			Expected: []string{"require-mfa", "android-high-risk"},
+		},
+		{
+			TestName: "RiskySignInFromUnknownPlatform",
+			Scenario: conditionalAccessSignInScenario{
+				UserId:          testUserId,
+				ApplicationId:   testApplicationId,
+				ClientAppType:   "mobileAppsAndDesktopClients",
+				SignInRiskLevel: "high",
+			},
+			Expected: []string{"require-mfa"},
+		},
+		{
+			TestName: "ServicePrincipal",
+			Scenario: conditionalAccessSignInScenario{
+				ServicePrincipalId: testServicePrincipalId,
+				ApplicationId:      testApplicationId,
+			},
+			Expected: []string{"workload-identities"},
+		},
+		{
+			TestName: "ServicePrincipalFromExcludedLocation",
+			Scenario: conditionalAccessSignInScenario{
+				ServicePrincipalId: testServicePrincipalId,
+				ApplicationId:      testApplicationId,
+				LocationIds:        []string{testLocationId},
+			},
+			Expected: []string{},
+		},
+		{
+			TestName: "UserAction",
+			Scenario: conditionalAccessSignInScenario{
+				UserId:        testUserId,
+				UserAction:    "urn:user:registersecurityinfo",
+				ClientAppType: "browser",
+			},
+			Expected: []string{"register-security-info"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			actual := make([]string, 0)
+			for _, result := range evaluateConditionalAccessPolicies(tc.Scenario, policies) {
+				actual = append(actual, pointer.From(result.Policy.Policy.DisplayName))
+			}
+
+			if !reflect.DeepEqual(tc.Expected, actual) {
+				t.Fatalf("unexpected policies\nexpected: %v\nactual:   %v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestEvaluateConditionalAccessPolicyUnevaluatedConditions(t *testing.T) {
+	policy := testConditionalAccessEvaluationPolicy("device-filter", stable.ConditionalAccessConditionSet{
+		Applications: stable.ConditionalAccessApplications{
+			IncludeApplications: &[]string{"All"},
+		},
+		ClientAppTypes: []stable.ConditionalAccessClientApp{stable.ConditionalAccessClientApp_All},
+		Devices: &stable.ConditionalAccessDevices{
+			DeviceFilter: &stable.ConditionalAccessFilter{
+				Mode: pointer.To(stable.FilterMode_Exclude),
+				Rule: pointer.To(`device.trustType -eq "ServerAD"`),
+			},
+		},
+		Users: &stable.ConditionalAccessUsers{
+			IncludeUsers: &[]string{"All"},
+		},
+	}, stable.ConditionalAccessGrantControl_CompliantDevice)
+
+	applies, unevaluated := evaluateConditionalAccessPolicy(conditionalAccessSignInScenario{
+		UserId:        testUserId,
+		ApplicationId: testApplicationId,
+		ClientAppType: "browser",
+	}, policy)
+
+	if !applies {
+		t.Fatalf("expected policy to apply")
+	}
+	if expected := []string{"devices.filter"}; !reflect.DeepEqual(expected, unevaluated) {
+		t.Fatalf("unexpected unevaluated conditions\nexpected: %v\nactual:   %v", expected, unevaluated)
+	}
+}
+
+func TestCombineConditionalAccessControls(t *testing.T) {
+	results := []conditionalAccessEvaluationResult{
+		{
+			Policy: conditionalAccessEvaluationPolicy{
+				Policy: stable.ConditionalAccessPolicy{
+					GrantControls: &stable.ConditionalAccessGrantControls{
+						BuiltInControls: &[]stable.ConditionalAccessGrantControl{stable.ConditionalAccessGrantControl_Mfa, stable.ConditionalAccessGrantControl_CompliantDevice},
+					},
+					SessionControls: &stable.ConditionalAccessSessionControls{
+						PersistentBrowser: &stable.PersistentBrowserSessionControl{
+							IsEnabled: nullable.Value(true),
+							Mode:      pointer.To(stable.PersistentBrowserSessionMode_Always),
+						},
+						SignInFrequency: &stable.SignInFrequencySessionControl{
+							IsEnabled: nullable.Value(true),
+							Type:      pointer.To(stable.SigninFrequencyType_Days),
+							Value:     nullable.Value(int64(1)),
+						},
+					},
+				},
+				Extensions: conditionalAccessPolicyExtensions{
+					ContinuousAccessEvaluation: nullable.Value(conditionalAccessContinuousAccessEvaluationSessionControl{
+						Mode: pointer.To(conditionalAccessContinuousAccessEvaluationModeStrictEnforcement),
+					}),
+				},
+			},
+		},
+		{
+			Policy: conditionalAccessEvaluationPolicy{
+				Policy: stable.ConditionalAccessPolicy{
+					GrantControls: &stable.ConditionalAccessGrantControls{
+						BuiltInControls: &[]stable.ConditionalAccessGrantControl{stable.ConditionalAccessGrantControl_Mfa},
+						TermsOfUse:      &[]string{"11111111-1111-1111-1111-111111111111"},
+					},
+					SessionControls: &stable.ConditionalAccessSessionControls{
+						PersistentBrowser: &stable.PersistentBrowserSessionControl{
+							IsEnabled: nullable.Value(true),
+							Mode:      pointer.To(stable.PersistentBrowserSessionMode_Never),
+						},
+						SignInFrequency: &stable.SignInFrequencySessionControl{
+							IsEnabled: nullable.Value(true),
+							Type:      pointer.To(stable.SigninFrequencyType_Hours),
+							Value:     nullable.Value(int64(4)),
+						},
+					},
+				},
+				Extensions: conditionalAccessPolicyExtensions{
+					ContinuousAccessEvaluation: nullable.Value(conditionalAccessContinuousAccessEvaluationSessionControl{
+						Mode: pointer.To(conditionalAccessContinuousAccessEvaluationModeDisabled),
+					}),
+				},
+			},
+		},
+	}
+
+	controls := combineConditionalAccessControls(results)
+
+	if controls.Blocked {
+		t.Fatalf("expected access not to be blocked")
+	}
+	if expected := []string{"compliantDevice", "mfa"}; !reflect.DeepEqual(expected, controls.BuiltInControls) {
+		t.Fatalf("unexpected built-in controls\nexpected: %v\nactual:   %v", expected, controls.BuiltInControls)
+	}
+	if expected := []string{"11111111-1111-1111-1111-111111111111"}; !reflect.DeepEqual(expected, controls.TermsOfUse) {
+		t.Fatalf("unexpected terms of use\nexpected: %v\nactual:   %v", expected, controls.TermsOfUse)
+	}
+
+	sessionControls := flattenConditionalAccessSessionControls(controls.SessionControls, controls.SessionControlsExtensions)[0].(map[string]interface{})
+	if v := sessionControls["persistent_browser_mode"]; v != "never" {
+		t.Fatalf("expected persistent browser mode %q, got %q", "never", v)
+	}
+	if v, p := sessionControls["sign_in_frequency"], sessionControls["sign_in_frequency_period"]; v != 4 || p != "hours" {
+		t.Fatalf("expected sign-in frequency of 4 hours, got %v %v", v, p)
+	}
+	if v := sessionControls["continuous_access_evaluation_mode"]; v != conditionalAccessContinuousAccessEvaluationModeStrictEnforcement {
+		t.Fatalf("expected continuous access evaluation mode %q, got %q", conditionalAccessContinuousAccessEvaluationModeStrictEnforcement, v)
+	}
+
+	if len(controls.GrantRequirements) != 2 {
+		t.Fatalf("expected 2 grant requirements, got %d", len(controls.GrantRequirements))
+	}
+	if expected := []string{"compliantDevice", "mfa"}; !reflect.DeepEqual(expected, controls.GrantRequirements[0].BuiltInControls) {
+		t.Fatalf("unexpected built-in controls for first policy\nexpected: %v\nactual:   %v", expected, controls.GrantRequirements[0].BuiltInControls)
+	}
+
+	blocked := combineConditionalAccessControls(append(results, conditionalAccessEvaluationResult{
+		Policy: testConditionalAccessEvaluationPolicy("block", stable.ConditionalAccessConditionSet{}, stable.ConditionalAccessGrantControl_Block),
+	}))
+	if !blocked.Blocked {
+		t.Fatalf("expected access to be blocked")
+	}
+}
+
+func TestCombineConditionalAccessControlsOperator(t *testing.T) {
+	results := []conditionalAccessEvaluationResult{
+		{
+			Policy: conditionalAccessEvaluationPolicy{
+				Policy: stable.ConditionalAccessPolicy{
+					DisplayName: pointer.To("Require MFA or compliant device"),
+					GrantControls: &stable.ConditionalAccessGrantControls{
+						Operator:        nullable.Value("OR"),
+						BuiltInControls: &[]stable.ConditionalAccessGrantControl{stable.ConditionalAccessGrantControl_Mfa, stable.ConditionalAccessGrantControl_CompliantDevice},
+					},
+				},
+			},
+		},
+		{
+			Policy: conditionalAccessEvaluationPolicy{
+				Policy: stable.ConditionalAccessPolicy{
+					DisplayName: pointer.To("Require terms of use"),
+					GrantControls: &stable.ConditionalAccessGrantControls{
+						Operator:   nullable.Value("AND"),
+						TermsOfUse: &[]string{"11111111-1111-1111-1111-111111111111"},
+					},
+				},
+			},
+		},
+	}
+
+	controls := combineConditionalAccessControls(results)
+
+	expected := []conditionalAccessGrantRequirement{
+		{
+			DisplayName:                     "Require MFA or compliant device",
+			Operator:                        "OR",
+			BuiltInControls:                 []string{"compliantDevice", "mfa"},
+			AuthenticationStrengthPolicyIds: []string{},
+			TermsOfUse:                      []string{},
+		},
+		{
+			DisplayName:                     "Require terms of use",
+			Operator:                        "AND",
+			BuiltInControls:                 []string{},
+			AuthenticationStrengthPolicyIds: []string{},
+			TermsOfUse:                      []string{"11111111-1111-1111-1111-111111111111"},
+		},
+	}
+	if !reflect.DeepEqual(expected, controls.GrantRequirements) {
+		t.Fatalf("unexpected grant requirements\nexpected: %+v\nactual:   %+v", expected, controls.GrantRequirements)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func conditionalAccessPoliciesDataSource() *pluginsdk.Resource {
+	policySchema := conditionalAccessPolicyResource().Schema
+
+	return &pluginsdk.Resource{
+		ReadContext: conditionalAccessPoliciesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"display_name_prefix": {
+				Description:  "Only return policies with a display name starting with this value",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"referenced_object_ids": {
+				Description: "Only return policies which include or exclude at least one of these users, groups, roles, applications, service principals, named locations or terms of use agreements",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"states": {
+				Description: "Only return policies with one of these states",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessPolicyState(), false),
+				},
+			},
+
+			"object_ids": {
+				Description: "The object IDs of the conditional access policies",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"policies": {
+				Description: "A list of conditional access policies",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"conditions": conditionalAccessPolicyComputedSchema(policySchema["conditions"]),
+
+						"display_name": {
+							Description: "The display name of the policy",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"grant_controls": conditionalAccessPolicyComputedSchema(policySchema["grant_controls"]),
+
+						"id": {
+							Description: "The ID of the policy",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"object_id": {
+							Description: "The object ID of the policy",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"session_controls": conditionalAccessPolicyComputedSchema(policySchema["session_controls"]),
+
+						"state": {
+							Description: "The state of the policy",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"template_id": {
+							Description: "The ID of the template the policy was created from",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func conditionalAccessPoliciesDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.PolicyClient
+
+	displayNamePrefix := d.Get("display_name_prefix").(string)
+	states := tf.ExpandStringSlice(d.Get("states").([]interface{}))
+
+	referencedIds := make([]string, 0)
+	for _, v := range d.Get("referenced_object_ids").([]interface{}) {
+		referencedIds = append(referencedIds, conditionalAccessReferencedObjectId(v.(string)))
+	}
+
+	policies, extensions, err := listConditionalAccessPolicies(ctx, client)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving conditional access policies")
+	}
+
+	objectIds := make([]string, 0)
+	policyList := make([]map[string]interface{}, 0)
+	for i, policy := range policies {
+		displayName := pointer.From(policy.DisplayName)
+		if displayNamePrefix != "" && !strings.HasPrefix(strings.ToLower(displayName), strings.ToLower(displayNamePrefix)) {
+			continue
+		}
+
+		state := string(pointer.From(policy.State))
+		if len(states) > 0 && !slices.Contains(states, state) {
+			continue
+		}
+
+		if len(referencedIds) > 0 {
+			policyReferencedIds := conditionalAccessPolicyReferencedObjectIds(policy)
+			if !slices.ContainsFunc(referencedIds, func(v string) bool {
+				return slices.ContainsFunc(policyReferencedIds, func(r string) bool { return strings.EqualFold(v, r) })
+			}) {
+				continue
+			}
+		}
+
+		objectId := pointer.From(policy.Id)
+		objectIds = append(objectIds, objectId)
+
+		policyList = append(policyList, map[string]interface{}{
+			"conditions":       flattenConditionalAccessConditionSet(policy.Conditions, extensions[i].AuthenticationFlows),
+			"display_name":     displayName,
+			"grant_controls":   flattenConditionalAccessGrantControls(policy.GrantControls),
+			"id":               stable.NewIdentityConditionalAccessPolicyID(objectId).ID(),
+			"object_id":        objectId,
+			"session_controls": flattenConditionalAccessSessionControls(policy.SessionControls, extensions[i]),
+			"state":            state,
+			"template_id":      policy.TemplateId.GetOrZero(),
+		})
+	}
+
+	// Generate a unique ID based on the filters and result
+	h := sha1.New()
+	if _, err = h.Write([]byte(displayNamePrefix + "/" + strings.Join(states, "/") + "/" + strings.Join(referencedIds, "/") + "/" + strings.Join(objectIds, "/"))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for conditional access policies")
+	}
+
+	d.SetId("conditionalAccessPolicies#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "object_ids", objectIds)
+	tf.Set(d, "policies", policyList)
+
+	return nil
+}
+
+// conditionalAccessReferencedObjectId returns the object ID from a resource ID, such as that of a named location, so
+// that resource IDs can be used interchangeably with object IDs
+func conditionalAccessReferencedObjectId(in string) string {
+	if i := strings.LastIndex(in, "/"); i >= 0 {
+		return in[i+1:]
+	}
+	return in
+}
+
+// conditionalAccessPolicyReferencedObjectIds returns the IDs of all users, groups, roles, applications, service
+// principals, named locations, terms of use agreements and authentication strength policies referenced by a policy
+func conditionalAccessPolicyReferencedObjectIds(policy stable.ConditionalAccessPolicy) []string {
+	result := make([]string, 0)
+	add := func(in *[]string) {
+		if in != nil {
+			result = append(result, *in...)
+		}
+	}
+
+	if conditions := policy.Conditions; conditions != nil {
+		if users := conditions.Users; users != nil {
+			add(users.IncludeUsers)
+			add(users.ExcludeUsers)
+			add(users.IncludeGroups)
+			add(users.ExcludeGroups)
+			add(users.IncludeRoles)
+			add(users.ExcludeRoles)
+		}
+
+		add(conditions.Applications.IncludeApplications)
+		add(conditions.Applications.ExcludeApplications)
+
+		if clientApplications := conditions.ClientApplications; clientApplications != nil {
+			add(clientApplications.IncludeServicePrincipals)
+			add(clientApplications.ExcludeServicePrincipals)
+		}
+
+		if locations := conditions.Locations; locations != nil {
+			add(locations.IncludeLocations)
+			add(locations.ExcludeLocations)
+		}
+	}
+
+	if grantControls := policy.GrantControls; grantControls != nil {
+		add(grantControls.TermsOfUse)
+		if grantControls.AuthenticationStrength != nil && grantControls.AuthenticationStrength.Id != nil {
+			result = append(result, *grantControls.AuthenticationStrength.Id)
+		}
+	}
+
+	return result
+}
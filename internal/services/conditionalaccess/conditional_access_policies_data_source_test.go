@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ConditionalAccessPoliciesDataSource struct{}
+
+func TestAccConditionalAccessPoliciesDataSource_displayNamePrefix(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_policies", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: ConditionalAccessPoliciesDataSource{}.displayNamePrefix(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("object_ids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("policies.#").HasValue("1"),
+				check.That(data.ResourceName).Key("policies.0.display_name").HasValue(fmt.Sprintf("acctest-CONPOLICY-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("policies.0.state").HasValue("disabled"),
+				check.That(data.ResourceName).Key("policies.0.conditions.0.users.0.included_users.0").HasValue("All"),
+				check.That(data.ResourceName).Key("policies.0.grant_controls.0.built_in_controls.0").HasValue("block"),
+			),
+		},
+	})
+}
+
+func TestAccConditionalAccessPoliciesDataSource_states(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_policies", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: ConditionalAccessPoliciesDataSource{}.states(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("policies.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAccConditionalAccessPoliciesDataSource_referencedObjectIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_policies", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: ConditionalAccessPoliciesDataSource{}.referencedObjectIds(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("policies.#").HasValue("1"),
+				check.That(data.ResourceName).Key("policies.0.display_name").HasValue(fmt.Sprintf("acctest-CONPOLICY-%d", data.RandomInteger)),
+			),
+		},
+	})
+}
+
+func (ConditionalAccessPoliciesDataSource) displayNamePrefix(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_conditional_access_policies" "test" {
+  display_name_prefix = azuread_conditional_access_policy.test.display_name
+}
+`, ConditionalAccessPolicyResource{}.basic(data))
+}
+
+func (ConditionalAccessPoliciesDataSource) states(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_conditional_access_policies" "test" {
+  display_name_prefix = azuread_conditional_access_policy.test.display_name
+  states              = ["enabled", "enabledForReportingButNotEnforced"]
+}
+`, ConditionalAccessPolicyResource{}.basic(data))
+}
+
+func (ConditionalAccessPoliciesDataSource) referencedObjectIds(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_conditional_access_policies" "test" {
+  display_name_prefix   = "acctest-CONPOLICY-"
+  referenced_object_ids = [data.azuread_service_principal.test.object_id]
+
+  depends_on = [azuread_conditional_access_policy.test]
+}
+`, ConditionalAccessPolicyResource{}.clientApplicationsIncluded(data))
+}
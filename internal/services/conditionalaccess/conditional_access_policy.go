@@ -0,0 +1,401 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesspolicy"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+)
+
+// The stable API supports authentication flow conditions, along with the continuous access evaluation and secure sign-in
+// session controls, but the SDK does not yet model them. Policies are therefore sent and retrieved using the operations
+// below, which add these properties to the stable models. The Beta API must not be used for this, as doing so
+// irrevocably mutates the policy (see the note in the client package).
+
+const (
+	conditionalAccessTransferMethodAuthenticationTransfer = "authenticationTransfer"
+	conditionalAccessTransferMethodDeviceCodeFlow         = "deviceCodeFlow"
+	conditionalAccessTransferMethodNone                   = "none"
+)
+
+func possibleValuesForConditionalAccessTransferMethods() []string {
+	return []string{
+		conditionalAccessTransferMethodAuthenticationTransfer,
+		conditionalAccessTransferMethodDeviceCodeFlow,
+	}
+}
+
+const (
+	conditionalAccessContinuousAccessEvaluationModeDisabled          = "disabled"
+	conditionalAccessContinuousAccessEvaluationModeStrictEnforcement = "strictEnforcement"
+	conditionalAccessContinuousAccessEvaluationModeStrictLocation    = "strictLocation"
+)
+
+func possibleValuesForConditionalAccessContinuousAccessEvaluationMode() []string {
+	return []string{
+		conditionalAccessContinuousAccessEvaluationModeDisabled,
+		conditionalAccessContinuousAccessEvaluationModeStrictEnforcement,
+		conditionalAccessContinuousAccessEvaluationModeStrictLocation,
+	}
+}
+
+type conditionalAccessAuthenticationFlows struct {
+	// TransferMethods is a comma-separated list of transfer methods
+	TransferMethods *string `json:"transferMethods,omitempty"`
+}
+
+type conditionalAccessContinuousAccessEvaluationSessionControl struct {
+	Mode *string `json:"mode,omitempty"`
+}
+
+type conditionalAccessSecureSignInSessionControl struct {
+	IsEnabled *bool `json:"isEnabled,omitempty"`
+}
+
+// conditionalAccessPolicyExtensions holds the policy properties not modeled by the SDK. Session controls which are
+// explicitly null are removed from the policy. The stable models omit the insider risk levels and application filter
+// conditions when they are unset, so these are instead removed by sending an explicit null when flagged for removal.
+type conditionalAccessPolicyExtensions struct {
+	AuthenticationFlows        *conditionalAccessAuthenticationFlows
+	ContinuousAccessEvaluation nullable.Type[conditionalAccessContinuousAccessEvaluationSessionControl]
+	SecureSignInSession        nullable.Type[conditionalAccessSecureSignInSessionControl]
+
+	RemoveApplicationFilter bool
+	RemoveInsiderRiskLevels bool
+}
+
+func (e conditionalAccessPolicyExtensions) isEmpty() bool {
+	return e.AuthenticationFlows == nil && !e.ContinuousAccessEvaluation.IsSet() && !e.SecureSignInSession.IsSet() &&
+		!e.RemoveApplicationFilter && !e.RemoveInsiderRiskLevels
+}
+
+type conditionalAccessPolicyExtensionsModel struct {
+	Conditions *struct {
+		AuthenticationFlows *conditionalAccessAuthenticationFlows `json:"authenticationFlows,omitempty"`
+	} `json:"conditions,omitempty"`
+	SessionControls *struct {
+		ContinuousAccessEvaluation *conditionalAccessContinuousAccessEvaluationSessionControl `json:"continuousAccessEvaluation,omitempty"`
+		SecureSignInSession        *conditionalAccessSecureSignInSessionControl               `json:"secureSignInSession,omitempty"`
+	} `json:"sessionControls,omitempty"`
+}
+
+func (m conditionalAccessPolicyExtensionsModel) extensions() conditionalAccessPolicyExtensions {
+	result := conditionalAccessPolicyExtensions{}
+	if conditions := m.Conditions; conditions != nil {
+		result.AuthenticationFlows = conditions.AuthenticationFlows
+	}
+	if sessionControls := m.SessionControls; sessionControls != nil {
+		if sessionControls.ContinuousAccessEvaluation != nil {
+			result.ContinuousAccessEvaluation = nullable.Value(*sessionControls.ContinuousAccessEvaluation)
+		}
+		if sessionControls.SecureSignInSession != nil {
+			result.SecureSignInSession = nullable.Value(*sessionControls.SecureSignInSession)
+		}
+	}
+	return result
+}
+
+func expandConditionalAccessAuthenticationFlows(in []interface{}) *conditionalAccessAuthenticationFlows {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	config := in[0].(map[string]interface{})
+
+	transferMethods := make([]string, 0)
+	for _, v := range config["transfer_methods"].(*pluginsdk.Set).List() {
+		transferMethods = append(transferMethods, v.(string))
+	}
+	sort.Strings(transferMethods)
+
+	result := conditionalAccessAuthenticationFlows{
+		TransferMethods: pointer.To(conditionalAccessTransferMethodNone),
+	}
+	if len(transferMethods) > 0 {
+		result.TransferMethods = pointer.To(strings.Join(transferMethods, ","))
+	}
+
+	return &result
+}
+
+func flattenConditionalAccessAuthenticationFlows(in *conditionalAccessAuthenticationFlows) []interface{} {
+	if in == nil || in.TransferMethods == nil {
+		return []interface{}{}
+	}
+
+	transferMethods := make([]interface{}, 0)
+	for _, v := range strings.Split(*in.TransferMethods, ",") {
+		if v = strings.TrimSpace(v); v != "" && v != conditionalAccessTransferMethodNone {
+			transferMethods = append(transferMethods, v)
+		}
+	}
+
+	if len(transferMethods) == 0 {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"transfer_methods": transferMethods,
+		},
+	}
+}
+
+// marshalConditionalAccessPolicy serializes a policy, adding the specified extension properties
+func marshalConditionalAccessPolicy(policy stable.ConditionalAccessPolicy, extensions conditionalAccessPolicyExtensions) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling policy: %+v", err)
+	}
+
+	var result map[string]interface{}
+	if err = json.Unmarshal(encoded, &result); err != nil {
+		return nil, fmt.Errorf("unmarshaling policy: %+v", err)
+	}
+
+	if extensions.AuthenticationFlows != nil || extensions.RemoveApplicationFilter || extensions.RemoveInsiderRiskLevels {
+		conditions, ok := result["conditions"].(map[string]interface{})
+		if !ok {
+			conditions = make(map[string]interface{})
+		}
+		if extensions.AuthenticationFlows != nil {
+			conditions["authenticationFlows"] = extensions.AuthenticationFlows
+		}
+		if extensions.RemoveInsiderRiskLevels {
+			conditions["insiderRiskLevels"] = nil
+		}
+		if extensions.RemoveApplicationFilter {
+			applications, ok := conditions["applications"].(map[string]interface{})
+			if !ok {
+				applications = make(map[string]interface{})
+			}
+			applications["applicationFilter"] = nil
+			conditions["applications"] = applications
+		}
+		result["conditions"] = conditions
+	}
+
+	sessionControls, _ := result["sessionControls"].(map[string]interface{})
+	for key, value := range map[string]interface {
+		IsSet() bool
+		IsNull() bool
+	}{
+		"continuousAccessEvaluation": extensions.ContinuousAccessEvaluation,
+		"secureSignInSession":        extensions.SecureSignInSession,
+	} {
+		if !value.IsSet() {
+			continue
+		}
+
+		// Removing a session control is implied when the entire sessionControls object is null
+		if value.IsNull() && sessionControls == nil {
+			continue
+		}
+
+		if sessionControls == nil {
+			sessionControls = make(map[string]interface{})
+		}
+		sessionControls[key] = value
+	}
+	if sessionControls != nil {
+		result["sessionControls"] = sessionControls
+	}
+
+	return result, nil
+}
+
+func createConditionalAccessPolicy(ctx context.Context, c *conditionalaccesspolicy.ConditionalAccessPolicyClient, policy stable.ConditionalAccessPolicy, extensions conditionalAccessPolicyExtensions) (*stable.ConditionalAccessPolicy, error) {
+	if extensions.isEmpty() {
+		resp, err := c.CreateConditionalAccessPolicy(ctx, policy, conditionalaccesspolicy.DefaultCreateConditionalAccessPolicyOperationOptions())
+		if err != nil {
+			return nil, err
+		}
+		return resp.Model, nil
+	}
+
+	input, err := marshalConditionalAccessPolicy(policy, extensions)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusCreated,
+		},
+		HttpMethod: http.MethodPost,
+		Path:       "/identity/conditionalAccess/policies",
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = req.Marshal(input); err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var model stable.ConditionalAccessPolicy
+	if err = resp.Unmarshal(&model); err != nil {
+		return nil, err
+	}
+
+	return &model, nil
+}
+
+func updateConditionalAccessPolicy(ctx context.Context, c *conditionalaccesspolicy.ConditionalAccessPolicyClient, id stable.IdentityConditionalAccessPolicyId, policy stable.ConditionalAccessPolicy, extensions conditionalAccessPolicyExtensions) error {
+	if extensions.isEmpty() {
+		_, err := c.UpdateConditionalAccessPolicy(ctx, id, policy, conditionalaccesspolicy.DefaultUpdateConditionalAccessPolicyOperationOptions())
+		return err
+	}
+
+	input, err := marshalConditionalAccessPolicy(policy, extensions)
+	if err != nil {
+		return err
+	}
+
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPatch,
+		Path:       id.ID(),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if err = req.Marshal(input); err != nil {
+		return err
+	}
+
+	_, err = req.Execute(ctx)
+	return err
+}
+
+// getConditionalAccessPolicy retrieves a policy along with its extension properties
+func getConditionalAccessPolicy(ctx context.Context, c *conditionalaccesspolicy.ConditionalAccessPolicyClient, id stable.IdentityConditionalAccessPolicyId) (*stable.ConditionalAccessPolicy, *conditionalAccessPolicyExtensions, *http.Response, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       id.ID(),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	resp, err := req.Execute(ctx)
+	var httpResponse *http.Response
+	if resp != nil {
+		httpResponse = resp.Response
+	}
+	if err != nil {
+		return nil, nil, httpResponse, err
+	}
+
+	var model stable.ConditionalAccessPolicy
+	if err = resp.Unmarshal(&model); err != nil {
+		return nil, nil, httpResponse, err
+	}
+
+	var extensionsModel conditionalAccessPolicyExtensionsModel
+	if err = resp.Unmarshal(&extensionsModel); err != nil {
+		return nil, nil, httpResponse, err
+	}
+
+	extensions := extensionsModel.extensions()
+
+	return &model, &extensions, httpResponse, nil
+}
+
+type conditionalAccessPolicyListPager struct {
+	NextLink *odata.Link `json:"@odata.nextLink"`
+}
+
+func (p *conditionalAccessPolicyListPager) NextPageLink() *odata.Link {
+	defer func() {
+		p.NextLink = nil
+	}()
+
+	return p.NextLink
+}
+
+// listConditionalAccessPolicies retrieves all policies along with their extension properties
+func listConditionalAccessPolicies(ctx context.Context, c *conditionalaccesspolicy.ConditionalAccessPolicyClient) ([]stable.ConditionalAccessPolicy, []conditionalAccessPolicyExtensions, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Pager:      &conditionalAccessPolicyListPager{},
+		Path:       "/identity/conditionalAccess/policies",
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := req.ExecutePaged(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var values struct {
+		Values *[]json.RawMessage `json:"value"`
+	}
+	if err = resp.Unmarshal(&values); err != nil {
+		return nil, nil, err
+	}
+
+	policies := make([]stable.ConditionalAccessPolicy, 0)
+	extensions := make([]conditionalAccessPolicyExtensions, 0)
+	if values.Values == nil {
+		return policies, extensions, nil
+	}
+
+	for i, value := range *values.Values {
+		var model stable.ConditionalAccessPolicy
+		if err = json.Unmarshal(value, &model); err != nil {
+			return nil, nil, fmt.Errorf("unmarshaling policy %d: %+v", i, err)
+		}
+
+		var extensionsModel conditionalAccessPolicyExtensionsModel
+		if err = json.Unmarshal(value, &extensionsModel); err != nil {
+			return nil, nil, fmt.Errorf("unmarshaling policy %d: %+v", i, err)
+		}
+
+		policies = append(policies, model)
+		extensions = append(extensions, extensionsModel.extensions())
+	}
+
+	return policies, extensions, nil
+}
@@ -8,13 +8,17 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesspolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesstemplate"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/features"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
@@ -67,14 +71,26 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 
 			"state": {
 				Type:         pluginsdk.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessPolicyState(), false),
 			},
 
+			"template_id": {
+				Description:  "The ID of a conditional access template from which to seed any of the `conditions`, `grant_controls` and `session_controls` blocks which are not specified",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
 			"conditions": {
-				Type:     pluginsdk.TypeList,
-				Required: true,
-				MaxItems: 1,
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"conditions", "template_id"},
+				MaxItems:     1,
 				Elem: &pluginsdk.Resource{
 					Schema: map[string]*pluginsdk.Schema{
 						"applications": {
@@ -86,7 +102,7 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 									"included_applications": {
 										Type:         pluginsdk.TypeList,
 										Optional:     true,
-										ExactlyOneOf: []string{"conditions.0.applications.0.included_applications", "conditions.0.applications.0.included_user_actions"},
+										ExactlyOneOf: []string{"conditions.0.applications.0.included_applications", "conditions.0.applications.0.included_authentication_context_class_references", "conditions.0.applications.0.included_user_actions"},
 										Elem: &pluginsdk.Schema{
 											Type:         pluginsdk.TypeString,
 											ValidateFunc: validation.StringIsNotEmpty,
@@ -102,10 +118,41 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 										},
 									},
 
+									"filter": {
+										Type:     pluginsdk.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &pluginsdk.Resource{
+											Schema: map[string]*pluginsdk.Schema{
+												"mode": {
+													Type:         pluginsdk.TypeString,
+													Required:     true,
+													ValidateFunc: validation.StringInSlice(stable.PossibleValuesForFilterMode(), false),
+												},
+
+												"rule": {
+													Type:         pluginsdk.TypeString,
+													Required:     true,
+													ValidateFunc: validation.StringIsNotEmpty,
+												},
+											},
+										},
+									},
+
+									"included_authentication_context_class_references": {
+										Type:         pluginsdk.TypeList,
+										Optional:     true,
+										ExactlyOneOf: []string{"conditions.0.applications.0.included_applications", "conditions.0.applications.0.included_authentication_context_class_references", "conditions.0.applications.0.included_user_actions"},
+										Elem: &pluginsdk.Schema{
+											Type:         pluginsdk.TypeString,
+											ValidateFunc: validation.StringMatch(authenticationContextIdRegex, "must be one of `c1` through `c99`"),
+										},
+									},
+
 									"included_user_actions": {
 										Type:         pluginsdk.TypeList,
 										Optional:     true,
-										ExactlyOneOf: []string{"conditions.0.applications.0.included_applications", "conditions.0.applications.0.included_user_actions"},
+										ExactlyOneOf: []string{"conditions.0.applications.0.included_applications", "conditions.0.applications.0.included_authentication_context_class_references", "conditions.0.applications.0.included_user_actions"},
 										Elem: &pluginsdk.Schema{
 											Type:         pluginsdk.TypeString,
 											ValidateFunc: validation.StringIsNotEmpty,
@@ -115,6 +162,25 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 							},
 						},
 
+						"authentication_flows": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"transfer_methods": {
+										Type:     pluginsdk.TypeSet,
+										Required: true,
+										MinItems: 1,
+										Elem: &pluginsdk.Schema{
+											Type:         pluginsdk.TypeString,
+											ValidateFunc: validation.StringInSlice(possibleValuesForConditionalAccessTransferMethods(), false),
+										},
+									},
+								},
+							},
+						},
+
 						"client_applications": {
 							Type:     pluginsdk.TypeList,
 							Optional: true,
@@ -382,6 +448,15 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 							},
 						},
 
+						"insider_risk_levels": {
+							Type:     pluginsdk.TypeSet,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessInsiderRiskLevels(), false),
+							},
+						},
+
 						"service_principal_risk_levels": {
 							Type:     pluginsdk.TypeList,
 							Optional: true,
@@ -415,7 +490,8 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 			"grant_controls": {
 				Type:         pluginsdk.TypeList,
 				Optional:     true,
-				AtLeastOneOf: []string{"grant_controls", "session_controls"},
+				Computed:     true,
+				AtLeastOneOf: []string{"grant_controls", "session_controls", "template_id"},
 				MaxItems:     1,
 				Elem: &pluginsdk.Resource{
 					Schema: map[string]*pluginsdk.Schema{
@@ -456,8 +532,9 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 							Optional:     true,
 							AtLeastOneOf: []string{"grant_controls.0.built_in_controls", "grant_controls.0.authentication_strength_policy_id", "grant_controls.0.terms_of_use"},
 							Elem: &pluginsdk.Schema{
-								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.StringIsNotEmpty,
+								Type:             pluginsdk.TypeString,
+								ValidateFunc:     validation.StringIsNotEmpty,
+								DiffSuppressFunc: conditionalAccessTermsOfUseDiffSuppress,
 							},
 						},
 					},
@@ -467,7 +544,8 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 			"session_controls": {
 				Type:             pluginsdk.TypeList,
 				Optional:         true,
-				AtLeastOneOf:     []string{"grant_controls", "session_controls"},
+				Computed:         true,
+				AtLeastOneOf:     []string{"grant_controls", "session_controls", "template_id"},
 				MaxItems:         1,
 				DiffSuppressFunc: conditionalAccessPolicyDiffSuppress,
 				Elem: &pluginsdk.Resource{
@@ -483,6 +561,12 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForCloudAppSecuritySessionControlType(), false),
 						},
 
+						"continuous_access_evaluation_mode": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(possibleValuesForConditionalAccessContinuousAccessEvaluationMode(), false),
+						},
+
 						"disable_resilience_defaults": {
 							Type:     pluginsdk.TypeBool,
 							Optional: true,
@@ -494,6 +578,11 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForPersistentBrowserSessionMode(), false),
 						},
 
+						"secure_sign_in_session_enabled": {
+							Type:     pluginsdk.TypeBool,
+							Optional: true,
+						},
+
 						"sign_in_frequency": {
 							Type:         pluginsdk.TypeInt,
 							Optional:     true,
@@ -530,17 +619,29 @@ func conditionalAccessPolicyResource() *pluginsdk.Resource {
 				Type:        pluginsdk.TypeString,
 				Computed:    true,
 			},
+
+			"skip_required_exclusions": {
+				Description: "Whether to skip enforcement of the users and groups which the provider requires to be excluded from policies that include all users",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 }
 
-func conditionalAccessPolicyCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+func conditionalAccessPolicyCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+	if err := conditionalAccessPolicyCustomizeDiffTemplate(diff); err != nil {
+		return err
+	}
+
 	// The API does not like sessionControls being set with ineffectual properties, so this additional validation complements
 	// AtLeastOneOf: []string{"grant_controls", "session_controls"} by helping to ensure that either `grant_controls` or a
 	// _useful_ `session_controls` block has been set in the configuration.
 	var sessionControlsSetButIneffective bool
 	if diff.Get("session_controls.#").(int) == 1 && !diff.Get("session_controls.0.application_enforced_restrictions_enabled").(bool) &&
 		diff.Get("session_controls.0.cloud_app_security_policy").(string) == "" && !diff.Get("session_controls.0.disable_resilience_defaults").(bool) &&
+		diff.Get("session_controls.0.continuous_access_evaluation_mode").(string) == "" && !diff.Get("session_controls.0.secure_sign_in_session_enabled").(bool) &&
 		diff.Get("session_controls.0.persistent_browser_mode").(string) == "" && diff.Get("session_controls.0.sign_in_frequency").(int) == 0 &&
 		diff.Get("session_controls.0.sign_in_frequency_authentication_type").(string) == string(stable.SignInFrequencyAuthenticationType_PrimaryAndSecondaryAuthentication) &&
 		diff.Get("session_controls.0.sign_in_frequency_interval").(string) == string(stable.SignInFrequencyInterval_TimeBased) {
@@ -550,6 +651,162 @@ func conditionalAccessPolicyCustomizeDiff(_ context.Context, diff *pluginsdk.Res
 		return fmt.Errorf("when specifying `session_controls` but not `grant_controls`, one of the properties in the `session_controls` block must be set to an effective value in order for session controls to work")
 	}
 
+	if err := validateConditionalAccessSessionControlCombinations(diff); err != nil {
+		return err
+	}
+
+	if !diff.Get("skip_required_exclusions").(bool) {
+		if err := validateConditionalAccessRequiredExclusions(diff, meta.(*clients.Client).Features.ConditionalAccess); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// conditionalAccessPolicyCustomizeDiffTemplate handles properties which are computed only when a policy is seeded from a
+// template. When `template_id` is not configured, these properties must be configured, and removing a block removes it
+// from the policy.
+func conditionalAccessPolicyCustomizeDiffTemplate(diff *pluginsdk.ResourceDiff) error {
+	config := diff.GetRawConfig()
+
+	if v := config.GetAttr("template_id"); !v.IsKnown() || !v.IsNull() {
+		if diff.Id() == "" && config.GetAttr("state").IsNull() {
+			return diff.SetNew("state", string(stable.ConditionalAccessPolicyState_EnabledForReportingButNotEnforced))
+		}
+		return nil
+	}
+
+	if config.GetAttr("state").IsNull() {
+		return fmt.Errorf("`state` must be specified unless the policy is seeded from a `template_id`")
+	}
+
+	for _, key := range []string{"grant_controls", "session_controls"} {
+		if v := config.GetAttr(key); v.IsKnown() && !v.IsNull() && v.LengthInt() == 0 && diff.Get(key+".#").(int) > 0 {
+			if err := diff.SetNew(key, []interface{}{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateConditionalAccessRequiredExclusions ensures that policies which include all users also exclude any users and
+// groups which the provider has been configured to require, so that emergency access accounts cannot be locked out
+func validateConditionalAccessRequiredExclusions(diff *pluginsdk.ResourceDiff, settings features.ConditionalAccessFeatures) error {
+	if len(settings.RequiredExcludedUsers) == 0 && len(settings.RequiredExcludedGroups) == 0 {
+		return nil
+	}
+
+	// Exclusions cannot be checked until all referenced object IDs are known, in which case they are checked at apply time
+	for _, key := range []string{"conditions.0.users.0.included_users", "conditions.0.users.0.excluded_users", "conditions.0.users.0.excluded_groups"} {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	includedUsers := tf.ExpandStringSlice(diff.Get("conditions.0.users.0.included_users").([]interface{}))
+	excludedUsers := tf.ExpandStringSlice(diff.Get("conditions.0.users.0.excluded_users").([]interface{}))
+	excludedGroups := tf.ExpandStringSlice(diff.Get("conditions.0.users.0.excluded_groups").([]interface{}))
+
+	for _, v := range slices.Concat(excludedUsers, excludedGroups) {
+		if !pluginsdk.ValueIsNotEmptyOrUnknown(v) {
+			return nil
+		}
+	}
+
+	return checkConditionalAccessRequiredExclusions(includedUsers, excludedUsers, excludedGroups, settings)
+}
+
+// checkConditionalAccessPolicyRequiredExclusions checks the required exclusions at apply time, when conditions seeded
+// from a template and any object IDs which were unknown during planning are known
+func checkConditionalAccessPolicyRequiredExclusions(d *pluginsdk.ResourceData, settings features.ConditionalAccessFeatures) error {
+	if d.Get("skip_required_exclusions").(bool) {
+		return nil
+	}
+
+	return checkConditionalAccessRequiredExclusions(
+		tf.ExpandStringSlice(d.Get("conditions.0.users.0.included_users").([]interface{})),
+		tf.ExpandStringSlice(d.Get("conditions.0.users.0.excluded_users").([]interface{})),
+		tf.ExpandStringSlice(d.Get("conditions.0.users.0.excluded_groups").([]interface{})),
+		settings,
+	)
+}
+
+// checkConditionalAccessRequiredExclusions returns an error when a policy including all users does not exclude the
+// required users and groups
+func checkConditionalAccessRequiredExclusions(includedUsers, excludedUsers, excludedGroups []string, settings features.ConditionalAccessFeatures) error {
+	if !slices.Contains(includedUsers, "All") {
+		return nil
+	}
+
+	missingUsers := missingConditionalAccessExclusions(excludedUsers, settings.RequiredExcludedUsers)
+	missingGroups := missingConditionalAccessExclusions(excludedGroups, settings.RequiredExcludedGroups)
+	if len(missingUsers) == 0 && len(missingGroups) == 0 {
+		return nil
+	}
+
+	missing := make([]string, 0)
+	if len(missingUsers) > 0 {
+		missing = append(missing, fmt.Sprintf("`excluded_users` must contain %s", strings.Join(missingUsers, ", ")))
+	}
+	if len(missingGroups) > 0 {
+		missing = append(missing, fmt.Sprintf("`excluded_groups` must contain %s", strings.Join(missingGroups, ", ")))
+	}
+
+	return fmt.Errorf("policy includes all users but does not exclude the users or groups required by the provider `conditional_access` block: %s. To intentionally apply this policy to these principals, set `skip_required_exclusions = true`", strings.Join(missing, "; "))
+}
+
+// missingConditionalAccessExclusions returns the required object IDs which are not present in the excluded object IDs
+func missingConditionalAccessExclusions(excluded, required []string) []string {
+	result := make([]string, 0)
+	for _, id := range required {
+		if !slices.ContainsFunc(excluded, func(v string) bool { return strings.EqualFold(v, id) }) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// validateConditionalAccessSessionControlCombinations rejects session controls which the API does not support in
+// combination with the rest of the policy
+func validateConditionalAccessSessionControlCombinations(diff *pluginsdk.ResourceDiff) error {
+	if diff.Get("session_controls.#").(int) == 0 {
+		return nil
+	}
+
+	includedApplications := tf.ExpandStringSlice(diff.Get("conditions.0.applications.0.included_applications").([]interface{}))
+
+	if mode := diff.Get("session_controls.0.continuous_access_evaluation_mode").(string); mode != "" {
+		// Continuous access evaluation is a tenant-wide behaviour and can only be customized for all applications
+		if len(includedApplications) != 1 || includedApplications[0] != "All" {
+			return fmt.Errorf("`continuous_access_evaluation_mode` can only be specified when `included_applications` is set to [\"All\"]")
+		}
+
+		// Resilience defaults only apply to sessions which are subject to continuous access evaluation
+		if mode == conditionalAccessContinuousAccessEvaluationModeDisabled && diff.Get("session_controls.0.disable_resilience_defaults").(bool) {
+			return fmt.Errorf("`disable_resilience_defaults` cannot be enabled when `continuous_access_evaluation_mode` is %q", mode)
+		}
+	}
+
+	if diff.Get("session_controls.0.secure_sign_in_session_enabled").(bool) {
+		// Token protection is only supported for desktop applications on Windows devices
+		clientAppTypes := tf.ExpandStringSlice(diff.Get("conditions.0.client_app_types").([]interface{}))
+		if len(clientAppTypes) != 1 || clientAppTypes[0] != string(stable.ConditionalAccessClientApp_MobileAppsAndDesktopClients) {
+			return fmt.Errorf("`secure_sign_in_session_enabled` can only be specified when `client_app_types` is set to [%q]", stable.ConditionalAccessClientApp_MobileAppsAndDesktopClients)
+		}
+
+		includedPlatforms := tf.ExpandStringSlice(diff.Get("conditions.0.platforms.0.included_platforms").([]interface{}))
+		if len(includedPlatforms) != 1 || includedPlatforms[0] != string(stable.ConditionalAccessDevicePlatform_Windows) {
+			return fmt.Errorf("`secure_sign_in_session_enabled` can only be specified when `included_platforms` is set to [%q]", stable.ConditionalAccessDevicePlatform_Windows)
+		}
+
+		if slices.Contains(includedApplications, "All") {
+			return fmt.Errorf("`secure_sign_in_session_enabled` cannot be specified when `included_applications` contains \"All\", as token protection is only supported by specific applications")
+		}
+	}
+
 	return nil
 }
 
@@ -571,9 +828,15 @@ func conditionalAccessPolicyDiffSuppress(k, old, new string, d *pluginsdk.Resour
 			if v, ok := sessionControls["cloud_app_security_policy"]; ok && v.(string) != "" {
 				suppress = false
 			}
+			if v, ok := sessionControls["continuous_access_evaluation_mode"]; ok && v.(string) != "" {
+				suppress = false
+			}
 			if v, ok := sessionControls["disable_resilience_defaults"]; ok && v.(bool) {
 				suppress = false
 			}
+			if v, ok := sessionControls["secure_sign_in_session_enabled"]; ok && v.(bool) {
+				suppress = false
+			}
 			if v, ok := sessionControls["persistent_browser_mode"]; ok && v.(string) != "" {
 				suppress = false
 			}
@@ -595,11 +858,32 @@ func conditionalAccessPolicyDiffSuppress(k, old, new string, d *pluginsdk.Resour
 	return suppress
 }
 
+// conditionalAccessTermsOfUseDiffSuppress suppresses diffs between the resource ID of a terms of use agreement and its
+// object ID, which is the value returned by the API
+func conditionalAccessTermsOfUseDiffSuppress(_, old, new string, _ *pluginsdk.ResourceData) bool {
+	return strings.EqualFold(termsOfUseAgreementObjectId(old), termsOfUseAgreementObjectId(new))
+}
+
 func conditionalAccessPolicyResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).ConditionalAccess.PolicyClient
 
 	var err error
 
+	if templateId := d.Get("template_id").(string); templateId != "" {
+		if err = seedConditionalAccessPolicyFromTemplate(ctx, meta.(*clients.Client).ConditionalAccess.TemplateClient, d, templateId); err != nil {
+			return tf.ErrorDiagPathF(err, "template_id", "Seeding conditional access policy from template")
+		}
+	}
+
+	if err = checkConditionalAccessPolicyRequiredExclusions(d, meta.(*clients.Client).Features.ConditionalAccess); err != nil {
+		return tf.ErrorDiagPathF(err, "conditions", "Validating required exclusions for conditional access policy")
+	}
+
+	authenticationContexts := tf.ExpandStringSlice(d.Get("conditions.0.applications.0.included_authentication_context_class_references").([]interface{}))
+	if err = validateConditionalAccessAuthenticationContextsExist(ctx, meta.(*clients.Client).ConditionalAccess.AuthenticationContextClient, authenticationContexts); err != nil {
+		return tf.ErrorDiagPathF(err, "conditions.0.applications.0.included_authentication_context_class_references", "Validating authentication contexts")
+	}
+
 	var grantControls *stable.ConditionalAccessGrantControls
 	if v, ok := d.GetOk("grant_controls"); ok {
 		grantControls, err = expandConditionalAccessGrantControls(v.([]interface{}))
@@ -621,12 +905,11 @@ func conditionalAccessPolicyResourceCreate(ctx context.Context, d *pluginsdk.Res
 		SessionControls: sessionControls,
 	}
 
-	resp, err := client.CreateConditionalAccessPolicy(ctx, properties, conditionalaccesspolicy.DefaultCreateConditionalAccessPolicyOperationOptions())
+	policy, err := createConditionalAccessPolicy(ctx, client, properties, expandConditionalAccessPolicyExtensions(d))
 	if err != nil {
 		return tf.ErrorDiagF(err, "Could not create conditional access policy")
 	}
 
-	policy := resp.Model
 	if policy == nil {
 		return tf.ErrorDiagF(errors.New("model was nil"), "Could not create conditional access policy")
 	}
@@ -664,6 +947,15 @@ func conditionalAccessPolicyResourceUpdate(ctx context.Context, d *pluginsdk.Res
 		return tf.ErrorDiagPathF(err, "id", "Parsing Conditional Access Policy ID")
 	}
 
+	if err = checkConditionalAccessPolicyRequiredExclusions(d, meta.(*clients.Client).Features.ConditionalAccess); err != nil {
+		return tf.ErrorDiagPathF(err, "conditions", "Validating required exclusions for conditional access policy")
+	}
+
+	authenticationContexts := tf.ExpandStringSlice(d.Get("conditions.0.applications.0.included_authentication_context_class_references").([]interface{}))
+	if err = validateConditionalAccessAuthenticationContextsExist(ctx, meta.(*clients.Client).ConditionalAccess.AuthenticationContextClient, authenticationContexts); err != nil {
+		return tf.ErrorDiagPathF(err, "conditions.0.applications.0.included_authentication_context_class_references", "Validating authentication contexts")
+	}
+
 	var grantControls *stable.ConditionalAccessGrantControls
 	if v, ok := d.GetOk("grant_controls"); ok {
 		grantControls, err = expandConditionalAccessGrantControls(v.([]interface{}))
@@ -685,7 +977,7 @@ func conditionalAccessPolicyResourceUpdate(ctx context.Context, d *pluginsdk.Res
 		SessionControls: sessionControls,
 	}
 
-	if _, err := client.UpdateConditionalAccessPolicy(ctx, *id, properties, conditionalaccesspolicy.DefaultUpdateConditionalAccessPolicyOperationOptions()); err != nil {
+	if err := updateConditionalAccessPolicy(ctx, client, *id, properties, expandConditionalAccessPolicyExtensions(d)); err != nil {
 		return tf.ErrorDiagF(err, "Could not update conditional access policy with ID: %q", d.Id())
 	}
 
@@ -734,9 +1026,9 @@ func conditionalAccessPolicyResourceRead(ctx context.Context, d *pluginsdk.Resou
 		return tf.ErrorDiagPathF(err, "id", "Parsing Conditional Access Policy ID")
 	}
 
-	resp, err := client.GetConditionalAccessPolicy(ctx, *id, conditionalaccesspolicy.DefaultGetConditionalAccessPolicyOperationOptions())
+	policy, extensions, httpResponse, err := getConditionalAccessPolicy(ctx, client, *id)
 	if err != nil {
-		if response.WasNotFound(resp.HttpResponse) {
+		if response.WasNotFound(httpResponse) {
 			log.Printf("[DEBUG] %s not found - removing from state", id)
 			d.SetId("")
 			return nil
@@ -745,7 +1037,6 @@ func conditionalAccessPolicyResourceRead(ctx context.Context, d *pluginsdk.Resou
 		return tf.ErrorDiagPathF(err, "id", "retrieving %s", id)
 	}
 
-	policy := resp.Model
 	if policy == nil {
 		return tf.ErrorDiagF(errors.New("model was nil"), "retrieving %s", id)
 	}
@@ -753,9 +1044,15 @@ func conditionalAccessPolicyResourceRead(ctx context.Context, d *pluginsdk.Resou
 	tf.Set(d, "object_id", pointer.From(policy.Id))
 	tf.Set(d, "display_name", pointer.From(policy.DisplayName))
 	tf.Set(d, "state", pointer.From(policy.State))
-	tf.Set(d, "conditions", flattenConditionalAccessConditionSet(policy.Conditions))
+
+	templateId := d.Get("template_id").(string)
+	if v := policy.TemplateId.GetOrZero(); v != "" {
+		templateId = v
+	}
+	tf.Set(d, "template_id", templateId)
+	tf.Set(d, "conditions", flattenConditionalAccessConditionSet(policy.Conditions, extensions.AuthenticationFlows))
 	tf.Set(d, "grant_controls", flattenConditionalAccessGrantControls(policy.GrantControls))
-	tf.Set(d, "session_controls", flattenConditionalAccessSessionControls(policy.SessionControls))
+	tf.Set(d, "session_controls", flattenConditionalAccessSessionControls(policy.SessionControls, *extensions))
 
 	return nil
 }
@@ -796,3 +1093,80 @@ func conditionalAccessPolicyResourceDelete(ctx context.Context, d *pluginsdk.Res
 
 	return nil
 }
+
+// seedConditionalAccessPolicyFromTemplate sets the conditions, grant controls and session controls of a new policy from
+// the details of a conditional access template, for any of these which are not specified in the configuration.
+// Overrides are per block: a configured block replaces the whole template block and is not merged with it, since a
+// partially configured block cannot be distinguished from one which intentionally leaves properties unset.
+func seedConditionalAccessPolicyFromTemplate(ctx context.Context, client *conditionalaccesstemplate.ConditionalAccessTemplateClient, d *pluginsdk.ResourceData, templateId string) error {
+	id := stable.NewIdentityConditionalAccessTemplateID(templateId)
+
+	resp, err := client.GetConditionalAccessTemplate(ctx, id, conditionalaccesstemplate.DefaultGetConditionalAccessTemplateOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return fmt.Errorf("%s was not found", id)
+		}
+		return fmt.Errorf("retrieving %s: %v", id, err)
+	}
+	if resp.Model == nil || resp.Model.Details == nil {
+		return fmt.Errorf("retrieving %s: model was nil", id)
+	}
+
+	details := resp.Model.Details
+	config := d.GetRawConfig()
+
+	if config.GetAttr("state").IsNull() {
+		tf.Set(d, "state", string(stable.ConditionalAccessPolicyState_EnabledForReportingButNotEnforced))
+	}
+
+	if config.GetAttr("conditions").LengthInt() == 0 {
+		if err = d.Set("conditions", flattenConditionalAccessConditionSet(details.Conditions, nil)); err != nil {
+			return fmt.Errorf("setting `conditions` from %s: %v", id, err)
+		}
+	}
+	if config.GetAttr("grant_controls").LengthInt() == 0 {
+		if err = d.Set("grant_controls", flattenConditionalAccessGrantControls(details.GrantControls)); err != nil {
+			return fmt.Errorf("setting `grant_controls` from %s: %v", id, err)
+		}
+	}
+	if config.GetAttr("session_controls").LengthInt() == 0 {
+		if err = d.Set("session_controls", flattenConditionalAccessSessionControls(details.SessionControls, conditionalAccessPolicyExtensions{})); err != nil {
+			return fmt.Errorf("setting `session_controls` from %s: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// expandConditionalAccessPolicyExtensions builds the policy properties not modeled by the SDK, ensuring that any which
+// have been removed from the configuration are also removed from the policy
+func expandConditionalAccessPolicyExtensions(d *pluginsdk.ResourceData) conditionalAccessPolicyExtensions {
+	result := conditionalAccessPolicyExtensions{
+		AuthenticationFlows: expandConditionalAccessAuthenticationFlows(d.Get("conditions.0.authentication_flows").([]interface{})),
+	}
+
+	if result.AuthenticationFlows == nil && d.HasChange("conditions.0.authentication_flows") {
+		// Removing the block must explicitly clear the transfer methods
+		result.AuthenticationFlows = &conditionalAccessAuthenticationFlows{
+			TransferMethods: pointer.To(conditionalAccessTransferMethodNone),
+		}
+	}
+
+	result.ContinuousAccessEvaluation, result.SecureSignInSession = expandConditionalAccessSessionControlExtensions(d.Get("session_controls").([]interface{}))
+
+	if !result.ContinuousAccessEvaluation.IsSet() && d.HasChange("session_controls.0.continuous_access_evaluation_mode") {
+		result.ContinuousAccessEvaluation.SetNull()
+	}
+	if !result.SecureSignInSession.IsSet() && d.HasChange("session_controls.0.secure_sign_in_session_enabled") {
+		result.SecureSignInSession.SetNull()
+	}
+
+	if d.HasChange("conditions.0.insider_risk_levels") && d.Get("conditions.0.insider_risk_levels").(*pluginsdk.Set).Len() == 0 {
+		result.RemoveInsiderRiskLevels = true
+	}
+	if d.HasChange("conditions.0.applications.0.filter") && len(d.Get("conditions.0.applications.0.filter").([]interface{})) == 0 {
+		result.RemoveApplicationFilter = true
+	}
+
+	return result
+}
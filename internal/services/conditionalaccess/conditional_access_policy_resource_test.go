@@ -6,6 +6,7 @@ package conditionalaccess_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
@@ -233,6 +234,46 @@ func TestAccConditionalAccessPolicy_sessionControls(t *testing.T) {
 	})
 }
 
+func TestAccConditionalAccessPolicy_sessionControlsContinuousAccessEvaluation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.sessionControlsContinuousAccessEvaluation(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("session_controls.0.continuous_access_evaluation_mode").HasValue("strictLocation"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.sessionControls(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("session_controls.0.continuous_access_evaluation_mode").HasValue(""),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccConditionalAccessPolicy_sessionControlsSecureSignInSession(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.sessionControlsSecureSignInSession(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("session_controls.0.secure_sign_in_session_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccConditionalAccessPolicy_clientApplications(t *testing.T) {
 	// This is a separate test for two reasons:
 	// - conditional access policies applies either to users/groups or to client applications (workload identities)
@@ -297,6 +338,106 @@ func TestAccConditionalAccessPolicy_authenticationStrength(t *testing.T) {
 	})
 }
 
+func TestAccConditionalAccessPolicy_authenticationContext(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.authenticationContext(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("conditions.0.applications.0.included_authentication_context_class_references.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccConditionalAccessPolicy_extendedConditions(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.extendedConditions(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("conditions.0.authentication_flows.0.transfer_methods.#").HasValue("1"),
+				check.That(data.ResourceName).Key("conditions.0.insider_risk_levels.#").HasValue("1"),
+				check.That(data.ResourceName).Key("conditions.0.insider_risk_levels.0").HasValue("elevated"),
+				check.That(data.ResourceName).Key("conditions.0.applications.0.filter.0.mode").HasValue("include"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("conditions.0.authentication_flows.#").HasValue("0"),
+				check.That(data.ResourceName).Key("conditions.0.insider_risk_levels.#").HasValue("0"),
+				check.That(data.ResourceName).Key("conditions.0.applications.0.filter.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccConditionalAccessPolicy_requiredExclusions(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.requiredExclusions(data, false),
+			ExpectError: regexp.MustCompile("does not exclude the users or groups required by the provider"),
+		},
+		{
+			Config: r.requiredExclusions(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("skip_required_exclusions").HasValue("true"),
+			),
+		},
+		data.ImportStep("skip_required_exclusions"),
+	})
+}
+
+func TestAccConditionalAccessPolicy_template(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("template_id").Exists(),
+				check.That(data.ResourceName).Key("state").HasValue("enabledForReportingButNotEnforced"),
+				check.That(data.ResourceName).Key("conditions.0.client_app_types.#").HasValue("2"),
+				check.That(data.ResourceName).Key("grant_controls.0.built_in_controls.0").HasValue("block"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.templateOverridden(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("state").HasValue("disabled"),
+				check.That(data.ResourceName).Key("conditions.0.users.0.excluded_users.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccConditionalAccessPolicy_guestsOrExternalUsers(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_conditional_access_policy", "test")
 	r := ConditionalAccessPolicyResource{}
@@ -378,6 +519,86 @@ resource "azuread_conditional_access_policy" "test" {
 `, data.RandomInteger)
 }
 
+func (ConditionalAccessPolicyResource) requiredExclusions(data acceptance.TestData, skip bool) string {
+	return fmt.Sprintf(`
+provider "azuread" {
+  conditional_access {
+    required_excluded_users = ["%[2]s"]
+  }
+}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name             = "acctest-CONPOLICY-%[1]d"
+  state                    = "disabled"
+  skip_required_exclusions = %[3]t
+
+  conditions {
+    client_app_types = ["browser"]
+
+    applications {
+      included_applications = ["None"]
+    }
+
+    users {
+      included_users = ["All"]
+      excluded_users = ["GuestsOrExternalUsers"]
+    }
+  }
+
+  grant_controls {
+    operator          = "OR"
+    built_in_controls = ["block"]
+  }
+}
+`, data.RandomInteger, data.RandomID, skip)
+}
+
+func (ConditionalAccessPolicyResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_conditional_access_templates" "test" {
+  names = ["Block legacy authentication"]
+}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[1]d"
+  template_id  = data.azuread_conditional_access_templates.test.template_ids[0]
+}
+`, data.RandomInteger)
+}
+
+func (ConditionalAccessPolicyResource) templateOverridden(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_client_config" "test" {}
+
+data "azuread_conditional_access_templates" "test" {
+  names = ["Block legacy authentication"]
+}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[1]d"
+  template_id  = data.azuread_conditional_access_templates.test.template_ids[0]
+  state        = "disabled"
+
+  conditions {
+    client_app_types = ["exchangeActiveSync", "other"]
+
+    applications {
+      included_applications = ["All"]
+    }
+
+    users {
+      included_users = ["All"]
+      excluded_users = [data.azuread_client_config.test.object_id]
+    }
+  }
+}
+`, data.RandomInteger)
+}
+
 func (ConditionalAccessPolicyResource) complete(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azuread" {}
@@ -474,6 +695,74 @@ resource "azuread_conditional_access_policy" "test" {
 `, data.RandomInteger)
 }
 
+func (ConditionalAccessPolicyResource) authenticationContext(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[2]d"
+  state        = "disabled"
+
+  conditions {
+    client_app_types = ["all"]
+
+    applications {
+      included_authentication_context_class_references = [azuread_conditional_access_authentication_context.test.context_id]
+    }
+
+    users {
+      included_users = ["All"]
+      excluded_users = ["GuestsOrExternalUsers"]
+    }
+  }
+
+  grant_controls {
+    operator          = "OR"
+    built_in_controls = ["mfa"]
+  }
+}
+`, ConditionalAccessAuthenticationContextResource{}.complete(data), data.RandomInteger)
+}
+
+func (ConditionalAccessPolicyResource) extendedConditions(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[1]d"
+  state        = "disabled"
+
+  conditions {
+    client_app_types    = ["all"]
+    insider_risk_levels = ["elevated"]
+
+    applications {
+      included_applications = ["All"]
+
+      filter {
+        mode = "include"
+        rule = "CustomSecurityAttribute.AcctestAttributeSet.AcctestAttribute -eq \"Sensitive\""
+      }
+    }
+
+    authentication_flows {
+      transfer_methods = ["deviceCodeFlow"]
+    }
+
+    users {
+      included_users = ["All"]
+      excluded_users = ["GuestsOrExternalUsers"]
+    }
+  }
+
+  grant_controls {
+    operator          = "OR"
+    built_in_controls = ["block"]
+  }
+}
+`, data.RandomInteger)
+}
+
 func (ConditionalAccessPolicyResource) sessionControls(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azuread" {}
@@ -677,6 +966,69 @@ resource "azuread_conditional_access_policy" "test" {
 `, data.RandomInteger)
 }
 
+func (ConditionalAccessPolicyResource) sessionControlsContinuousAccessEvaluation(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[1]d"
+  state        = "disabled"
+
+  conditions {
+    client_app_types = ["all"]
+
+    applications {
+      included_applications = ["All"]
+    }
+
+    users {
+      included_users = ["All"]
+      excluded_users = ["GuestsOrExternalUsers"]
+    }
+  }
+
+  session_controls {
+    continuous_access_evaluation_mode = "strictLocation"
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ConditionalAccessPolicyResource) sessionControlsSecureSignInSession(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[1]d"
+  state        = "disabled"
+
+  conditions {
+    client_app_types = ["mobileAppsAndDesktopClients"]
+
+    applications {
+      included_applications = [
+        "00000002-0000-0ff1-ce00-000000000000", # Office 365 Exchange Online
+        "00000003-0000-0ff1-ce00-000000000000", # Office 365 SharePoint Online
+      ]
+    }
+
+    platforms {
+      included_platforms = ["windows"]
+    }
+
+    users {
+      included_users = ["All"]
+      excluded_users = ["GuestsOrExternalUsers"]
+    }
+  }
+
+  session_controls {
+    secure_sign_in_session_enabled = true
+  }
+}
+`, data.RandomInteger)
+}
+
 func (ConditionalAccessPolicyResource) clientApplicationsIncluded(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azuread" {}
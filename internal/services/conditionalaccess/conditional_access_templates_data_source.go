@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesstemplate"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func conditionalAccessTemplatesDataSource() *pluginsdk.Resource {
+	policySchema := conditionalAccessPolicyResource().Schema
+
+	return &pluginsdk.Resource{
+		ReadContext: conditionalAccessTemplatesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"names": {
+				Description: "Only return templates with one of these names",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"scenarios": {
+				Description: "Only return templates associated with one of these scenarios",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForTemplateScenarios(), false),
+				},
+			},
+
+			"template_ids": {
+				Description: "The IDs of the conditional access templates",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"templates": {
+				Description: "A list of conditional access templates",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"conditions": conditionalAccessPolicyComputedSchema(policySchema["conditions"]),
+
+						"description": {
+							Description: "The description of the template",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"grant_controls": conditionalAccessPolicyComputedSchema(policySchema["grant_controls"]),
+
+						"name": {
+							Description: "The name of the template",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"scenarios": {
+							Description: "The scenarios which the template is associated with",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"session_controls": conditionalAccessPolicyComputedSchema(policySchema["session_controls"]),
+
+						"template_id": {
+							Description: "The ID of the template",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func conditionalAccessTemplatesDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.TemplateClient
+
+	names := tf.ExpandStringSlice(d.Get("names").([]interface{}))
+	scenarios := tf.ExpandStringSlice(d.Get("scenarios").([]interface{}))
+
+	resp, err := client.ListConditionalAccessTemplatesComplete(ctx, conditionalaccesstemplate.DefaultListConditionalAccessTemplatesOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving conditional access templates")
+	}
+	if resp.Items == nil {
+		return tf.ErrorDiagF(errors.New("API returned nil result"), "Retrieving conditional access templates")
+	}
+
+	templateIds := make([]string, 0)
+	templateList := make([]map[string]interface{}, 0)
+	for _, template := range resp.Items {
+		name := pointer.From(template.Name)
+		if len(names) > 0 && !slices.ContainsFunc(names, func(v string) bool { return strings.EqualFold(v, name) }) {
+			continue
+		}
+
+		templateScenarios := flattenConditionalAccessTemplateScenarios(template.Scenarios)
+		if len(scenarios) > 0 && !slices.ContainsFunc(templateScenarios, func(v string) bool { return slices.Contains(scenarios, v) }) {
+			continue
+		}
+
+		templateId := pointer.From(template.Id)
+		templateIds = append(templateIds, templateId)
+
+		details := pointer.From(template.Details)
+		templateList = append(templateList, map[string]interface{}{
+			"conditions":       flattenConditionalAccessConditionSet(details.Conditions, nil),
+			"description":      pointer.From(template.Description),
+			"grant_controls":   flattenConditionalAccessGrantControls(details.GrantControls),
+			"name":             name,
+			"scenarios":        templateScenarios,
+			"session_controls": flattenConditionalAccessSessionControls(details.SessionControls, conditionalAccessPolicyExtensions{}),
+			"template_id":      templateId,
+		})
+	}
+
+	// Generate a unique ID based on the filters and result
+	h := sha1.New()
+	if _, err = h.Write([]byte(strings.Join(names, "/") + "/" + strings.Join(scenarios, "/") + "/" + strings.Join(templateIds, "/"))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for conditional access templates")
+	}
+
+	d.SetId("conditionalAccessTemplates#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "template_ids", templateIds)
+	tf.Set(d, "templates", templateList)
+
+	return nil
+}
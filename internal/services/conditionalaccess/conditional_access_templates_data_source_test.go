@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ConditionalAccessTemplatesDataSource struct{}
+
+func TestAccConditionalAccessTemplatesDataSource_all(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_templates", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: ConditionalAccessTemplatesDataSource{}.all(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("template_ids.#").Exists(),
+				check.That(data.ResourceName).Key("templates.#").Exists(),
+				check.That(data.ResourceName).Key("templates.0.name").Exists(),
+				check.That(data.ResourceName).Key("templates.0.conditions.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAccConditionalAccessTemplatesDataSource_byName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_templates", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: ConditionalAccessTemplatesDataSource{}.byName(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("templates.#").HasValue("1"),
+				check.That(data.ResourceName).Key("templates.0.name").HasValue("Require multifactor authentication for admins"),
+				check.That(data.ResourceName).Key("templates.0.grant_controls.0.built_in_controls.0").HasValue("mfa"),
+			),
+		},
+	})
+}
+
+func TestAccConditionalAccessTemplatesDataSource_byScenario(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_templates", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: ConditionalAccessTemplatesDataSource{}.byScenario(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("templates.#").Exists(),
+				check.That(data.ResourceName).Key("templates.0.scenarios.#").Exists(),
+			),
+		},
+	})
+}
+
+func (ConditionalAccessTemplatesDataSource) all() string {
+	return `
+provider "azuread" {}
+
+data "azuread_conditional_access_templates" "test" {}
+`
+}
+
+func (ConditionalAccessTemplatesDataSource) byName() string {
+	return `
+provider "azuread" {}
+
+data "azuread_conditional_access_templates" "test" {
+  names = ["Require multifactor authentication for admins"]
+}
+`
+}
+
+func (ConditionalAccessTemplatesDataSource) byScenario() string {
+	return `
+provider "azuread" {}
+
+data "azuread_conditional_access_templates" "test" {
+  scenarios = ["protectAdmins"]
+}
+`
+}
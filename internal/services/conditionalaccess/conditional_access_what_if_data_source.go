@@ -0,0 +1,516 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func conditionalAccessWhatIfDataSource() *pluginsdk.Resource {
+	policySchema := conditionalAccessPolicyResource().Schema
+
+	// Conditions are optional for the resource only when seeded from a template
+	conditionsSchema := conditionalAccessWhatIfPolicySchema(policySchema["conditions"])
+	conditionsSchema.Optional = false
+	conditionsSchema.Required = true
+
+	return &pluginsdk.Resource{
+		ReadContext: conditionalAccessWhatIfDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"policy": {
+				Description: "A conditional access policy definition to evaluate",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"display_name": {
+							Description:  "The friendly name for this conditional access policy",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"conditions":       conditionsSchema,
+						"grant_controls":   conditionalAccessWhatIfPolicySchema(policySchema["grant_controls"]),
+						"session_controls": conditionalAccessWhatIfPolicySchema(policySchema["session_controls"]),
+					},
+				},
+				AtLeastOneOf: []string{"policy", "policy_ids"},
+			},
+
+			"policy_ids": {
+				Description: "The IDs of existing conditional access policies to evaluate",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: stable.ValidateIdentityConditionalAccessPolicyID,
+				},
+				AtLeastOneOf: []string{"policy", "policy_ids"},
+			},
+
+			"user_id": {
+				Description:  "The object ID of the user signing in",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"user_id", "service_principal_id"},
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"group_ids": {
+				Description: "The object IDs of groups which the user is a member of",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+
+			"role_ids": {
+				Description: "The template IDs of directory roles assigned to the user",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+
+			"guest_or_external_user_types": {
+				Description: "The guest or external user types of the user",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessGuestOrExternalUserTypes(), false),
+				},
+			},
+
+			"external_tenant_id": {
+				Description:  "The tenant ID of an external user",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"service_principal_id": {
+				Description:  "The object ID of the service principal signing in",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"user_id", "service_principal_id"},
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"application_id": {
+				Description:  "The client ID of the application being accessed",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"application_id", "authentication_context_class_references", "user_action"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"authentication_context_class_references": {
+				Description:  "The authentication contexts requested by the application being accessed",
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				ExactlyOneOf: []string{"application_id", "authentication_context_class_references", "user_action"},
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringMatch(authenticationContextIdRegex, "must be one of `c1` through `c99`"),
+				},
+			},
+
+			"user_action": {
+				Description:  "The user action being performed",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"application_id", "authentication_context_class_references", "user_action"},
+				ValidateFunc: validation.StringInSlice([]string{"urn:user:registerdevice", "urn:user:registersecurityinfo"}, false),
+			},
+
+			"authentication_flow": {
+				Description:  "The authentication flow used to sign in",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(possibleValuesForConditionalAccessTransferMethods(), false),
+			},
+
+			"client_app_type": {
+				Description:  "The type of client application used to sign in",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessClientApp(), false),
+			},
+
+			"platform": {
+				Description:  "The platform of the device used to sign in",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessDevicePlatform(), false),
+			},
+
+			"location_ids": {
+				Description: "The IDs of named locations which the sign-in originates from",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"trusted_location": {
+				Description: "Whether the sign-in originates from a trusted location",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"insider_risk_level": {
+				Description:  "The insider risk level of the user",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForConditionalAccessInsiderRiskLevels(), false),
+			},
+
+			"service_principal_risk_level": {
+				Description:  "The risk level of the service principal",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+			},
+
+			"sign_in_risk_level": {
+				Description:  "The risk level of the sign-in",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+			},
+
+			"user_risk_level": {
+				Description:  "The risk level of the user",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+			},
+
+			"policies": {
+				Description: "The policies which would apply to the sign-in",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"display_name": {
+							Description: "The friendly name of the policy",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"object_id": {
+							Description: "The object ID of the policy, for existing policies",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"state": {
+							Description: "The state of the policy, for existing policies",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"grant_controls":   conditionalAccessPolicyComputedSchema(policySchema["grant_controls"]),
+						"session_controls": conditionalAccessPolicyComputedSchema(policySchema["session_controls"]),
+
+						"unevaluated_conditions": {
+							Description: "Conditions of the policy which cannot be evaluated, and which are assumed to match the sign-in",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"blocked": {
+				Description: "Whether access would be blocked",
+				Type:        pluginsdk.TypeBool,
+				Computed:    true,
+			},
+
+			"built_in_controls": {
+				Description: "The built-in controls referenced by the applicable policies",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"authentication_strength_policy_ids": {
+				Description: "The IDs of authentication strength policies referenced by the applicable policies",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"terms_of_use": {
+				Description: "The IDs of terms of use referenced by the applicable policies",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"grant_requirements": {
+				Description: "The grant controls of each applicable policy, all of which must be satisfied",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"display_name": {
+							Description: "The friendly name of the policy",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"operator": {
+							Description: "Whether all of the controls (`AND`) or any one of the controls (`OR`) must be satisfied",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"built_in_controls": {
+							Description: "The built-in controls of the policy",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"authentication_strength_policy_ids": {
+							Description: "The IDs of authentication strength policies of the policy",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"terms_of_use": {
+							Description: "The IDs of terms of use of the policy",
+							Type:        pluginsdk.TypeList,
+							Computed:    true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"session_controls": conditionalAccessPolicyComputedSchema(policySchema["session_controls"]),
+		},
+	}
+}
+
+// conditionalAccessWhatIfPolicySchema copies a conditional access policy resource schema for use in a nested block,
+// removing any references to other attributes which are only valid at the root of the resource schema
+func conditionalAccessWhatIfPolicySchema(in *pluginsdk.Schema) *pluginsdk.Schema {
+	result := *in
+	result.AtLeastOneOf = nil
+	result.Computed = false
+	result.ConflictsWith = nil
+	result.DiffSuppressFunc = nil
+	result.ExactlyOneOf = nil
+	result.RequiredWith = nil
+
+	if elem, ok := in.Elem.(*pluginsdk.Resource); ok {
+		nested := make(map[string]*pluginsdk.Schema, len(elem.Schema))
+		for k, v := range elem.Schema {
+			nested[k] = conditionalAccessWhatIfPolicySchema(v)
+		}
+		result.Elem = &pluginsdk.Resource{Schema: nested}
+	}
+
+	return &result
+}
+
+// conditionalAccessPolicyComputedSchema copies a conditional access policy resource schema for use as a computed attribute
+func conditionalAccessPolicyComputedSchema(in *pluginsdk.Schema) *pluginsdk.Schema {
+	result := &pluginsdk.Schema{
+		Type:     in.Type,
+		Computed: true,
+	}
+
+	switch elem := in.Elem.(type) {
+	case *pluginsdk.Resource:
+		nested := make(map[string]*pluginsdk.Schema, len(elem.Schema))
+		for k, v := range elem.Schema {
+			nested[k] = conditionalAccessPolicyComputedSchema(v)
+		}
+		result.Elem = &pluginsdk.Resource{Schema: nested}
+	case *pluginsdk.Schema:
+		result.Elem = &pluginsdk.Schema{Type: elem.Type}
+	}
+
+	return result
+}
+
+func conditionalAccessWhatIfDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.PolicyClient
+
+	policies := make([]conditionalAccessEvaluationPolicy, 0)
+
+	for i, raw := range d.Get("policy").([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		config := raw.(map[string]interface{})
+
+		grantControls, err := expandConditionalAccessGrantControls(config["grant_controls"].([]interface{}))
+		if err != nil {
+			return tf.ErrorDiagPathF(err, fmt.Sprintf("policy.%d.grant_controls", i), "Parsing `grant_controls`")
+		}
+
+		conditions := config["conditions"].([]interface{})
+		sessionControls := config["session_controls"].([]interface{})
+
+		extensions := conditionalAccessPolicyExtensions{}
+		if len(conditions) > 0 && conditions[0] != nil {
+			extensions.AuthenticationFlows = expandConditionalAccessAuthenticationFlows(conditions[0].(map[string]interface{})["authentication_flows"].([]interface{}))
+		}
+		extensions.ContinuousAccessEvaluation, extensions.SecureSignInSession = expandConditionalAccessSessionControlExtensions(sessionControls)
+
+		var expandedSessionControls *stable.ConditionalAccessSessionControls
+		if len(sessionControls) > 0 {
+			expandedSessionControls = expandConditionalAccessSessionControls(sessionControls)
+		}
+
+		policies = append(policies, conditionalAccessEvaluationPolicy{
+			Policy: stable.ConditionalAccessPolicy{
+				DisplayName:     pointer.To(config["display_name"].(string)),
+				Conditions:      expandConditionalAccessConditionSet(conditions),
+				GrantControls:   grantControls,
+				SessionControls: expandedSessionControls,
+			},
+			Extensions: extensions,
+		})
+	}
+
+	for i, v := range d.Get("policy_ids").([]interface{}) {
+		id, err := stable.ParseIdentityConditionalAccessPolicyID(v.(string))
+		if err != nil {
+			return tf.ErrorDiagPathF(err, fmt.Sprintf("policy_ids.%d", i), "Parsing conditional access policy ID")
+		}
+
+		policy, extensions, _, err := getConditionalAccessPolicy(ctx, client, *id)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, fmt.Sprintf("policy_ids.%d", i), "Retrieving %s", id)
+		}
+		if policy == nil {
+			return tf.ErrorDiagPathF(errors.New("model was nil"), fmt.Sprintf("policy_ids.%d", i), "Retrieving %s", id)
+		}
+
+		policies = append(policies, conditionalAccessEvaluationPolicy{
+			Policy:     *policy,
+			Extensions: *extensions,
+		})
+	}
+
+	scenario := conditionalAccessSignInScenario{
+		UserId:                               d.Get("user_id").(string),
+		GroupIds:                             tf.ExpandStringSlice(d.Get("group_ids").([]interface{})),
+		RoleIds:                              tf.ExpandStringSlice(d.Get("role_ids").([]interface{})),
+		GuestOrExternalUserTypes:             tf.ExpandStringSlice(d.Get("guest_or_external_user_types").([]interface{})),
+		ExternalTenantId:                     d.Get("external_tenant_id").(string),
+		ServicePrincipalId:                   d.Get("service_principal_id").(string),
+		ApplicationId:                        d.Get("application_id").(string),
+		UserAction:                           d.Get("user_action").(string),
+		AuthenticationContextClassReferences: tf.ExpandStringSlice(d.Get("authentication_context_class_references").([]interface{})),
+		AuthenticationFlow:                   d.Get("authentication_flow").(string),
+		ClientAppType:                        d.Get("client_app_type").(string),
+		Platform:                             d.Get("platform").(string),
+		LocationIds:                          tf.ExpandStringSlice(d.Get("location_ids").([]interface{})),
+		TrustedLocation:                      d.Get("trusted_location").(bool),
+		InsiderRiskLevel:                     d.Get("insider_risk_level").(string),
+		ServicePrincipalRiskLevel:            d.Get("service_principal_risk_level").(string),
+		SignInRiskLevel:                      d.Get("sign_in_risk_level").(string),
+		UserRiskLevel:                        d.Get("user_risk_level").(string),
+	}
+
+	results := evaluateConditionalAccessPolicies(scenario, policies)
+	controls := combineConditionalAccessControls(results)
+
+	names := make([]string, 0, len(results))
+	policyList := make([]map[string]interface{}, 0, len(results))
+	for _, result := range results {
+		policy := result.Policy.Policy
+		names = append(names, pointer.From(policy.DisplayName))
+
+		policyList = append(policyList, map[string]interface{}{
+			"display_name":           pointer.From(policy.DisplayName),
+			"object_id":              pointer.From(policy.Id),
+			"state":                  string(pointer.From(policy.State)),
+			"grant_controls":         flattenConditionalAccessGrantControls(policy.GrantControls),
+			"session_controls":       flattenConditionalAccessSessionControls(policy.SessionControls, result.Policy.Extensions),
+			"unevaluated_conditions": result.UnevaluatedConditions,
+		})
+	}
+
+	// Generate a unique ID based on the applicable policies
+	h := sha1.New()
+	if _, err := h.Write([]byte(fmt.Sprintf("%+v/%s", scenario, strings.Join(names, "/")))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for conditional access evaluation")
+	}
+
+	d.SetId("conditionalAccessWhatIf#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "policies", policyList)
+	tf.Set(d, "blocked", controls.Blocked)
+	tf.Set(d, "built_in_controls", controls.BuiltInControls)
+	tf.Set(d, "authentication_strength_policy_ids", controls.AuthenticationStrengthPolicyIds)
+	tf.Set(d, "terms_of_use", controls.TermsOfUse)
+	tf.Set(d, "grant_requirements", flattenConditionalAccessGrantRequirements(controls.GrantRequirements))
+	tf.Set(d, "session_controls", flattenConditionalAccessSessionControls(controls.SessionControls, controls.SessionControlsExtensions))
+
+	return nil
+}
+
+func flattenConditionalAccessGrantRequirements(in []conditionalAccessGrantRequirement) []interface{} {
+	result := make([]interface{}, 0, len(in))
+	for _, requirement := range in {
+		result = append(result, map[string]interface{}{
+			"display_name":                       requirement.DisplayName,
+			"operator":                           requirement.Operator,
+			"built_in_controls":                  requirement.BuiltInControls,
+			"authentication_strength_policy_ids": requirement.AuthenticationStrengthPolicyIds,
+			"terms_of_use":                       requirement.TermsOfUse,
+		})
+	}
+	return result
+}
@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ConditionalAccessWhatIfDataSource struct{}
+
+func TestAccConditionalAccessWhatIfDataSource_policy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_what_if", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: ConditionalAccessWhatIfDataSource{}.policy(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("policies.#").HasValue("1"),
+				check.That(data.ResourceName).Key("policies.0.display_name").HasValue(fmt.Sprintf("acctest-CONPOLICY-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("blocked").HasValue("false"),
+				check.That(data.ResourceName).Key("built_in_controls.#").HasValue("1"),
+				check.That(data.ResourceName).Key("built_in_controls.0").HasValue("mfa"),
+			),
+		},
+	})
+}
+
+func TestAccConditionalAccessWhatIfDataSource_breakGlass(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_what_if", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: ConditionalAccessWhatIfDataSource{}.breakGlass(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("policies.#").HasValue("0"),
+				check.That(data.ResourceName).Key("built_in_controls.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAccConditionalAccessWhatIfDataSource_policyIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_what_if", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: ConditionalAccessWhatIfDataSource{}.policyIds(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("policies.#").HasValue("1"),
+				check.That(data.ResourceName).Key("policies.0.object_id").Exists(),
+				check.That(data.ResourceName).Key("policies.0.state").HasValue("disabled"),
+				check.That(data.ResourceName).Key("session_controls.0.persistent_browser_mode").HasValue("never"),
+				check.That(data.ResourceName).Key("session_controls.0.sign_in_frequency").HasValue("10"),
+			),
+		},
+	})
+}
+
+func (ConditionalAccessWhatIfDataSource) policy(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_client_config" "current" {}
+
+data "azuread_conditional_access_what_if" "test" {
+  user_id         = data.azuread_client_config.current.object_id
+  application_id  = "00000003-0000-0000-c000-000000000000"
+  client_app_type = "browser"
+  platform        = "windows"
+
+  policy {
+    display_name = "acctest-CONPOLICY-%[1]d"
+
+    conditions {
+      client_app_types = ["all"]
+
+      applications {
+        included_applications = ["All"]
+      }
+
+      users {
+        included_users = ["All"]
+      }
+    }
+
+    grant_controls {
+      operator          = "OR"
+      built_in_controls = ["mfa"]
+    }
+  }
+
+  policy {
+    display_name = "acctest-CONPOLICY-legacy-%[1]d"
+
+    conditions {
+      client_app_types = ["exchangeActiveSync", "other"]
+
+      applications {
+        included_applications = ["All"]
+      }
+
+      users {
+        included_users = ["All"]
+      }
+    }
+
+    grant_controls {
+      operator          = "OR"
+      built_in_controls = ["block"]
+    }
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ConditionalAccessWhatIfDataSource) breakGlass(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_client_config" "current" {}
+
+data "azuread_conditional_access_what_if" "test" {
+  user_id         = data.azuread_client_config.current.object_id
+  application_id  = "00000003-0000-0000-c000-000000000000"
+  client_app_type = "browser"
+
+  policy {
+    display_name = "acctest-CONPOLICY-%[1]d"
+
+    conditions {
+      client_app_types = ["all"]
+
+      applications {
+        included_applications = ["All"]
+      }
+
+      users {
+        included_users = ["All"]
+        excluded_users = [data.azuread_client_config.current.object_id]
+      }
+    }
+
+    grant_controls {
+      operator          = "OR"
+      built_in_controls = ["block"]
+    }
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ConditionalAccessWhatIfDataSource) policyIds(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_client_config" "current" {}
+
+data "azuread_conditional_access_what_if" "test" {
+  user_id         = data.azuread_client_config.current.object_id
+  application_id  = "00000003-0000-0000-c000-000000000000"
+  client_app_type = "browser"
+  platform        = "linux"
+
+  policy_ids = [azuread_conditional_access_policy.test.id]
+}
+`, ConditionalAccessPolicyResource{}.sessionControls(data))
+}
@@ -4,15 +4,18 @@
 package conditionalaccess
 
 import (
+	"net/netip"
 	"strings"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
 	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
 )
 
-func flattenConditionalAccessConditionSet(in *stable.ConditionalAccessConditionSet) []interface{} {
+func flattenConditionalAccessConditionSet(in *stable.ConditionalAccessConditionSet, authenticationFlows *conditionalAccessAuthenticationFlows) []interface{} {
 	if in == nil {
 		return []interface{}{}
 	}
@@ -37,15 +40,25 @@ func flattenConditionalAccessConditionSet(in *stable.ConditionalAccessConditionS
 		userRiskLevels = append(userRiskLevels, string(v))
 	}
 
+	// Insider risk levels are a flags enum, serialized as a comma-separated list
+	insiderRiskLevels := make([]string, 0)
+	for _, v := range strings.Split(string(pointer.From(in.InsiderRiskLevels)), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			insiderRiskLevels = append(insiderRiskLevels, v)
+		}
+	}
+
 	return []interface{}{
 		map[string]interface{}{
 			"applications":                  flattenConditionalAccessApplications(in.Applications),
+			"authentication_flows":          flattenConditionalAccessAuthenticationFlows(authenticationFlows),
 			"client_applications":           flattenConditionalAccessClientApplications(in.ClientApplications),
 			"users":                         flattenConditionalAccessUsers(in.Users),
 			"client_app_types":              clientAppTypes,
 			"devices":                       flattenConditionalAccessDevices(in.Devices),
 			"locations":                     flattenConditionalAccessLocations(in.Locations),
 			"platforms":                     flattenConditionalAccessPlatforms(in.Platforms),
+			"insider_risk_levels":           insiderRiskLevels,
 			"service_principal_risk_levels": servicePrincipalRiskLevels,
 			"sign_in_risk_levels":           signInRiskLevels,
 			"user_risk_levels":              userRiskLevels,
@@ -53,12 +66,36 @@ func flattenConditionalAccessConditionSet(in *stable.ConditionalAccessConditionS
 	}
 }
 
+// expandConditionalAccessInsiderRiskLevels joins the specified insider risk levels into the comma-separated
+// representation of the flags enum, in the order they are defined by the API
+func expandConditionalAccessInsiderRiskLevels(in []interface{}) *stable.ConditionalAccessInsiderRiskLevels {
+	configured := make(map[string]bool)
+	for _, v := range in {
+		configured[v.(string)] = true
+	}
+
+	insiderRiskLevels := make([]string, 0)
+	for _, v := range stable.PossibleValuesForConditionalAccessInsiderRiskLevels() {
+		if configured[v] {
+			insiderRiskLevels = append(insiderRiskLevels, v)
+		}
+	}
+
+	if len(insiderRiskLevels) == 0 {
+		return nil
+	}
+
+	return pointer.To(stable.ConditionalAccessInsiderRiskLevels(strings.Join(insiderRiskLevels, ",")))
+}
+
 func flattenConditionalAccessApplications(in stable.ConditionalAccessApplications) []interface{} {
 	return []interface{}{
 		map[string]interface{}{
-			"included_applications": tf.FlattenStringSlicePtr(in.IncludeApplications),
-			"excluded_applications": tf.FlattenStringSlicePtr(in.ExcludeApplications),
-			"included_user_actions": tf.FlattenStringSlicePtr(in.IncludeUserActions),
+			"included_applications":                            tf.FlattenStringSlicePtr(in.IncludeApplications),
+			"excluded_applications":                            tf.FlattenStringSlicePtr(in.ExcludeApplications),
+			"included_user_actions":                            tf.FlattenStringSlicePtr(in.IncludeUserActions),
+			"included_authentication_context_class_references": tf.FlattenStringSlicePtr(in.IncludeAuthenticationContextClassReferences),
+			"filter": flattenConditionalAccessFilter(in.ApplicationFilter),
 		},
 	}
 }
@@ -102,7 +139,7 @@ func flattenConditionalAccessDevices(in *stable.ConditionalAccessDevices) []inte
 
 	return []interface{}{
 		map[string]interface{}{
-			"filter": flattenConditionalAccessDeviceFilter(in.DeviceFilter),
+			"filter": flattenConditionalAccessFilter(in.DeviceFilter),
 		},
 	}
 }
@@ -169,11 +206,21 @@ func flattenConditionalAccessGrantControls(in *stable.ConditionalAccessGrantCont
 	}
 }
 
-func flattenConditionalAccessSessionControls(in *stable.ConditionalAccessSessionControls) []interface{} {
+func flattenConditionalAccessSessionControls(in *stable.ConditionalAccessSessionControls, extensions conditionalAccessPolicyExtensions) []interface{} {
 	if in == nil {
 		return []interface{}{}
 	}
 
+	continuousAccessEvaluationMode := ""
+	if v := extensions.ContinuousAccessEvaluation.Get(); v != nil {
+		continuousAccessEvaluationMode = pointer.From(v.Mode)
+	}
+
+	secureSignInSessionEnabled := false
+	if v := extensions.SecureSignInSession.Get(); v != nil {
+		secureSignInSessionEnabled = pointer.From(v.IsEnabled)
+	}
+
 	applicationEnforceRestrictions := false
 	if in.ApplicationEnforcedRestrictions != nil {
 		applicationEnforceRestrictions = in.ApplicationEnforcedRestrictions.IsEnabled.GetOrZero()
@@ -212,8 +259,10 @@ func flattenConditionalAccessSessionControls(in *stable.ConditionalAccessSession
 		map[string]interface{}{
 			"application_enforced_restrictions_enabled": applicationEnforceRestrictions,
 			"cloud_app_security_policy":                 cloudAppSecurity,
+			"continuous_access_evaluation_mode":         continuousAccessEvaluationMode,
 			"disable_resilience_defaults":               disableResilienceDefaults,
 			"persistent_browser_mode":                   persistentBrowserMode,
+			"secure_sign_in_session_enabled":            secureSignInSessionEnabled,
 			"sign_in_frequency":                         signInFrequency,
 			"sign_in_frequency_authentication_type":     signInFrequencyAuthenticationType,
 			"sign_in_frequency_interval":                signInFrequencyInterval,
@@ -222,7 +271,7 @@ func flattenConditionalAccessSessionControls(in *stable.ConditionalAccessSession
 	}
 }
 
-func flattenConditionalAccessDeviceFilter(in *stable.ConditionalAccessFilter) []interface{} {
+func flattenConditionalAccessFilter(in *stable.ConditionalAccessFilter) []interface{} {
 	if in == nil {
 		return []interface{}{}
 	}
@@ -278,14 +327,33 @@ func flattenCountryNamedLocation(in *stable.CountryNamedLocation) []interface{}
 		includeUnknown = *in.IncludeUnknownCountriesAndRegions
 	}
 
+	countryLookupMethod := string(stable.CountryLookupMethodType_ClientIPAddress)
+	if in.CountryLookupMethod != nil {
+		countryLookupMethod = string(*in.CountryLookupMethod)
+	}
+
 	return []interface{}{
 		map[string]interface{}{
 			"countries_and_regions":                 tf.FlattenStringSlice(in.CountriesAndRegions),
+			"country_lookup_method":                 countryLookupMethod,
 			"include_unknown_countries_and_regions": includeUnknown,
 		},
 	}
 }
 
+func flattenCompliantNetworkNamedLocation(in *beta.CompliantNetworkNamedLocation) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"compliant_network_type": string(pointer.From(in.CompliantNetworkType)),
+			"trusted":                pointer.From(in.IsTrusted),
+		},
+	}
+}
+
 func flattenIPNamedLocation(in *stable.IPNamedLocation) []interface{} {
 	if in == nil {
 		return []interface{}{}
@@ -326,6 +394,22 @@ func flattenIPNamedLocationIPRange(in []stable.IPRange) []interface{} {
 	return tf.FlattenStringSlice(result)
 }
 
+// flattenConditionalAccessTemplateScenarios splits the flagged enum of template scenarios into its individual values
+func flattenConditionalAccessTemplateScenarios(in *stable.TemplateScenarios) []string {
+	result := make([]string, 0)
+	if in == nil {
+		return result
+	}
+
+	for _, v := range strings.Split(string(*in), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
 func expandConditionalAccessConditionSet(in []interface{}) *stable.ConditionalAccessConditionSet {
 	if len(in) == 0 || in[0] == nil {
 		return nil
@@ -365,6 +449,7 @@ func expandConditionalAccessConditionSet(in []interface{}) *stable.ConditionalAc
 	result.ClientAppTypes = clientAppTypes
 	result.ClientApplications = expandConditionalAccessClientApplications(clientApplications)
 	result.Devices = expandConditionalAccessDevices(devices)
+	result.InsiderRiskLevels = expandConditionalAccessInsiderRiskLevels(config["insider_risk_levels"].(*pluginsdk.Set).List())
 	result.Locations = expandConditionalAccessLocations(locations)
 	result.Platforms = expandConditionalAccessPlatforms(platforms)
 	result.ServicePrincipalRiskLevels = &servicePrincipalRiskLevels
@@ -399,10 +484,16 @@ func expandConditionalAccessApplications(in []interface{}) stable.ConditionalAcc
 	includeApplications := config["included_applications"].([]interface{})
 	excludeApplications := config["excluded_applications"].([]interface{})
 	includeUserActions := config["included_user_actions"].([]interface{})
+	includeAuthenticationContextClassReferences := config["included_authentication_context_class_references"].([]interface{})
 
 	result.IncludeApplications = tf.ExpandStringSlicePtr(includeApplications)
 	result.ExcludeApplications = tf.ExpandStringSlicePtr(excludeApplications)
 	result.IncludeUserActions = tf.ExpandStringSlicePtr(includeUserActions)
+	result.IncludeAuthenticationContextClassReferences = tf.ExpandStringSlicePtr(includeAuthenticationContextClassReferences)
+
+	if filter := config["filter"].([]interface{}); len(filter) > 0 {
+		result.ApplicationFilter = expandConditionalAccessFilter(filter)
+	}
 
 	return result
 }
@@ -518,7 +609,12 @@ func expandConditionalAccessGrantControls(in []interface{}) (*stable.Conditional
 	result.BuiltInControls = &builtInControls
 	result.CustomAuthenticationFactors = tf.ExpandStringSlicePtr(config["custom_authentication_factors"].([]interface{}))
 	result.Operator = nullable.Value(config["operator"].(string))
-	result.TermsOfUse = tf.ExpandStringSlicePtr(config["terms_of_use"].([]interface{}))
+
+	termsOfUse := make([]string, 0)
+	for _, elem := range config["terms_of_use"].([]interface{}) {
+		termsOfUse = append(termsOfUse, termsOfUseAgreementObjectId(elem.(string)))
+	}
+	result.TermsOfUse = &termsOfUse
 
 	return &result, nil
 }
@@ -589,6 +685,28 @@ func expandConditionalAccessSessionControls(in []interface{}) *stable.Conditiona
 	return &result
 }
 
+func expandConditionalAccessSessionControlExtensions(in []interface{}) (continuousAccessEvaluation nullable.Type[conditionalAccessContinuousAccessEvaluationSessionControl], secureSignInSession nullable.Type[conditionalAccessSecureSignInSessionControl]) {
+	if len(in) == 0 || in[0] == nil {
+		return
+	}
+
+	config := in[0].(map[string]interface{})
+
+	if mode := config["continuous_access_evaluation_mode"].(string); mode != "" {
+		continuousAccessEvaluation = nullable.Value(conditionalAccessContinuousAccessEvaluationSessionControl{
+			Mode: pointer.To(mode),
+		})
+	}
+
+	if config["secure_sign_in_session_enabled"].(bool) {
+		secureSignInSession = nullable.Value(conditionalAccessSecureSignInSessionControl{
+			IsEnabled: pointer.To(true),
+		})
+	}
+
+	return
+}
+
 func expandConditionalAccessFilter(in []interface{}) *stable.ConditionalAccessFilter {
 	result := stable.ConditionalAccessFilter{}
 
@@ -659,6 +777,10 @@ func expandCountryNamedLocation(in []interface{}) *stable.CountryNamedLocation {
 	result.CountriesAndRegions = tf.ExpandStringSlice(countriesAndRegions)
 	result.IncludeUnknownCountriesAndRegions = pointer.To(includeUnknown.(bool))
 
+	if v, ok := config["country_lookup_method"]; ok && v.(string) != "" {
+		result.CountryLookupMethod = pointer.To(stable.CountryLookupMethodType(v.(string)))
+	}
+
 	return &result
 }
 
@@ -681,11 +803,31 @@ func expandIPNamedLocation(in []interface{}) *stable.IPNamedLocation {
 
 func expandIPNamedLocationIPRange(in []interface{}) []stable.IPRange {
 	result := make([]stable.IPRange, 0)
-	for _, cidr := range in {
+	for _, raw := range in {
+		cidr := canonicalNamedLocationIPRange(raw.(string))
+		if prefix, err := netip.ParsePrefix(cidr); err == nil && prefix.Addr().Is6() {
+			result = append(result, stable.IPv6CIDRRange{
+				CIDRAddress: pointer.To(cidr),
+			})
+			continue
+		}
 		result = append(result, stable.IPv4CIDRRange{
-			CIDRAddress: pointer.To(cidr.(string)),
+			CIDRAddress: pointer.To(cidr),
 		})
 	}
 
 	return result
 }
+
+func expandCompliantNetworkNamedLocation(in []interface{}) *beta.CompliantNetworkNamedLocation {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	config := in[0].(map[string]interface{})
+
+	return &beta.CompliantNetworkNamedLocation{
+		CompliantNetworkType: pointer.To(beta.CompliantNetworkType(config["compliant_network_type"].(string))),
+		IsTrusted:            pointer.To(config["trusted"].(bool)),
+	}
+}
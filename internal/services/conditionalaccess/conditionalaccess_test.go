@@ -0,0 +1,749 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestConditionalAccessConditionSetRoundTrip(t *testing.T) {
+	cases := []struct {
+		TestName string
+		Config   map[string]interface{}
+	}{
+		{
+			TestName: "Basic",
+			Config: map[string]interface{}{
+				"client_app_types": []interface{}{"all"},
+				"applications": []interface{}{
+					map[string]interface{}{
+						"included_applications": []interface{}{"All"},
+					},
+				},
+				"users": []interface{}{
+					map[string]interface{}{
+						"included_users": []interface{}{"All"},
+					},
+				},
+			},
+		},
+		{
+			TestName: "InsiderRiskLevels",
+			Config: map[string]interface{}{
+				"client_app_types":    []interface{}{"browser", "mobileAppsAndDesktopClients"},
+				"insider_risk_levels": []interface{}{"elevated"},
+				"sign_in_risk_levels": []interface{}{"medium"},
+				"applications": []interface{}{
+					map[string]interface{}{
+						"included_applications": []interface{}{"All"},
+					},
+				},
+				"users": []interface{}{
+					map[string]interface{}{
+						"included_users": []interface{}{"All"},
+						"excluded_users": []interface{}{"GuestsOrExternalUsers"},
+					},
+				},
+			},
+		},
+		{
+			TestName: "ApplicationFilter",
+			Config: map[string]interface{}{
+				"client_app_types": []interface{}{"all"},
+				"applications": []interface{}{
+					map[string]interface{}{
+						"included_applications": []interface{}{"All"},
+						"filter": []interface{}{
+							map[string]interface{}{
+								"mode": "exclude",
+								"rule": `CustomSecurityAttribute.Engineering.Project -eq "Baker"`,
+							},
+						},
+					},
+				},
+				"devices": []interface{}{
+					map[string]interface{}{
+						"filter": []interface{}{
+							map[string]interface{}{
+								"mode": "include",
+								"rule": `device.trustType -eq "ServerAD"`,
+							},
+						},
+					},
+				},
+				"users": []interface{}{
+					map[string]interface{}{
+						"included_users": []interface{}{"All"},
+					},
+				},
+			},
+		},
+		{
+			TestName: "AuthenticationFlows",
+			Config: map[string]interface{}{
+				"client_app_types": []interface{}{"all"},
+				"applications": []interface{}{
+					map[string]interface{}{
+						"included_applications": []interface{}{"All"},
+					},
+				},
+				"authentication_flows": []interface{}{
+					map[string]interface{}{
+						"transfer_methods": []interface{}{"deviceCodeFlow", "authenticationTransfer"},
+					},
+				},
+				"users": []interface{}{
+					map[string]interface{}{
+						"included_users": []interface{}{"All"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, conditionalAccessPolicyResource().Schema, map[string]interface{}{
+				"conditions": []interface{}{tc.Config},
+			})
+
+			config := d.Get("conditions").([]interface{})
+			expected := normalizeSets(config)
+
+			conditions := expandConditionalAccessConditionSet(config)
+			authenticationFlows := expandConditionalAccessAuthenticationFlows(d.Get("conditions.0.authentication_flows").([]interface{}))
+
+			if err := d.Set("conditions", flattenConditionalAccessConditionSet(conditions, authenticationFlows)); err != nil {
+				t.Fatalf("setting flattened conditions: %+v", err)
+			}
+
+			if actual := normalizeSets(d.Get("conditions")); !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("conditions did not round trip\nexpected: %#v\nactual:   %#v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestConditionalAccessApplicationsRoundTrip(t *testing.T) {
+	cases := []struct {
+		TestName string
+		Input    stable.ConditionalAccessApplications
+	}{
+		{
+			TestName: "WithoutFilter",
+			Input: stable.ConditionalAccessApplications{
+				IncludeApplications:                         &[]string{"All"},
+				ExcludeApplications:                         &[]string{"00000002-0000-0ff1-ce00-000000000000"},
+				IncludeUserActions:                          &[]string{},
+				IncludeAuthenticationContextClassReferences: &[]string{},
+			},
+		},
+		{
+			TestName: "WithFilter",
+			Input: stable.ConditionalAccessApplications{
+				IncludeApplications:                         &[]string{"All"},
+				ExcludeApplications:                         &[]string{},
+				IncludeUserActions:                          &[]string{},
+				IncludeAuthenticationContextClassReferences: &[]string{},
+				ApplicationFilter: &stable.ConditionalAccessFilter{
+					Mode: pointer.To(stable.FilterMode_Include),
+					Rule: pointer.To(`CustomSecurityAttribute.Engineering.Project -eq "Baker"`),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			flattened := flattenConditionalAccessApplications(tc.Input)
+
+			// Nested blocks are expanded from their schema representation
+			config := flattened[0].(map[string]interface{})
+			if filter := config["filter"].([]interface{}); len(filter) > 0 {
+				f := filter[0].(map[string]interface{})
+				config["filter"] = []interface{}{
+					map[string]interface{}{
+						"mode": string(*f["mode"].(*stable.FilterMode)),
+						"rule": *f["rule"].(*string),
+					},
+				}
+			}
+
+			if actual := expandConditionalAccessApplications(flattened); !reflect.DeepEqual(tc.Input, actual) {
+				t.Fatalf("applications did not round trip\nexpected: %#v\nactual:   %#v", tc.Input, actual)
+			}
+		})
+	}
+}
+
+func TestConditionalAccessAuthenticationFlowsRoundTrip(t *testing.T) {
+	cases := []struct {
+		TestName        string
+		TransferMethods []interface{}
+		Expected        *conditionalAccessAuthenticationFlows
+	}{
+		{
+			TestName:        "Single",
+			TransferMethods: []interface{}{"deviceCodeFlow"},
+			Expected:        &conditionalAccessAuthenticationFlows{TransferMethods: pointer.To("deviceCodeFlow")},
+		},
+		{
+			TestName:        "Multiple",
+			TransferMethods: []interface{}{"deviceCodeFlow", "authenticationTransfer"},
+			Expected:        &conditionalAccessAuthenticationFlows{TransferMethods: pointer.To("authenticationTransfer,deviceCodeFlow")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			input := []interface{}{
+				map[string]interface{}{
+					"transfer_methods": schema.NewSet(schema.HashString, tc.TransferMethods),
+				},
+			}
+
+			expanded := expandConditionalAccessAuthenticationFlows(input)
+			if !reflect.DeepEqual(tc.Expected, expanded) {
+				t.Fatalf("unexpected expanded value\nexpected: %#v\nactual:   %#v", tc.Expected, expanded)
+			}
+
+			flattened := flattenConditionalAccessAuthenticationFlows(expanded)
+			if len(flattened) != 1 {
+				t.Fatalf("expected 1 flattened block, got %d", len(flattened))
+			}
+			transferMethods := schema.NewSet(schema.HashString, flattened[0].(map[string]interface{})["transfer_methods"].([]interface{}))
+			if !transferMethods.Equal(input[0].(map[string]interface{})["transfer_methods"]) {
+				t.Fatalf("transfer methods did not round trip, got %v", transferMethods.List())
+			}
+		})
+	}
+
+	t.Run("None", func(t *testing.T) {
+		if flattened := flattenConditionalAccessAuthenticationFlows(&conditionalAccessAuthenticationFlows{TransferMethods: pointer.To(conditionalAccessTransferMethodNone)}); len(flattened) != 0 {
+			t.Fatalf("expected no flattened blocks for %q, got %v", conditionalAccessTransferMethodNone, flattened)
+		}
+		if expanded := expandConditionalAccessAuthenticationFlows([]interface{}{}); expanded != nil {
+			t.Fatalf("expected nil when block is absent, got %#v", expanded)
+		}
+	})
+}
+
+func TestConditionalAccessSessionControlsRoundTrip(t *testing.T) {
+	cases := []struct {
+		TestName string
+		Config   map[string]interface{}
+	}{
+		{
+			TestName: "ContinuousAccessEvaluation",
+			Config: map[string]interface{}{
+				"continuous_access_evaluation_mode": "strictLocation",
+			},
+		},
+		{
+			TestName: "SecureSignInSession",
+			Config: map[string]interface{}{
+				"secure_sign_in_session_enabled": true,
+				"sign_in_frequency":              4,
+				"sign_in_frequency_period":       "hours",
+			},
+		},
+		{
+			TestName: "ResilienceDefaults",
+			Config: map[string]interface{}{
+				"continuous_access_evaluation_mode": "strictEnforcement",
+				"disable_resilience_defaults":       true,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, conditionalAccessPolicyResource().Schema, map[string]interface{}{
+				"session_controls": []interface{}{tc.Config},
+			})
+
+			config := d.Get("session_controls").([]interface{})
+			expected := normalizeSets(config)
+
+			sessionControls := expandConditionalAccessSessionControls(config)
+			if sessionControls == nil {
+				sessionControls = &stable.ConditionalAccessSessionControls{}
+			}
+
+			extensions := conditionalAccessPolicyExtensions{}
+			extensions.ContinuousAccessEvaluation, extensions.SecureSignInSession = expandConditionalAccessSessionControlExtensions(config)
+
+			flattened := flattenConditionalAccessSessionControls(sessionControls, extensions)
+
+			// Computed properties are populated by the API and are not part of the configuration
+			flattened[0].(map[string]interface{})["sign_in_frequency_authentication_type"] = ""
+			flattened[0].(map[string]interface{})["sign_in_frequency_interval"] = ""
+
+			if err := d.Set("session_controls", flattened); err != nil {
+				t.Fatalf("setting flattened session controls: %+v", err)
+			}
+
+			if actual := normalizeSets(d.Get("session_controls")); !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("session controls did not round trip\nexpected: %#v\nactual:   %#v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestMarshalConditionalAccessPolicy(t *testing.T) {
+	cases := []struct {
+		TestName                string
+		Policy                  stable.ConditionalAccessPolicy
+		Extensions              func() conditionalAccessPolicyExtensions
+		ExpectedSessionControls interface{}
+	}{
+		{
+			TestName: "AddedToNullSessionControls",
+			Extensions: func() conditionalAccessPolicyExtensions {
+				return conditionalAccessPolicyExtensions{
+					ContinuousAccessEvaluation: nullable.Value(conditionalAccessContinuousAccessEvaluationSessionControl{
+						Mode: pointer.To("strictEnforcement"),
+					}),
+				}
+			},
+			ExpectedSessionControls: map[string]interface{}{
+				"continuousAccessEvaluation": map[string]interface{}{
+					"mode": "strictEnforcement",
+				},
+			},
+		},
+		{
+			TestName: "RemovedFromSessionControls",
+			Policy: stable.ConditionalAccessPolicy{
+				SessionControls: &stable.ConditionalAccessSessionControls{
+					DisableResilienceDefaults: nullable.Value(true),
+				},
+			},
+			Extensions: func() (result conditionalAccessPolicyExtensions) {
+				result.SecureSignInSession.SetNull()
+				return
+			},
+			ExpectedSessionControls: map[string]interface{}{
+				"cloudAppSecurity":          nil,
+				"disableResilienceDefaults": true,
+				"secureSignInSession":       nil,
+			},
+		},
+		{
+			TestName: "RemovedWithNullSessionControls",
+			Extensions: func() (result conditionalAccessPolicyExtensions) {
+				result.ContinuousAccessEvaluation.SetNull()
+				return
+			},
+			ExpectedSessionControls: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			result, err := marshalConditionalAccessPolicy(tc.Policy, tc.Extensions())
+			if err != nil {
+				t.Fatalf("marshaling policy: %+v", err)
+			}
+
+			// Round trip through JSON to compare the serialized representation
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("encoding result: %+v", err)
+			}
+			var decoded map[string]interface{}
+			if err = json.Unmarshal(encoded, &decoded); err != nil {
+				t.Fatalf("decoding result: %+v", err)
+			}
+
+			if actual := decoded["sessionControls"]; !reflect.DeepEqual(tc.ExpectedSessionControls, actual) {
+				t.Fatalf("unexpected session controls\nexpected: %#v\nactual:   %#v", tc.ExpectedSessionControls, actual)
+			}
+		})
+	}
+}
+
+func TestMarshalConditionalAccessPolicyRemovedConditions(t *testing.T) {
+	policy := stable.ConditionalAccessPolicy{
+		Conditions: &stable.ConditionalAccessConditionSet{
+			Applications: stable.ConditionalAccessApplications{
+				IncludeApplications: &[]string{"All"},
+			},
+		},
+	}
+	extensions := conditionalAccessPolicyExtensions{
+		RemoveApplicationFilter: true,
+		RemoveInsiderRiskLevels: true,
+	}
+	if extensions.isEmpty() {
+		t.Fatalf("expected extensions removing conditions to be non-empty")
+	}
+
+	result, err := marshalConditionalAccessPolicy(policy, extensions)
+	if err != nil {
+		t.Fatalf("marshaling policy: %+v", err)
+	}
+
+	conditions := result["conditions"].(map[string]interface{})
+	if v, ok := conditions["insiderRiskLevels"]; !ok || v != nil {
+		t.Fatalf("expected insiderRiskLevels to be explicitly null, got: %#v", v)
+	}
+
+	applications := conditions["applications"].(map[string]interface{})
+	if v, ok := applications["applicationFilter"]; !ok || v != nil {
+		t.Fatalf("expected applicationFilter to be explicitly null, got: %#v", v)
+	}
+	if !reflect.DeepEqual(applications["includeApplications"], []interface{}{"All"}) {
+		t.Fatalf("expected includeApplications to be retained, got: %#v", applications["includeApplications"])
+	}
+}
+
+func TestMissingConditionalAccessExclusions(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Excluded []string
+		Required []string
+		Expected []string
+	}{
+		{
+			Name:     "nothing required",
+			Excluded: []string{"GuestsOrExternalUsers"},
+			Required: []string{},
+			Expected: []string{},
+		},
+		{
+			Name:     "all excluded",
+			Excluded: []string{"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"},
+			Required: []string{"22222222-2222-2222-2222-222222222222"},
+			Expected: []string{},
+		},
+		{
+			Name:     "case insensitive",
+			Excluded: []string{"AAAAAAAA-AAAA-AAAA-AAAA-AAAAAAAAAAAA"},
+			Required: []string{"aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"},
+			Expected: []string{},
+		},
+		{
+			Name:     "some missing",
+			Excluded: []string{"11111111-1111-1111-1111-111111111111"},
+			Required: []string{"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222", "33333333-3333-3333-3333-333333333333"},
+			Expected: []string{"22222222-2222-2222-2222-222222222222", "33333333-3333-3333-3333-333333333333"},
+		},
+		{
+			Name:     "none excluded",
+			Excluded: nil,
+			Required: []string{"11111111-1111-1111-1111-111111111111"},
+			Expected: []string{"11111111-1111-1111-1111-111111111111"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if actual := missingConditionalAccessExclusions(tc.Excluded, tc.Required); !reflect.DeepEqual(tc.Expected, actual) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestFlattenConditionalAccessTemplateScenarios(t *testing.T) {
+	testCases := []struct {
+		Input    *stable.TemplateScenarios
+		Expected []string
+	}{
+		{
+			Input:    nil,
+			Expected: []string{},
+		},
+		{
+			Input:    pointer.To(stable.TemplateScenarios_ProtectAdmins),
+			Expected: []string{"protectAdmins"},
+		},
+		{
+			Input:    pointer.To(stable.TemplateScenarios("secureFoundation, zeroTrust,remoteWork")),
+			Expected: []string{"secureFoundation", "zeroTrust", "remoteWork"},
+		},
+	}
+
+	for _, tc := range testCases {
+		if actual := flattenConditionalAccessTemplateScenarios(tc.Input); !reflect.DeepEqual(tc.Expected, actual) {
+			t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+		}
+	}
+}
+
+func TestTermsOfUseAgreementFilesReplaced(t *testing.T) {
+	file := func(language, fileName, content string, isDefault bool) interface{} {
+		return map[string]interface{}{
+			"content_base64": content,
+			"content_sha256": "",
+			"default":        isDefault,
+			"file_name":      fileName,
+			"language":       language,
+			"path":           "",
+		}
+	}
+
+	testCases := []struct {
+		Name     string
+		Old      []interface{}
+		New      []interface{}
+		Expected bool
+	}{
+		{
+			Name:     "unchanged",
+			Old:      []interface{}{file("en-US", "terms.pdf", "Zm9v", true)},
+			New:      []interface{}{file("en-US", "terms.pdf", "Zm9v", true)},
+			Expected: false,
+		},
+		{
+			Name:     "file added",
+			Old:      []interface{}{file("en-US", "terms.pdf", "Zm9v", true)},
+			New:      []interface{}{file("de-DE", "bedingungen.pdf", "YmFy", false), file("en-us", "terms.pdf", "Zm9v", true)},
+			Expected: false,
+		},
+		{
+			Name:     "file removed",
+			Old:      []interface{}{file("en-US", "terms.pdf", "Zm9v", true), file("de-DE", "bedingungen.pdf", "YmFy", false)},
+			New:      []interface{}{file("en-US", "terms.pdf", "Zm9v", true)},
+			Expected: true,
+		},
+		{
+			Name:     "content changed",
+			Old:      []interface{}{file("en-US", "terms.pdf", "Zm9v", true)},
+			New:      []interface{}{file("en-US", "terms.pdf", "YmFy", true)},
+			Expected: true,
+		},
+		{
+			Name:     "imported",
+			Old:      []interface{}{file("en-US", "terms.pdf", "", true)},
+			New:      []interface{}{file("en-US", "terms.pdf", "Zm9v", true)},
+			Expected: false,
+		},
+		{
+			Name:     "default changed",
+			Old:      []interface{}{file("en-US", "terms.pdf", "Zm9v", true)},
+			New:      []interface{}{file("en-US", "terms.pdf", "Zm9v", false)},
+			Expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if actual := termsOfUseAgreementFilesReplaced(tc.Old, tc.New); actual != tc.Expected {
+				t.Fatalf("expected %t, got %t", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestTermsOfUseAgreementObjectId(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "11111111-1111-1111-1111-111111111111",
+			Expected: "11111111-1111-1111-1111-111111111111",
+		},
+		{
+			Input:    "/identityGovernance/termsOfUse/agreements/11111111-1111-1111-1111-111111111111",
+			Expected: "11111111-1111-1111-1111-111111111111",
+		},
+	}
+
+	for _, tc := range testCases {
+		if actual := termsOfUseAgreementObjectId(tc.Input); actual != tc.Expected {
+			t.Fatalf("expected %q, got %q", tc.Expected, actual)
+		}
+	}
+}
+
+func TestCanonicalNamedLocationIPRange(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "10.0.0.0/8",
+			Expected: "10.0.0.0/8",
+		},
+		{
+			Input:    "10.0.0.1/8",
+			Expected: "10.0.0.0/8",
+		},
+		{
+			Input:    "2001:0DB8:0000:0000:0000:0000:0000:0000/32",
+			Expected: "2001:db8::/32",
+		},
+		{
+			Input:    "2001:db8::1/64",
+			Expected: "2001:db8::/64",
+		},
+		{
+			Input:    "not-a-cidr",
+			Expected: "not-a-cidr",
+		},
+	}
+
+	for _, tc := range testCases {
+		if actual := canonicalNamedLocationIPRange(tc.Input); actual != tc.Expected {
+			t.Fatalf("expected %q for %q, got %q", tc.Expected, tc.Input, actual)
+		}
+	}
+}
+
+func TestExpandIPNamedLocationIPRange(t *testing.T) {
+	actual := expandIPNamedLocationIPRange([]interface{}{"192.168.1.10/24", "2001:DB8::/48"})
+	expected := []stable.IPRange{
+		stable.IPv4CIDRRange{CIDRAddress: pointer.To("192.168.1.0/24")},
+		stable.IPv6CIDRRange{CIDRAddress: pointer.To("2001:db8::/48")},
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+}
+
+func TestOverlappingNamedLocationIPRanges(t *testing.T) {
+	others := map[string][]string{
+		"Head Office":   {"10.0.0.0/16", "2001:db8::/48"},
+		"Branch Office": {"192.168.0.0/24"},
+	}
+
+	testCases := []struct {
+		Name     string
+		Ranges   []string
+		Expected []string
+	}{
+		{
+			Name:     "no overlap",
+			Ranges:   []string{"10.1.0.0/16", "192.168.1.0/24", "2001:db9::/48"},
+			Expected: []string{},
+		},
+		{
+			Name:     "contained",
+			Ranges:   []string{"10.0.1.0/24"},
+			Expected: []string{`10.0.1.0/24 overlaps 10.0.0.0/16 in "Head Office"`},
+		},
+		{
+			Name:     "containing",
+			Ranges:   []string{"192.168.0.0/16"},
+			Expected: []string{`192.168.0.0/16 overlaps 192.168.0.0/24 in "Branch Office"`},
+		},
+		{
+			Name:     "ipv6",
+			Ranges:   []string{"2001:db8:0:1::/64"},
+			Expected: []string{`2001:db8:0:1::/64 overlaps 2001:db8::/48 in "Head Office"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if actual := overlappingNamedLocationIPRanges(tc.Ranges, others); !reflect.DeepEqual(tc.Expected, actual) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestNamedLocationManagedOverlaps(t *testing.T) {
+	if actual := namedLocationManagedOverlaps("test-head-office", "Head Office", true, []string{"10.0.0.0/16"}); len(actual) != 0 {
+		t.Fatalf("expected no overlaps for the first named location, got %#v", actual)
+	}
+
+	if actual := namedLocationManagedOverlaps("test-untrusted", "Untrusted", false, []string{"10.0.1.0/24"}); len(actual) != 0 {
+		t.Fatalf("expected no overlaps for an untrusted named location, got %#v", actual)
+	}
+
+	expected := []string{`10.0.1.0/24 overlaps 10.0.0.0/16 in "Head Office"`}
+	if actual := namedLocationManagedOverlaps("test-branch-office", "Branch Office", true, []string{"10.0.1.0/24"}); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+
+	// Planning the same named location again should not compare it with itself
+	expected = []string{`10.0.0.0/16 overlaps 10.0.1.0/24 in "Branch Office"`}
+	if actual := namedLocationManagedOverlaps("test-head-office", "Head Office", true, []string{"10.0.0.0/16"}); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+
+	// A named location which is no longer trusted is no longer compared
+	namedLocationManagedOverlaps("test-branch-office", "Branch Office", false, []string{"10.0.1.0/24"})
+	if actual := namedLocationManagedOverlaps("test-head-office", "Head Office", true, []string{"10.0.0.0/16"}); len(actual) != 0 {
+		t.Fatalf("expected no overlaps, got %#v", actual)
+	}
+}
+
+func TestConditionalAccessPolicyReferencedObjectIds(t *testing.T) {
+	policy := stable.ConditionalAccessPolicy{
+		Conditions: &stable.ConditionalAccessConditionSet{
+			Applications: stable.ConditionalAccessApplications{
+				IncludeApplications: &[]string{"All"},
+				ExcludeApplications: &[]string{"00000003-0000-0000-c000-000000000000"},
+			},
+			ClientApplications: &stable.ConditionalAccessClientApplications{
+				ExcludeServicePrincipals: &[]string{"11111111-1111-1111-1111-111111111111"},
+			},
+			Locations: &stable.ConditionalAccessLocations{
+				IncludeLocations: &[]string{"All"},
+				ExcludeLocations: &[]string{"22222222-2222-2222-2222-222222222222"},
+			},
+			Users: &stable.ConditionalAccessUsers{
+				IncludeUsers:  &[]string{"All"},
+				ExcludeGroups: &[]string{"33333333-3333-3333-3333-333333333333"},
+			},
+		},
+		GrantControls: &stable.ConditionalAccessGrantControls{
+			TermsOfUse: &[]string{"44444444-4444-4444-4444-444444444444"},
+		},
+	}
+
+	expected := []string{
+		"All",
+		"33333333-3333-3333-3333-333333333333",
+		"All",
+		"00000003-0000-0000-c000-000000000000",
+		"11111111-1111-1111-1111-111111111111",
+		"All",
+		"22222222-2222-2222-2222-222222222222",
+		"44444444-4444-4444-4444-444444444444",
+	}
+
+	if actual := conditionalAccessPolicyReferencedObjectIds(policy); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+
+	if actual := conditionalAccessReferencedObjectId("/identity/conditionalAccess/namedLocations/22222222-2222-2222-2222-222222222222"); actual != "22222222-2222-2222-2222-222222222222" {
+		t.Fatalf("expected object ID from resource ID, got %q", actual)
+	}
+}
+
+// normalizeSets replaces any sets with their sorted elements, so that schema values can be compared
+func normalizeSets(in interface{}) interface{} {
+	switch v := in.(type) {
+	case *schema.Set:
+		return normalizeSets(v.List())
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			result = append(result, normalizeSets(item))
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = normalizeSets(item)
+		}
+		return result
+	}
+
+	return in
+}
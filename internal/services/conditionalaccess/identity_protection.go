@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// The SDK does not provide clients for the Identity Protection API, so these mirror the equivalent generated list
+// operations for other entity collections
+
+type identityProtectionListOptions struct {
+	Filter string
+}
+
+func (o identityProtectionListOptions) ToHeaders() *client.Headers {
+	return &client.Headers{}
+}
+
+func (o identityProtectionListOptions) ToOData() *odata.Query {
+	return &odata.Query{
+		Filter: o.Filter,
+	}
+}
+
+func (o identityProtectionListOptions) ToQuery() *client.QueryParams {
+	return &client.QueryParams{}
+}
+
+type identityProtectionListPager struct {
+	NextLink *odata.Link `json:"@odata.nextLink"`
+}
+
+func (p *identityProtectionListPager) NextPageLink() *odata.Link {
+	defer func() {
+		p.NextLink = nil
+	}()
+
+	return p.NextLink
+}
+
+func listIdentityProtection(ctx context.Context, c *msgraph.Client, path, filter string) ([]json.RawMessage, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod:    http.MethodGet,
+		OptionsObject: identityProtectionListOptions{Filter: filter},
+		Pager:         &identityProtectionListPager{},
+		Path:          path,
+	}
+
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := req.ExecutePaged(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var values struct {
+		Values *[]json.RawMessage `json:"value"`
+	}
+	if err = resp.Unmarshal(&values); err != nil {
+		return nil, err
+	}
+
+	if values.Values == nil {
+		return []json.RawMessage{}, nil
+	}
+
+	return *values.Values, nil
+}
+
+func listRiskyUsers(ctx context.Context, c *msgraph.Client, filter string) ([]stable.BaseRiskyUserImpl, error) {
+	values, err := listIdentityProtection(ctx, c, "/identityProtection/riskyUsers", filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing risky users: %+v", err)
+	}
+
+	result := make([]stable.BaseRiskyUserImpl, 0, len(values))
+	for i, value := range values {
+		riskyUser, err := stable.UnmarshalRiskyUserImplementation(value)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshaling risky user %d: %+v", i, err)
+		}
+		if riskyUser == nil {
+			continue
+		}
+		result = append(result, riskyUser.RiskyUser())
+	}
+
+	return result, nil
+}
+
+func listRiskDetections(ctx context.Context, c *msgraph.Client, filter string) ([]stable.RiskDetection, error) {
+	values, err := listIdentityProtection(ctx, c, "/identityProtection/riskDetections", filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing risk detections: %+v", err)
+	}
+
+	result := make([]stable.RiskDetection, 0, len(values))
+	for i, value := range values {
+		var riskDetection stable.RiskDetection
+		if err = json.Unmarshal(value, &riskDetection); err != nil {
+			return nil, fmt.Errorf("unmarshaling risk detection %d: %+v", i, err)
+		}
+		result = append(result, riskDetection)
+	}
+
+	return result, nil
+}
+
+// identityProtectionFilter combines the non-empty filter clauses, all of which must match
+func identityProtectionFilter(clauses ...string) string {
+	result := make([]string, 0, len(clauses))
+	for _, clause := range clauses {
+		if clause != "" {
+			result = append(result, clause)
+		}
+	}
+
+	return strings.Join(result, " and ")
+}
+
+// identityProtectionFilterAnyOf builds a filter clause matching any of the specified values for a property
+func identityProtectionFilterAnyOf(property string, values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	clauses := make([]string, 0, len(values))
+	for _, v := range values {
+		clauses = append(clauses, fmt.Sprintf("%s eq '%s'", property, odata.EscapeSingleQuote(v)))
+	}
+
+	if len(clauses) == 1 {
+		return clauses[0]
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(clauses, " or "))
+}
@@ -0,0 +1,226 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+)
+
+// canonicalNamedLocationIPRange returns the canonical form of a CIDR range, with any host bits cleared and IPv6
+// addresses compressed, e.g. `10.0.0.1/8` becomes `10.0.0.0/8`. Values which cannot be parsed are returned unchanged.
+func canonicalNamedLocationIPRange(in string) string {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(in))
+	if err != nil {
+		return in
+	}
+	return prefix.Masked().String()
+}
+
+func namedLocationIPRangeDiffSuppress(_, old, new string, _ *pluginsdk.ResourceData) bool {
+	return canonicalNamedLocationIPRange(old) == canonicalNamedLocationIPRange(new)
+}
+
+// namedLocationIPRangesEqual compares two lists of CIDR ranges in their canonical forms
+func namedLocationIPRangesEqual(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if canonicalNamedLocationIPRange(a[i].(string)) != canonicalNamedLocationIPRange(b[i].(string)) {
+			return false
+		}
+	}
+	return true
+}
+
+// overlappingNamedLocationIPRanges returns a description of each range in `ranges` which overlaps a range in any of
+// the `others` named locations, which are keyed by display name
+func overlappingNamedLocationIPRanges(ranges []string, others map[string][]string) []string {
+	names := make([]string, 0, len(others))
+	for name := range others {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]string, 0)
+	for _, r := range ranges {
+		prefix, err := netip.ParsePrefix(r)
+		if err != nil {
+			continue
+		}
+
+		for _, name := range names {
+			for _, other := range others[name] {
+				otherPrefix, err := netip.ParsePrefix(other)
+				if err != nil {
+					continue
+				}
+				if prefix.Overlaps(otherPrefix) {
+					result = append(result, fmt.Sprintf("%s overlaps %s in %q", prefix.Masked(), otherPrefix.Masked(), name))
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+type namedLocationPlannedRanges struct {
+	DisplayName string
+	IPRanges    []string
+}
+
+// namedLocationPlannedTrustedRanges records the planned IP ranges of each trusted IP named location which is managed by
+// the azuread_named_location resource, so that overlapping ranges can be detected when planning. Named locations are
+// keyed by object ID, or by display name when they have not yet been created. Every managed named location is planned
+// in each Terraform run, which starts a new provider process, so an overlap is detected by whichever of the two named
+// locations is planned later.
+var namedLocationPlannedTrustedRanges = struct {
+	sync.Mutex
+	m map[string]namedLocationPlannedRanges
+}{
+	m: make(map[string]namedLocationPlannedRanges),
+}
+
+// namedLocationManagedOverlaps records the planned IP ranges of a managed named location, and returns a description of
+// each of those ranges which overlaps a range of another managed trusted IP named location. Ranges are only recorded
+// for trusted named locations.
+func namedLocationManagedOverlaps(key, displayName string, trusted bool, ranges []string) []string {
+	namedLocationPlannedTrustedRanges.Lock()
+	defer namedLocationPlannedTrustedRanges.Unlock()
+
+	if !trusted {
+		delete(namedLocationPlannedTrustedRanges.m, key)
+		return nil
+	}
+
+	namedLocationPlannedTrustedRanges.m[key] = namedLocationPlannedRanges{
+		DisplayName: displayName,
+		IPRanges:    ranges,
+	}
+
+	others := make(map[string][]string)
+	for k, v := range namedLocationPlannedTrustedRanges.m {
+		if k != key {
+			others[v.DisplayName] = append(others[v.DisplayName], v.IPRanges...)
+		}
+	}
+
+	return overlappingNamedLocationIPRanges(ranges, others)
+}
+
+// The SDK does not support compliant network named locations with the Stable API, so the following functions use the
+// Beta API to manage them
+
+func createCompliantNetworkNamedLocation(ctx context.Context, c *msgraph.Client, namedLocation beta.CompliantNetworkNamedLocation) (*beta.CompliantNetworkNamedLocation, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusCreated,
+		},
+		HttpMethod: http.MethodPost,
+		Path:       "/identity/conditionalAccess/namedLocations",
+	}
+
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = req.Marshal(namedLocation); err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var model beta.CompliantNetworkNamedLocation
+	if err = resp.Unmarshal(&model); err != nil {
+		return nil, err
+	}
+
+	return &model, nil
+}
+
+func updateCompliantNetworkNamedLocation(ctx context.Context, c *msgraph.Client, id stable.IdentityConditionalAccessNamedLocationId, namedLocation beta.CompliantNetworkNamedLocation) error {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPatch,
+		Path:       id.ID(),
+	}
+
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if err = req.Marshal(namedLocation); err != nil {
+		return err
+	}
+
+	_, err = req.Execute(ctx)
+	return err
+}
+
+// getCompliantNetworkNamedLocation retrieves a named location using the Beta API, returning nil when the named location
+// is not a compliant network named location
+func getCompliantNetworkNamedLocation(ctx context.Context, c *msgraph.Client, id stable.IdentityConditionalAccessNamedLocationId) (*beta.CompliantNetworkNamedLocation, *http.Response, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       id.ID(),
+	}
+
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := req.Execute(ctx)
+	var httpResponse *http.Response
+	if resp != nil {
+		httpResponse = resp.Response
+	}
+	if err != nil {
+		return nil, httpResponse, err
+	}
+
+	var raw json.RawMessage
+	if err = resp.Unmarshal(&raw); err != nil {
+		return nil, httpResponse, err
+	}
+
+	namedLocation, err := beta.UnmarshalNamedLocationImplementation(raw)
+	if err != nil {
+		return nil, httpResponse, err
+	}
+
+	if model, ok := namedLocation.(beta.CompliantNetworkNamedLocation); ok {
+		return &model, httpResponse, nil
+	}
+
+	return nil, httpResponse, nil
+}
@@ -69,6 +69,11 @@ func namedLocationDataSource() *pluginsdk.Resource {
 							},
 						},
 
+						"country_lookup_method": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
 						"include_unknown_countries_and_regions": {
 							Type:     pluginsdk.TypeBool,
 							Computed: true,
@@ -76,6 +81,24 @@ func namedLocationDataSource() *pluginsdk.Resource {
 					},
 				},
 			},
+
+			"compliant_network": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"compliant_network_type": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"trusted": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -130,6 +153,19 @@ func namedLocationDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData,
 	}
 
 	id := stable.NewIdentityConditionalAccessNamedLocationID(pointer.From(item.NamedLocation().Id))
+
+	if _, ok := item.(stable.RawNamedLocationImpl); ok {
+		// Compliant network named locations are only returned by the Beta API
+		namedLocation, _, err := getCompliantNetworkNamedLocation(ctx, meta.(*clients.Client).ConditionalAccess.NamedLocationClientBeta, id)
+		if err != nil {
+			return tf.ErrorDiagF(err, "Retrieving %s", id)
+		}
+		if namedLocation != nil {
+			tf.Set(d, "display_name", pointer.From(namedLocation.DisplayName))
+			tf.Set(d, "compliant_network", flattenCompliantNetworkNamedLocation(namedLocation))
+		}
+	}
+
 	d.SetId(id.ID())
 
 	return nil
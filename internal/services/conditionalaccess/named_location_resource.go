@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/beta"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccessnamedlocation"
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
@@ -30,6 +32,8 @@ func namedLocationResource() *pluginsdk.Resource {
 		UpdateContext: namedLocationResourceUpdate,
 		DeleteContext: namedLocationResourceDelete,
 
+		CustomizeDiff: namedLocationResourceCustomizeDiff,
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -69,15 +73,16 @@ func namedLocationResource() *pluginsdk.Resource {
 				Optional:     true,
 				ForceNew:     true,
 				MaxItems:     1,
-				ExactlyOneOf: []string{"ip", "country"},
+				ExactlyOneOf: []string{"ip", "country", "compliant_network"},
 				Elem: &pluginsdk.Resource{
 					Schema: map[string]*pluginsdk.Schema{
 						"ip_ranges": {
 							Type:     pluginsdk.TypeList,
 							Required: true,
 							Elem: &pluginsdk.Schema{
-								Type:         pluginsdk.TypeString,
-								ValidateFunc: validation.PrefixLengthAtLeast(8),
+								Type:             pluginsdk.TypeString,
+								ValidateFunc:     validation.PrefixLengthAtLeast(8),
+								DiffSuppressFunc: namedLocationIPRangeDiffSuppress,
 							},
 						},
 
@@ -94,7 +99,7 @@ func namedLocationResource() *pluginsdk.Resource {
 				Optional:     true,
 				ForceNew:     true,
 				MaxItems:     1,
-				ExactlyOneOf: []string{"ip", "country"},
+				ExactlyOneOf: []string{"ip", "country", "compliant_network"},
 				Elem: &pluginsdk.Resource{
 					Schema: map[string]*pluginsdk.Schema{
 						"countries_and_regions": {
@@ -106,6 +111,13 @@ func namedLocationResource() *pluginsdk.Resource {
 							},
 						},
 
+						"country_lookup_method": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      string(stable.CountryLookupMethodType_ClientIPAddress),
+							ValidateFunc: validation.StringInSlice(stable.PossibleValuesForCountryLookupMethodType(), false),
+						},
+
 						"include_unknown_countries_and_regions": {
 							Type:     pluginsdk.TypeBool,
 							Optional: true,
@@ -113,10 +125,68 @@ func namedLocationResource() *pluginsdk.Resource {
 					},
 				},
 			},
+
+			"compliant_network": {
+				Type:         pluginsdk.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"ip", "country", "compliant_network"},
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"compliant_network_type": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Default:      string(beta.CompliantNetworkType_AllTenantCompliantNetworks),
+							ValidateFunc: validation.StringInSlice(beta.PossibleValuesForCompliantNetworkType(), false),
+						},
+
+						"trusted": {
+							Type:     pluginsdk.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+// namedLocationResourceCustomizeDiff checks the planned IP ranges of a trusted named location for overlaps with other
+// trusted named locations managed by this resource. The plugin SDK does not support warnings when planning, so overlaps
+// are logged, unless the provider is configured to reject them, in which case planning fails.
+func namedLocationResourceCustomizeDiff(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+	if diff.Get("ip.#").(int) == 0 || !diff.NewValueKnown("ip.0.ip_ranges") || !diff.NewValueKnown("ip.0.trusted") {
+		return nil
+	}
+
+	key := diff.Id()
+	if key == "" {
+		if !diff.NewValueKnown("display_name") {
+			return nil
+		}
+		key = diff.Get("display_name").(string)
+	}
+
+	ranges := tf.ExpandStringSlice(diff.Get("ip.0.ip_ranges").([]interface{}))
+	for i, r := range ranges {
+		ranges[i] = canonicalNamedLocationIPRange(r)
+	}
+
+	overlaps := namedLocationManagedOverlaps(key, diff.Get("display_name").(string), diff.Get("ip.0.trusted").(bool), ranges)
+	if len(overlaps) == 0 {
+		return nil
+	}
+
+	if meta.(*clients.Client).Features.ConditionalAccess.RejectOverlappingTrustedIPRanges {
+		return fmt.Errorf("the IP ranges of trusted named location %q overlap those of other trusted named locations: %s", diff.Get("display_name").(string), strings.Join(overlaps, "; "))
+	}
+
+	log.Printf("[WARN] The IP ranges of trusted named location %q overlap those of other trusted named locations: %s", diff.Get("display_name").(string), strings.Join(overlaps, "; "))
+
+	return nil
+}
+
 func namedLocationResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).ConditionalAccess.NamedLocationClient
 
@@ -170,8 +240,24 @@ func namedLocationResourceCreate(ctx context.Context, d *pluginsdk.ResourceData,
 		id := stable.NewIdentityConditionalAccessNamedLocationID(*namedLocation.Id)
 		d.SetId(id.ID())
 
+	} else if v, ok = d.GetOk("compliant_network"); ok {
+		properties := expandCompliantNetworkNamedLocation(v.([]interface{}))
+		properties.DisplayName = pointer.To(d.Get("display_name").(string))
+
+		namedLocation, err := createCompliantNetworkNamedLocation(ctx, meta.(*clients.Client).ConditionalAccess.NamedLocationClientBeta, *properties)
+		if err != nil {
+			return tf.ErrorDiagF(err, "Could not create named location")
+		}
+
+		if namedLocation.Id == nil {
+			return tf.ErrorDiagF(errors.New("nil/empty object ID returned for named location"), "Bad API response")
+		}
+
+		id := stable.NewIdentityConditionalAccessNamedLocationID(*namedLocation.Id)
+		d.SetId(id.ID())
+
 	} else {
-		return tf.ErrorDiagF(errors.New("one of `ip` or `country` must be specified"), "Unable to determine named location type")
+		return tf.ErrorDiagF(errors.New("one of `ip`, `country` or `compliant_network` must be specified"), "Unable to determine named location type")
 	}
 
 	return namedLocationResourceRead(ctx, d, meta)
@@ -215,7 +301,7 @@ func namedLocationResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData,
 				location := locationRaw[0].(map[string]interface{})
 				ip := v.([]interface{})[0].(map[string]interface{})
 
-				if !reflect.DeepEqual(location["ip_ranges"], ip["ip_ranges"]) {
+				if !namedLocationIPRangesEqual(location["ip_ranges"].([]interface{}), ip["ip_ranges"].([]interface{})) {
 					return pointer.To(false), nil
 				}
 
@@ -266,12 +352,43 @@ func namedLocationResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData,
 				if location["include_unknown_countries_and_regions"].(bool) != ip["include_unknown_countries_and_regions"].(bool) {
 					return pointer.To(false), nil
 				}
+
+				if location["country_lookup_method"].(string) != ip["country_lookup_method"].(string) {
+					return pointer.To(false), nil
+				}
 			}
 
 			return pointer.To(true), nil
 		}); err != nil {
 			return tf.ErrorDiagF(err, "waiting for update of %s", id)
 		}
+
+	} else if v, ok := d.GetOk("compliant_network"); ok {
+		betaClient := meta.(*clients.Client).ConditionalAccess.NamedLocationClientBeta
+		properties := expandCompliantNetworkNamedLocation(v.([]interface{}))
+
+		if d.HasChange("display_name") {
+			properties.DisplayName = pointer.To(d.Get("display_name").(string))
+		}
+
+		if err := updateCompliantNetworkNamedLocation(ctx, betaClient, *id, *properties); err != nil {
+			return tf.ErrorDiagF(err, "Updating %s", id)
+		}
+
+		if err := consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+			namedLocation, _, err := getCompliantNetworkNamedLocation(ctx, betaClient, *id)
+			if err != nil {
+				return nil, err
+			}
+
+			if namedLocation == nil {
+				return nil, errors.New("returned model was not a CompliantNetworkNamedLocation")
+			}
+
+			return pointer.To(pointer.From(namedLocation.IsTrusted) == pointer.From(properties.IsTrusted)), nil
+		}); err != nil {
+			return tf.ErrorDiagF(err, "waiting for update of %s", id)
+		}
 	}
 
 	return namedLocationResourceRead(ctx, d, meta)
@@ -285,6 +402,10 @@ func namedLocationResourceRead(ctx context.Context, d *pluginsdk.ResourceData, m
 		return tf.ErrorDiagPathF(err, "id", "Parsing Named Location ID")
 	}
 
+	if len(d.Get("compliant_network").([]interface{})) > 0 {
+		return compliantNetworkNamedLocationResourceRead(ctx, d, meta, *id)
+	}
+
 	resp, err := client.GetConditionalAccessNamedLocation(ctx, *id, conditionalaccessnamedlocation.DefaultGetConditionalAccessNamedLocationOperationOptions())
 	if err != nil {
 		if response.WasNotFound(resp.HttpResponse) {
@@ -314,11 +435,38 @@ func namedLocationResourceRead(ctx context.Context, d *pluginsdk.ResourceData, m
 
 		tf.Set(d, "display_name", pointer.From(namedLocation.DisplayName))
 		tf.Set(d, "country", flattenCountryNamedLocation(&namedLocation))
+
+	default:
+		// Compliant network named locations are only returned by the Beta API
+		return compliantNetworkNamedLocationResourceRead(ctx, d, meta, *id)
 	}
 
 	return nil
 }
 
+func compliantNetworkNamedLocationResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, id stable.IdentityConditionalAccessNamedLocationId) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.NamedLocationClientBeta
+
+	namedLocation, httpResponse, err := getCompliantNetworkNamedLocation(ctx, client, id)
+	if err != nil {
+		if response.WasNotFound(httpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	if namedLocation == nil {
+		return tf.ErrorDiagF(errors.New("returned model was not a CompliantNetworkNamedLocation"), "Bad API response")
+	}
+
+	tf.Set(d, "display_name", pointer.From(namedLocation.DisplayName))
+	tf.Set(d, "compliant_network", flattenCompliantNetworkNamedLocation(namedLocation))
+
+	return nil
+}
+
 func namedLocationResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).ConditionalAccess.NamedLocationClient
 
@@ -341,6 +489,15 @@ func namedLocationResourceDelete(ctx context.Context, d *pluginsdk.ResourceData,
 		}
 	}
 
+	if v, ok := d.GetOk("compliant_network"); ok {
+		properties := expandCompliantNetworkNamedLocation(v.([]interface{}))
+		properties.IsTrusted = pointer.To(false)
+
+		if err := updateCompliantNetworkNamedLocation(ctx, meta.(*clients.Client).ConditionalAccess.NamedLocationClientBeta, *id, *properties); err != nil {
+			return tf.ErrorDiagF(err, "updating %s prior to deletion", id)
+		}
+	}
+
 	resp, err := client.DeleteConditionalAccessNamedLocation(ctx, *id, conditionalaccessnamedlocation.DefaultDeleteConditionalAccessNamedLocationOperationOptions())
 	if err != nil {
 		if response.WasNotFound(resp.HttpResponse) {
@@ -138,6 +138,62 @@ func TestAccNamedLocation_updateCountry(t *testing.T) {
 	})
 }
 
+func TestAccNamedLocation_nonCanonicalIP(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
+	r := NamedLocationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.nonCanonicalIP(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("ip.0.ip_ranges.0").HasValue("10.0.0.0/8"),
+				check.That(data.ResourceName).Key("ip.0.ip_ranges.1").HasValue("2001:db8::/48"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccNamedLocation_countryLookupMethod(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
+	r := NamedLocationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basicCountry(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("country.0.country_lookup_method").HasValue("clientIpAddress"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.countryAuthenticatorAppGps(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("country.0.country_lookup_method").HasValue("authenticatorAppGps"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccNamedLocation_compliantNetwork(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
+	r := NamedLocationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.compliantNetwork(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("compliant_network.0.compliant_network_type").HasValue("allTenantCompliantNetworks"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (r NamedLocationResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.ConditionalAccess.NamedLocationClient
 
@@ -217,3 +273,43 @@ resource "azuread_named_location" "test" {
 }
 `, data.RandomInteger)
 }
+
+func (NamedLocationResource) nonCanonicalIP(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_named_location" "test" {
+  display_name = "acctestNLIP-%[1]d"
+  ip {
+    ip_ranges = [
+      "10.0.0.1/8",
+      "2001:0DB8:0000::1/48",
+    ]
+  }
+}
+`, data.RandomInteger)
+}
+
+func (NamedLocationResource) countryAuthenticatorAppGps(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_named_location" "test" {
+  display_name = "acctestNLC-%[1]d"
+  country {
+    countries_and_regions = [
+      "GB",
+      "US",
+    ]
+    country_lookup_method = "authenticatorAppGps"
+  }
+}
+`, data.RandomInteger)
+}
+
+func (NamedLocationResource) compliantNetwork(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_named_location" "test" {
+  display_name = "acctestNLCN-%[1]d"
+  compliant_network {
+    compliant_network_type = "allTenantCompliantNetworks"
+  }
+}
+`, data.RandomInteger)
+}
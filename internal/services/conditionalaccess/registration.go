@@ -29,14 +29,22 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azuread_named_location": namedLocationDataSource(),
+		"azuread_conditional_access_policies":        conditionalAccessPoliciesDataSource(),
+		"azuread_conditional_access_templates":       conditionalAccessTemplatesDataSource(),
+		"azuread_conditional_access_what_if":         conditionalAccessWhatIfDataSource(),
+		"azuread_named_location":                     namedLocationDataSource(),
+		"azuread_risk_detections":                    riskDetectionsDataSource(),
+		"azuread_risky_users":                        riskyUsersDataSource(),
+		"azuread_terms_of_use_agreement_acceptances": termsOfUseAgreementAcceptancesDataSource(),
 	}
 }
 
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azuread_named_location":            namedLocationResource(),
-		"azuread_conditional_access_policy": conditionalAccessPolicyResource(),
+		"azuread_named_location":                            namedLocationResource(),
+		"azuread_conditional_access_authentication_context": conditionalAccessAuthenticationContextResource(),
+		"azuread_conditional_access_policy":                 conditionalAccessPolicyResource(),
+		"azuread_terms_of_use_agreement":                    termsOfUseAgreementResource(),
 	}
 }
@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func riskDetectionsDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		ReadContext: riskDetectionsDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"detected_after": {
+				Description:  "Only return risks detected after this date, formatted as an RFC3339 date string (e.g. 2018-01-01T01:02:03Z)",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			"risk_event_types": {
+				Description: "Only return risks with one of these risk event types, e.g. `unfamiliarFeatures` or `leakedCredentials`",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"risk_levels": {
+				Description: "Only return risks with one of these risk levels",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+				},
+			},
+
+			"risk_states": {
+				Description: "Only return risks with one of these risk states",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskState(), false),
+				},
+			},
+
+			"user_ids": {
+				Description: "Only return risks detected for one of these users",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+
+			"risk_detections": {
+				Description: "A list of risk detections",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"activity": {
+							Description: "The type of activity for which the risk was detected",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"activity_date": {
+							Description: "The date at which the risky activity occurred",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"city": {
+							Description: "The city from which the risky activity originated",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"country_or_region": {
+							Description: "The country or region from which the risky activity originated",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"detected_date": {
+							Description: "The date at which the risk was detected",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"detection_timing_type": {
+							Description: "The timing of the detected risk, e.g. `realtime` or `offline`",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"id": {
+							Description: "The ID of the risk detection",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"ip_address": {
+							Description: "The IP address from which the risky activity originated",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"risk_detail": {
+							Description: "The reason for the current risk state",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"risk_event_type": {
+							Description: "The type of risk event detected",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"risk_level": {
+							Description: "The level of the detected risk",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"risk_state": {
+							Description: "The state of the detected risk",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"source": {
+							Description: "The source of the risk detection",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"user_display_name": {
+							Description: "The display name of the user",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"user_id": {
+							Description: "The object ID of the user",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"user_principal_name": {
+							Description: "The user principal name (UPN) of the user",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func riskDetectionsDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.IdentityProtectionClient
+
+	detectedAfter := ""
+	if v := d.Get("detected_after").(string); v != "" {
+		detectedAfter = fmt.Sprintf("detectedDateTime gt %s", v)
+	}
+
+	filter := identityProtectionFilter(
+		identityProtectionFilterAnyOf("userId", tf.ExpandStringSlice(d.Get("user_ids").([]interface{}))),
+		identityProtectionFilterAnyOf("riskLevel", tf.ExpandStringSlice(d.Get("risk_levels").([]interface{}))),
+		identityProtectionFilterAnyOf("riskState", tf.ExpandStringSlice(d.Get("risk_states").([]interface{}))),
+		identityProtectionFilterAnyOf("riskEventType", tf.ExpandStringSlice(d.Get("risk_event_types").([]interface{}))),
+		detectedAfter,
+	)
+
+	riskDetections, err := listRiskDetections(ctx, client, filter)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving risk detections")
+	}
+
+	ids := make([]string, 0, len(riskDetections))
+	riskDetectionList := make([]map[string]interface{}, 0, len(riskDetections))
+	for _, riskDetection := range riskDetections {
+		id := pointer.From(riskDetection.Id)
+		ids = append(ids, id)
+
+		city, countryOrRegion := "", ""
+		if location := riskDetection.Location; location != nil {
+			city = location.City.GetOrZero()
+			countryOrRegion = location.CountryOrRegion.GetOrZero()
+		}
+
+		riskDetectionList = append(riskDetectionList, map[string]interface{}{
+			"activity":              string(pointer.From(riskDetection.Activity)),
+			"activity_date":         riskDetection.ActivityDateTime.GetOrZero(),
+			"city":                  city,
+			"country_or_region":     countryOrRegion,
+			"detected_date":         riskDetection.DetectedDateTime.GetOrZero(),
+			"detection_timing_type": string(pointer.From(riskDetection.DetectionTimingType)),
+			"id":                    id,
+			"ip_address":            riskDetection.IPAddress.GetOrZero(),
+			"risk_detail":           string(pointer.From(riskDetection.RiskDetail)),
+			"risk_event_type":       riskDetection.RiskEventType.GetOrZero(),
+			"risk_level":            string(pointer.From(riskDetection.RiskLevel)),
+			"risk_state":            string(pointer.From(riskDetection.RiskState)),
+			"source":                riskDetection.Source.GetOrZero(),
+			"user_display_name":     riskDetection.UserDisplayName.GetOrZero(),
+			"user_id":               riskDetection.UserId.GetOrZero(),
+			"user_principal_name":   riskDetection.UserPrincipalName.GetOrZero(),
+		})
+	}
+
+	// Generate a unique ID based on the filter and result
+	h := sha1.New()
+	if _, err = h.Write([]byte(filter + "/" + strings.Join(ids, "/"))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for risk detections")
+	}
+
+	d.SetId("riskDetections#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "risk_detections", riskDetectionList)
+
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type RiskDetectionsDataSource struct{}
+
+func TestAccRiskDetectionsDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_risk_detections", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: RiskDetectionsDataSource{}.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").Exists(),
+				check.That(data.ResourceName).Key("risk_detections.#").Exists(),
+			),
+		},
+	})
+}
+
+func (RiskDetectionsDataSource) basic() string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_risk_detections" "test" {
+  detected_after = "%[1]s"
+  risk_levels    = ["high"]
+}
+`, time.Now().AddDate(0, 0, -30).UTC().Format(time.RFC3339))
+}
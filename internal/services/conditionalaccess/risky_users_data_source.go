@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func riskyUsersDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		ReadContext: riskyUsersDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"risk_levels": {
+				Description: "Only return users with one of these risk levels",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskLevel(), false),
+				},
+			},
+
+			"risk_states": {
+				Description: "Only return users with one of these risk states",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForRiskState(), false),
+				},
+			},
+
+			"user_principal_names": {
+				Description: "Only return users with one of these user principal names",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"object_ids": {
+				Description: "The object IDs of the risky users",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"risky_users": {
+				Description: "A list of risky users",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"deleted": {
+							Description: "Whether the user is deleted",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The display name of the user",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"object_id": {
+							Description: "The object ID of the user",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"processing": {
+							Description: "Whether the risk state of the user is being processed by the backend",
+							Type:        pluginsdk.TypeBool,
+							Computed:    true,
+						},
+
+						"risk_detail": {
+							Description: "The reason for the current risk state of the user",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"risk_last_updated_date": {
+							Description: "The date at which the risk state of the user was last updated",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"risk_level": {
+							Description: "The aggregated risk level of the user",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"risk_state": {
+							Description: "The risk state of the user",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"user_principal_name": {
+							Description: "The user principal name (UPN) of the user",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func riskyUsersDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.IdentityProtectionClient
+
+	filter := identityProtectionFilter(
+		identityProtectionFilterAnyOf("riskLevel", tf.ExpandStringSlice(d.Get("risk_levels").([]interface{}))),
+		identityProtectionFilterAnyOf("riskState", tf.ExpandStringSlice(d.Get("risk_states").([]interface{}))),
+		identityProtectionFilterAnyOf("userPrincipalName", tf.ExpandStringSlice(d.Get("user_principal_names").([]interface{}))),
+	)
+
+	riskyUsers, err := listRiskyUsers(ctx, client, filter)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving risky users")
+	}
+
+	objectIds := make([]string, 0, len(riskyUsers))
+	riskyUserList := make([]map[string]interface{}, 0, len(riskyUsers))
+	for _, riskyUser := range riskyUsers {
+		objectId := pointer.From(riskyUser.Id)
+		objectIds = append(objectIds, objectId)
+
+		riskyUserList = append(riskyUserList, map[string]interface{}{
+			"deleted":                riskyUser.IsDeleted.GetOrZero(),
+			"display_name":           riskyUser.UserDisplayName.GetOrZero(),
+			"object_id":              objectId,
+			"processing":             riskyUser.IsProcessing.GetOrZero(),
+			"risk_detail":            string(pointer.From(riskyUser.RiskDetail)),
+			"risk_last_updated_date": riskyUser.RiskLastUpdatedDateTime.GetOrZero(),
+			"risk_level":             string(pointer.From(riskyUser.RiskLevel)),
+			"risk_state":             string(pointer.From(riskyUser.RiskState)),
+			"user_principal_name":    riskyUser.UserPrincipalName.GetOrZero(),
+		})
+	}
+
+	// Generate a unique ID based on the filter and result
+	h := sha1.New()
+	if _, err = h.Write([]byte(filter + "/" + strings.Join(objectIds, "/"))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for risky users")
+	}
+
+	d.SetId("riskyUsers#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "object_ids", objectIds)
+	tf.Set(d, "risky_users", riskyUserList)
+
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type RiskyUsersDataSource struct{}
+
+func TestAccRiskyUsersDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_risky_users", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: RiskyUsersDataSource{}.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").Exists(),
+				check.That(data.ResourceName).Key("object_ids.#").Exists(),
+			),
+		},
+	})
+}
+
+func (RiskyUsersDataSource) basic() string {
+	return `
+provider "azuread" {}
+
+data "azuread_risky_users" "test" {
+  risk_levels = ["high", "medium"]
+  risk_states = ["atRisk"]
+}
+`
+}
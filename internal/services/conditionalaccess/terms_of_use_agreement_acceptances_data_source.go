@@ -0,0 +1,212 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identitygovernance/stable/termsofuseagreementacceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func termsOfUseAgreementAcceptancesDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		ReadContext: termsOfUseAgreementAcceptancesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"agreement_id": {
+				Description:  "The ID of the terms of use agreement",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"state": {
+				Description:  "Only return acceptances with this state",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(stable.PossibleValuesForAgreementAcceptanceState(), false),
+			},
+
+			"user_ids": {
+				Description: "Only return acceptances recorded for these users",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+
+			"accepted_user_ids": {
+				Description: "The object IDs of users who have accepted the agreement, and whose acceptance has not expired",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"acceptances": {
+				Description: "A list of acceptances recorded for the agreement",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"device_display_name": {
+							Description: "The display name of the device used to accept the agreement",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"device_id": {
+							Description: "The ID of the device used to accept the agreement",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"expiration_date": {
+							Description: "The date and time when the acceptance expires",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"recorded_date": {
+							Description: "The date and time when the acceptance was recorded",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"state": {
+							Description: "The state of the acceptance",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"user_display_name": {
+							Description: "The display name of the user when the acceptance was recorded",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"user_email": {
+							Description: "The email address of the user when the acceptance was recorded",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"user_id": {
+							Description: "The object ID of the user",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"user_principal_name": {
+							Description: "The user principal name of the user when the acceptance was recorded",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func termsOfUseAgreementAcceptancesDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.TermsOfUseAgreementAcceptanceClient
+
+	id := stable.NewIdentityGovernanceTermsOfUseAgreementID(termsOfUseAgreementObjectId(d.Get("agreement_id").(string)))
+	state := d.Get("state").(string)
+	userIds := tf.ExpandStringSlice(d.Get("user_ids").([]interface{}))
+
+	resp, err := client.ListTermsOfUseAgreementAcceptancesComplete(ctx, id, termsofuseagreementacceptance.DefaultListTermsOfUseAgreementAcceptancesOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "agreement_id", "Retrieving acceptances for %s", id)
+	}
+	if resp.Items == nil {
+		return tf.ErrorDiagF(errors.New("API returned nil result"), "Retrieving acceptances for %s", id)
+	}
+
+	now := time.Now()
+	acceptedUserIds := make([]string, 0)
+	acceptanceIds := make([]string, 0)
+	acceptanceList := make([]map[string]interface{}, 0)
+	for _, acceptance := range resp.Items {
+		userId := acceptance.UserId.GetOrZero()
+		if len(userIds) > 0 && !slices.ContainsFunc(userIds, func(v string) bool { return strings.EqualFold(v, userId) }) {
+			continue
+		}
+
+		acceptanceState := string(pointer.From(acceptance.State))
+		if state != "" && acceptanceState != state {
+			continue
+		}
+
+		acceptanceIds = append(acceptanceIds, pointer.From(acceptance.Id))
+		acceptanceList = append(acceptanceList, map[string]interface{}{
+			"device_display_name": acceptance.DeviceDisplayName.GetOrZero(),
+			"device_id":           acceptance.DeviceId.GetOrZero(),
+			"expiration_date":     acceptance.ExpirationDateTime.GetOrZero(),
+			"recorded_date":       acceptance.RecordedDateTime.GetOrZero(),
+			"state":               acceptanceState,
+			"user_display_name":   acceptance.UserDisplayName.GetOrZero(),
+			"user_email":          acceptance.UserEmail.GetOrZero(),
+			"user_id":             userId,
+			"user_principal_name": acceptance.UserPrincipalName.GetOrZero(),
+		})
+
+		if acceptanceState == string(stable.AgreementAcceptanceState_Accepted) && !termsOfUseAgreementAcceptanceExpired(acceptance.ExpirationDateTime.GetOrZero(), now) &&
+			!slices.Contains(acceptedUserIds, userId) {
+			acceptedUserIds = append(acceptedUserIds, userId)
+		}
+	}
+
+	// Generate a unique ID based on the filters and result
+	h := sha1.New()
+	if _, err = h.Write([]byte(id.AgreementId + "/" + state + "/" + strings.Join(userIds, "/") + "/" + strings.Join(acceptanceIds, "/"))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for terms of use agreement acceptances")
+	}
+
+	d.SetId("termsOfUseAgreementAcceptances#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "accepted_user_ids", acceptedUserIds)
+	tf.Set(d, "acceptances", acceptanceList)
+
+	return nil
+}
+
+// termsOfUseAgreementObjectId returns the object ID of an agreement, given either an object ID or a resource ID
+func termsOfUseAgreementObjectId(in string) string {
+	if id, err := stable.ParseIdentityGovernanceTermsOfUseAgreementID(in); err == nil {
+		return id.AgreementId
+	}
+	return in
+}
+
+// termsOfUseAgreementAcceptanceExpired returns true when an acceptance has an expiry date which has passed
+func termsOfUseAgreementAcceptanceExpired(expirationDate string, now time.Time) bool {
+	if expirationDate == "" {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339, expirationDate)
+	if err != nil {
+		return false
+	}
+	return expiry.Before(now)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type TermsOfUseAgreementAcceptancesDataSource struct{}
+
+func TestAccTermsOfUseAgreementAcceptancesDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_terms_of_use_agreement_acceptances", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: TermsOfUseAgreementAcceptancesDataSource{}.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("acceptances.#").HasValue("0"),
+				check.That(data.ResourceName).Key("accepted_user_ids.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAccTermsOfUseAgreementAcceptancesDataSource_filtered(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_terms_of_use_agreement_acceptances", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: TermsOfUseAgreementAcceptancesDataSource{}.filtered(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("acceptances.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func (TermsOfUseAgreementAcceptancesDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_terms_of_use_agreement_acceptances" "test" {
+  agreement_id = azuread_terms_of_use_agreement.test.id
+}
+`, TermsOfUseAgreementResource{}.basic(data))
+}
+
+func (TermsOfUseAgreementAcceptancesDataSource) filtered(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_client_config" "current" {}
+
+data "azuread_terms_of_use_agreement_acceptances" "test" {
+  agreement_id = azuread_terms_of_use_agreement.test.object_id
+  state        = "accepted"
+  user_ids     = [data.azuread_client_config.current.object_id]
+}
+`, TermsOfUseAgreementResource{}.basic(data))
+}
@@ -0,0 +1,587 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identitygovernance/stable/termsofuseagreement"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identitygovernance/stable/termsofuseagreementfile"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/iso8601"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+var termsOfUseAgreementSha256Regex = regexp.MustCompile("^[0-9a-fA-F]{64}$")
+
+func termsOfUseAgreementResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: termsOfUseAgreementResourceCreate,
+		ReadContext:   termsOfUseAgreementResourceRead,
+		UpdateContext: termsOfUseAgreementResourceUpdate,
+		DeleteContext: termsOfUseAgreementResourceDelete,
+
+		CustomizeDiff: termsOfUseAgreementCustomizeDiff,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, errs := stable.ValidateIdentityGovernanceTermsOfUseAgreementID(id, "id"); len(errs) > 0 {
+				out := ""
+				for _, err := range errs {
+					out += err.Error()
+				}
+				return fmt.Errorf(out)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"display_name": {
+				Description:  "The display name of the agreement",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"file": {
+				Description: "The localized files containing the terms of the agreement",
+				Type:        pluginsdk.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"content_base64": {
+							Description:  "The base64 encoded PDF content of the file",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsBase64,
+						},
+
+						"content_sha256": {
+							Description:  "The SHA-256 hash of the file content, as a hexadecimal string",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringMatch(termsOfUseAgreementSha256Regex, "must be a hexadecimal SHA-256 hash"),
+						},
+
+						"default": {
+							Description: "Whether this is the default file, which is shown when none of the files match the language of the user",
+							Type:        pluginsdk.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+
+						"file_name": {
+							Description:  "The name of the file, e.g. `terms.pdf`",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"language": {
+							Description:  "The language of the file, as an IETF language tag, e.g. `en-US`",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.ISO639Language,
+						},
+
+						"path": {
+							Description:  "The path to a local PDF file",
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"per_device_acceptance_required": {
+				Description: "Whether users must accept the agreement on every device they use to access resources",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"terms_expiration": {
+				Description: "Specifies when all users must accept the agreement again",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"frequency": {
+							Description:  "How often all users must accept the agreement again, formatted as an ISO8601 duration, e.g. `P365D`",
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: iso8601.ValidateDuration,
+						},
+
+						"start_date": {
+							Description:      "The date from which all users must accept the agreement again, formatted as an RFC3339 date string",
+							Type:             pluginsdk.TypeString,
+							Required:         true,
+							ValidateFunc:     validation.IsRFC3339Time,
+							DiffSuppressFunc: termsOfUseAgreementDateDiffSuppress,
+						},
+					},
+				},
+			},
+
+			"user_reaccept_required_frequency": {
+				Description:  "How often each user must accept the agreement again, formatted as an ISO8601 duration, e.g. `P90D`",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: iso8601.ValidateDuration,
+			},
+
+			"view_before_acceptance_required": {
+				Description: "Whether users must expand and view the agreement before accepting it",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"object_id": {
+				Description: "The object ID of the agreement",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func termsOfUseAgreementCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	defaultFiles := 0
+	for i, raw := range diff.Get("file").([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		file := raw.(map[string]interface{})
+
+		if pluginsdk.ValueIsNotEmptyOrUnknown(file["content_base64"]) == pluginsdk.ValueIsNotEmptyOrUnknown(file["path"]) &&
+			diff.NewValueKnown(fmt.Sprintf("file.%d.content_base64", i)) && diff.NewValueKnown(fmt.Sprintf("file.%d.path", i)) {
+			return fmt.Errorf("exactly one of `content_base64` or `path` must be specified for `file.%d`", i)
+		}
+
+		if file["default"].(bool) {
+			defaultFiles++
+		}
+	}
+	if defaultFiles > 1 {
+		return fmt.Errorf("only one `file` can be the default file")
+	}
+
+	// Files cannot be modified or removed from an existing agreement, only added
+	if diff.Id() != "" && diff.HasChange("file") {
+		oldFiles, newFiles := diff.GetChange("file")
+		if termsOfUseAgreementFilesReplaced(oldFiles.([]interface{}), newFiles.([]interface{})) {
+			if err := diff.ForceNew("file"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func termsOfUseAgreementDateDiffSuppress(_, old, new string, _ *pluginsdk.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
+func termsOfUseAgreementResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.TermsOfUseAgreementClient
+
+	files, fileList, err := expandTermsOfUseAgreementFiles(d.Get("file").([]interface{}))
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "file", "Reading terms of use agreement files")
+	}
+
+	properties := expandTermsOfUseAgreement(d)
+	properties.Files = &files
+
+	resp, err := client.CreateTermsOfUseAgreement(ctx, properties, termsofuseagreement.DefaultCreateTermsOfUseAgreementOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not create terms of use agreement")
+	}
+
+	agreement := resp.Model
+	if agreement == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Could not create terms of use agreement")
+	}
+
+	if agreement.Id == nil || *agreement.Id == "" {
+		return tf.ErrorDiagF(errors.New("Bad API response"), "Object ID returned for terms of use agreement is nil/empty")
+	}
+
+	id := stable.NewIdentityGovernanceTermsOfUseAgreementID(*agreement.Id)
+
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetTermsOfUseAgreement(ctx, id, termsofuseagreement.DefaultGetTermsOfUseAgreementOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(resp.Model != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for creation of %s", id)
+	}
+
+	d.SetId(id.ID())
+
+	// The file content is not returned by the API, so the computed hashes are saved here
+	tf.Set(d, "file", fileList)
+
+	return termsOfUseAgreementResourceRead(ctx, d, meta)
+}
+
+func termsOfUseAgreementResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.TermsOfUseAgreementClient
+	fileClient := meta.(*clients.Client).ConditionalAccess.TermsOfUseAgreementFileClient
+
+	id, err := stable.ParseIdentityGovernanceTermsOfUseAgreementID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Terms of Use Agreement ID")
+	}
+
+	properties := expandTermsOfUseAgreement(d)
+	if _, err = client.UpdateTermsOfUseAgreement(ctx, *id, properties, termsofuseagreement.DefaultUpdateTermsOfUseAgreementOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Updating %s", id)
+	}
+
+	if d.HasChange("file") {
+		// Any other changes to files force a new resource, so only new languages need to be added here
+		oldFiles, _ := d.GetChange("file")
+		existingLanguages := make(map[string]bool)
+		for _, raw := range oldFiles.([]interface{}) {
+			if raw != nil {
+				existingLanguages[strings.ToLower(raw.(map[string]interface{})["language"].(string))] = true
+			}
+		}
+
+		files, fileList, err := expandTermsOfUseAgreementFiles(d.Get("file").([]interface{}))
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "file", "Reading terms of use agreement files")
+		}
+
+		for _, file := range files {
+			if existingLanguages[strings.ToLower(file.Language.GetOrZero())] {
+				continue
+			}
+			if _, err = fileClient.CreateTermsOfUseAgreementFile(ctx, *id, file, termsofuseagreementfile.DefaultCreateTermsOfUseAgreementFileOperationOptions()); err != nil {
+				return tf.ErrorDiagF(err, "Adding file for language %q to %s", file.Language.GetOrZero(), id)
+			}
+		}
+
+		tf.Set(d, "file", fileList)
+	}
+
+	return termsOfUseAgreementResourceRead(ctx, d, meta)
+}
+
+func termsOfUseAgreementResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.TermsOfUseAgreementClient
+
+	id, err := stable.ParseIdentityGovernanceTermsOfUseAgreementID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Terms of Use Agreement ID")
+	}
+
+	options := termsofuseagreement.GetTermsOfUseAgreementOperationOptions{
+		Expand: &odata.Expand{Relationship: "files"},
+	}
+	resp, err := client.GetTermsOfUseAgreement(ctx, *id, options)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	agreement := resp.Model
+	if agreement == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", id)
+	}
+
+	tf.Set(d, "display_name", agreement.DisplayName.GetOrZero())
+	tf.Set(d, "file", flattenTermsOfUseAgreementFiles(agreement.Files, d.Get("file").([]interface{})))
+	tf.Set(d, "object_id", id.AgreementId)
+	tf.Set(d, "per_device_acceptance_required", agreement.IsPerDeviceAcceptanceRequired.GetOrZero())
+	tf.Set(d, "terms_expiration", flattenTermsOfUseAgreementTermsExpiration(agreement.TermsExpiration))
+	tf.Set(d, "user_reaccept_required_frequency", agreement.UserReacceptRequiredFrequency.GetOrZero())
+	tf.Set(d, "view_before_acceptance_required", agreement.IsViewingBeforeAcceptanceRequired.GetOrZero())
+
+	return nil
+}
+
+func termsOfUseAgreementResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.TermsOfUseAgreementClient
+
+	id, err := stable.ParseIdentityGovernanceTermsOfUseAgreementID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing Terms of Use Agreement ID")
+	}
+
+	resp, err := client.DeleteTermsOfUseAgreement(ctx, *id, termsofuseagreement.DefaultDeleteTermsOfUseAgreementOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s already deleted", id)
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Deleting %s", id)
+	}
+
+	if err = consistency.WaitForDeletion(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetTermsOfUseAgreement(ctx, *id, termsofuseagreement.DefaultGetTermsOfUseAgreementOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(true), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for deletion of %s", id)
+	}
+
+	return nil
+}
+
+func expandTermsOfUseAgreement(d *pluginsdk.ResourceData) stable.Agreement {
+	result := stable.Agreement{
+		DisplayName:                       nullable.Value(d.Get("display_name").(string)),
+		IsPerDeviceAcceptanceRequired:     nullable.Value(d.Get("per_device_acceptance_required").(bool)),
+		IsViewingBeforeAcceptanceRequired: nullable.Value(d.Get("view_before_acceptance_required").(bool)),
+		UserReacceptRequiredFrequency:     nullable.NoZero(d.Get("user_reaccept_required_frequency").(string)),
+	}
+
+	if v := d.Get("terms_expiration").([]interface{}); len(v) > 0 && v[0] != nil {
+		termsExpiration := v[0].(map[string]interface{})
+		result.TermsExpiration = &stable.TermsExpiration{
+			Frequency:     nullable.Value(termsExpiration["frequency"].(string)),
+			StartDateTime: nullable.Value(termsExpiration["start_date"].(string)),
+		}
+	} else if d.HasChange("terms_expiration") {
+		result.TermsExpiration = &stable.TermsExpiration{}
+		result.TermsExpiration.Frequency.SetNull()
+		result.TermsExpiration.StartDateTime.SetNull()
+	}
+
+	return result
+}
+
+// expandTermsOfUseAgreementFiles reads the content of each configured file, returning the files to be uploaded along
+// with the configuration updated with the hash of each file
+func expandTermsOfUseAgreementFiles(in []interface{}) ([]stable.AgreementFileLocalization, []interface{}, error) {
+	files := make([]stable.AgreementFileLocalization, 0)
+	fileList := make([]interface{}, 0)
+
+	for i, raw := range in {
+		if raw == nil {
+			continue
+		}
+		file := raw.(map[string]interface{})
+
+		content, err := termsOfUseAgreementFileContent(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("file.%d: %v", i, err)
+		}
+
+		hash := sha256.Sum256(content)
+		contentSha256 := hex.EncodeToString(hash[:])
+		if v := file["content_sha256"].(string); v != "" && !strings.EqualFold(v, contentSha256) {
+			return nil, nil, fmt.Errorf("file.%d: `content_sha256` does not match the file content, expected %q", i, contentSha256)
+		}
+
+		files = append(files, stable.AgreementFileLocalization{
+			FileData: &stable.AgreementFileData{
+				Data: nullable.Value(base64.StdEncoding.EncodeToString(content)),
+			},
+			FileName:  nullable.Value(file["file_name"].(string)),
+			IsDefault: nullable.Value(file["default"].(bool)),
+			Language:  nullable.Value(file["language"].(string)),
+		})
+
+		fileList = append(fileList, map[string]interface{}{
+			"content_base64": file["content_base64"].(string),
+			"content_sha256": contentSha256,
+			"default":        file["default"].(bool),
+			"file_name":      file["file_name"].(string),
+			"language":       file["language"].(string),
+			"path":           file["path"].(string),
+		})
+	}
+
+	return files, fileList, nil
+}
+
+// termsOfUseAgreementFileContent returns the content of a file, either decoded from `content_base64` or read from `path`
+func termsOfUseAgreementFileContent(file map[string]interface{}) ([]byte, error) {
+	if v := file["content_base64"].(string); v != "" {
+		content, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("decoding `content_base64`: %v", err)
+		}
+		return content, nil
+	}
+
+	if v := file["path"].(string); v != "" {
+		content, err := os.ReadFile(v)
+		if err != nil {
+			return nil, fmt.Errorf("reading file %q: %v", v, err)
+		}
+		return content, nil
+	}
+
+	return nil, errors.New("one of `content_base64` or `path` must be specified")
+}
+
+// termsOfUseAgreementFilesReplaced returns true when any existing file has been removed or changed
+func termsOfUseAgreementFilesReplaced(oldFiles, newFiles []interface{}) bool {
+	newByLanguage := make(map[string]map[string]interface{})
+	for _, raw := range newFiles {
+		if raw != nil {
+			file := raw.(map[string]interface{})
+			newByLanguage[strings.ToLower(file["language"].(string))] = file
+		}
+	}
+
+	for _, raw := range oldFiles {
+		if raw == nil {
+			continue
+		}
+		oldFile := raw.(map[string]interface{})
+
+		newFile, ok := newByLanguage[strings.ToLower(oldFile["language"].(string))]
+		if !ok {
+			return true
+		}
+
+		for _, key := range []string{"default", "file_name"} {
+			if !reflect.DeepEqual(oldFile[key], newFile[key]) {
+				return true
+			}
+		}
+
+		// The content of imported files is not known, so it is assumed to be unchanged
+		if oldFile["content_base64"].(string) == "" && oldFile["path"].(string) == "" && oldFile["content_sha256"].(string) == "" {
+			continue
+		}
+
+		for _, key := range []string{"content_base64", "path"} {
+			if !reflect.DeepEqual(oldFile[key], newFile[key]) {
+				return true
+			}
+		}
+		if v := newFile["content_sha256"].(string); v != "" && !strings.EqualFold(v, oldFile["content_sha256"].(string)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// flattenTermsOfUseAgreementFiles returns the files of an agreement in the order they are configured, since the file
+// content is not returned by the API and must be retained from the configuration
+func flattenTermsOfUseAgreementFiles(in *[]stable.AgreementFileLocalization, existing []interface{}) []interface{} {
+	if in == nil {
+		return existing
+	}
+
+	filesByLanguage := make(map[string]stable.AgreementFileLocalization)
+	for _, file := range *in {
+		filesByLanguage[strings.ToLower(file.Language.GetOrZero())] = file
+	}
+
+	result := make([]interface{}, 0)
+	seen := make(map[string]bool)
+	for _, raw := range existing {
+		if raw == nil {
+			continue
+		}
+		existingFile := raw.(map[string]interface{})
+		language := strings.ToLower(existingFile["language"].(string))
+
+		file, ok := filesByLanguage[language]
+		if !ok {
+			continue
+		}
+		seen[language] = true
+
+		result = append(result, map[string]interface{}{
+			"content_base64": existingFile["content_base64"],
+			"content_sha256": existingFile["content_sha256"],
+			"default":        file.IsDefault.GetOrZero(),
+			"file_name":      file.FileName.GetOrZero(),
+			"language":       existingFile["language"],
+			"path":           existingFile["path"],
+		})
+	}
+
+	for _, file := range *in {
+		if seen[strings.ToLower(file.Language.GetOrZero())] {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"content_base64": "",
+			"content_sha256": "",
+			"default":        file.IsDefault.GetOrZero(),
+			"file_name":      file.FileName.GetOrZero(),
+			"language":       file.Language.GetOrZero(),
+			"path":           "",
+		})
+	}
+
+	return result
+}
+
+func flattenTermsOfUseAgreementTermsExpiration(in *stable.TermsExpiration) []interface{} {
+	if in == nil || in.Frequency.GetOrZero() == "" {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"frequency":  in.Frequency.GetOrZero(),
+			"start_date": in.StartDateTime.GetOrZero(),
+		},
+	}
+}
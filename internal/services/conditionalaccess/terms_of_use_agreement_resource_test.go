@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package conditionalaccess_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identitygovernance/stable/termsofuseagreement"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type TermsOfUseAgreementResource struct{}
+
+// termsOfUsePdf is a minimal PDF document containing a single blank page
+const termsOfUsePdf = "%PDF-1.4\n1 0 obj<</Type/Catalog/Pages 2 0 R>>endobj\n2 0 obj<</Type/Pages/Kids[3 0 R]/Count 1>>endobj\n3 0 obj<</Type/Page/Parent 2 0 R/MediaBox[0 0 612 792]>>endobj\ntrailer<</Root 1 0 R>>\n%%EOF\n"
+
+func TestAccTermsOfUseAgreement_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_terms_of_use_agreement", "test")
+	r := TermsOfUseAgreementResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("object_id").Exists(),
+				check.That(data.ResourceName).Key("file.0.content_sha256").Exists(),
+			),
+		},
+		data.ImportStep("file.0.content_base64", "file.0.content_sha256"),
+	})
+}
+
+func TestAccTermsOfUseAgreement_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_terms_of_use_agreement", "test")
+	r := TermsOfUseAgreementResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("file.#").HasValue("2"),
+				check.That(data.ResourceName).Key("terms_expiration.0.frequency").HasValue("P365D"),
+			),
+		},
+		data.ImportStep("file.0.content_base64", "file.0.content_sha256", "file.1.content_base64", "file.1.content_sha256"),
+	})
+}
+
+func TestAccTermsOfUseAgreement_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_terms_of_use_agreement", "test")
+	r := TermsOfUseAgreementResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("file.0.content_base64", "file.0.content_sha256"),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("file.#").HasValue("2"),
+			),
+		},
+		data.ImportStep("file.0.content_base64", "file.0.content_sha256", "file.1.content_base64", "file.1.content_sha256"),
+	})
+}
+
+func TestAccTermsOfUseAgreement_conditionalAccessPolicy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_terms_of_use_agreement", "test")
+	r := TermsOfUseAgreementResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.conditionalAccessPolicy(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azuread_conditional_access_policy.test").Key("grant_controls.0.terms_of_use.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("file.0.content_base64", "file.0.content_sha256"),
+	})
+}
+
+func TestAccTermsOfUseAgreement_invalidHash(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_terms_of_use_agreement", "test")
+	r := TermsOfUseAgreementResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.invalidHash(data),
+			ExpectError: regexp.MustCompile("`content_sha256` does not match the file content"),
+		},
+	})
+}
+
+func (r TermsOfUseAgreementResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ConditionalAccess.TermsOfUseAgreementClient
+
+	id, err := stable.ParseIdentityGovernanceTermsOfUseAgreementID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetTermsOfUseAgreement(ctx, *id, termsofuseagreement.DefaultGetTermsOfUseAgreementOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %+v", id, err)
+	}
+
+	return pointer.To(true), nil
+}
+
+func (TermsOfUseAgreementResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_terms_of_use_agreement" "test" {
+  display_name = "acctest-TOU-%[1]d"
+
+  file {
+    language       = "en-US"
+    file_name      = "terms.pdf"
+    content_base64 = "%[2]s"
+    default        = true
+  }
+}
+`, data.RandomInteger, base64.StdEncoding.EncodeToString([]byte(termsOfUsePdf)))
+}
+
+func (TermsOfUseAgreementResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_terms_of_use_agreement" "test" {
+  display_name                     = "acctest-TOU-%[1]d"
+  per_device_acceptance_required   = true
+  view_before_acceptance_required  = true
+  user_reaccept_required_frequency = "P90D"
+
+  file {
+    language       = "en-US"
+    file_name      = "terms.pdf"
+    content_base64 = "%[2]s"
+    default        = true
+  }
+
+  file {
+    language       = "de-DE"
+    file_name      = "bedingungen.pdf"
+    content_base64 = "%[2]s"
+  }
+
+  terms_expiration {
+    start_date = "2030-01-01T00:00:00Z"
+    frequency  = "P365D"
+  }
+}
+`, data.RandomInteger, base64.StdEncoding.EncodeToString([]byte(termsOfUsePdf)))
+}
+
+func (r TermsOfUseAgreementResource) conditionalAccessPolicy(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_conditional_access_policy" "test" {
+  display_name = "acctest-CONPOLICY-%[2]d"
+  state        = "disabled"
+
+  conditions {
+    client_app_types = ["browser"]
+
+    applications {
+      included_applications = ["All"]
+    }
+
+    users {
+      included_users = ["All"]
+      excluded_users = ["GuestsOrExternalUsers"]
+    }
+  }
+
+  grant_controls {
+    operator     = "OR"
+    terms_of_use = [azuread_terms_of_use_agreement.test.id]
+  }
+}
+`, r.basic(data), data.RandomInteger)
+}
+
+func (TermsOfUseAgreementResource) invalidHash(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_terms_of_use_agreement" "test" {
+  display_name = "acctest-TOU-%[1]d"
+
+  file {
+    language       = "en-US"
+    file_name      = "terms.pdf"
+    content_base64 = "%[2]s"
+    content_sha256 = "0000000000000000000000000000000000000000000000000000000000000000"
+    default        = true
+  }
+}
+`, data.RandomInteger, base64.StdEncoding.EncodeToString([]byte(termsOfUsePdf)))
+}
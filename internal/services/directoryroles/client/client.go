@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directoryroles/stable/member"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directoryroletemplates/stable/directoryroletemplate"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/rolemanagement/stable/directoryroleassignment"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/rolemanagement/stable/directoryroleassignmentschedulerequest"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/rolemanagement/stable/directoryroledefinition"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/rolemanagement/stable/directoryroleeligibilityschedulerequest"
 	"github.com/hashicorp/terraform-provider-azuread/internal/common"
@@ -17,6 +18,7 @@ import (
 type Client struct {
 	DirectoryObjectClient                         *directoryobject.DirectoryObjectClient
 	DirectoryRoleAssignmentClient                 *directoryroleassignment.DirectoryRoleAssignmentClient
+	DirectoryRoleAssignmentScheduleRequestClient  *directoryroleassignmentschedulerequest.DirectoryRoleAssignmentScheduleRequestClient
 	DirectoryRoleClient                           *directoryrole.DirectoryRoleClient
 	DirectoryRoleDefinitionClient                 *directoryroledefinition.DirectoryRoleDefinitionClient
 	DirectoryRoleEligibilityScheduleRequestClient *directoryroleeligibilityschedulerequest.DirectoryRoleEligibilityScheduleRequestClient
@@ -31,29 +33,29 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(directoryObjectClient.Client)
 
-	directoryRoleClient, err := directoryrole.NewDirectoryRoleClientWithBaseURI(o.Environment.MicrosoftGraph)
+	directoryRoleAssignmentClient, err := directoryroleassignment.NewDirectoryRoleAssignmentClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
 	}
-	o.Configure(directoryRoleClient.Client)
+	o.Configure(directoryRoleAssignmentClient.Client)
 
-	directoryRoleAssignmentClient, err := directoryroleassignment.NewDirectoryRoleAssignmentClientWithBaseURI(o.Environment.MicrosoftGraph)
+	directoryRoleAssignmentScheduleRequestClient, err := directoryroleassignmentschedulerequest.NewDirectoryRoleAssignmentScheduleRequestClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
 	}
-	o.Configure(directoryRoleAssignmentClient.Client)
+	o.Configure(directoryRoleAssignmentScheduleRequestClient.Client)
 
-	directoryRoleDefinitionClient, err := directoryroledefinition.NewDirectoryRoleDefinitionClientWithBaseURI(o.Environment.MicrosoftGraph)
+	directoryRoleClient, err := directoryrole.NewDirectoryRoleClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
 	}
-	o.Configure(directoryRoleDefinitionClient.Client)
+	o.Configure(directoryRoleClient.Client)
 
-	directoryRoleMemberClient, err := member.NewMemberClientWithBaseURI(o.Environment.MicrosoftGraph)
+	directoryRoleDefinitionClient, err := directoryroledefinition.NewDirectoryRoleDefinitionClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
 	}
-	o.Configure(directoryRoleMemberClient.Client)
+	o.Configure(directoryRoleDefinitionClient.Client)
 
 	directoryRoleEligibilityScheduleRequestClient, err := directoryroleeligibilityschedulerequest.NewDirectoryRoleEligibilityScheduleRequestClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
@@ -61,6 +63,12 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(directoryRoleEligibilityScheduleRequestClient.Client)
 
+	directoryRoleMemberClient, err := member.NewMemberClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(directoryRoleMemberClient.Client)
+
 	directoryRoleTemplateClient, err := directoryroletemplate.NewDirectoryRoleTemplateClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
@@ -70,6 +78,7 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	return &Client{
 		DirectoryObjectClient:                         directoryObjectClient,
 		DirectoryRoleAssignmentClient:                 directoryRoleAssignmentClient,
+		DirectoryRoleAssignmentScheduleRequestClient:  directoryRoleAssignmentScheduleRequestClient,
 		DirectoryRoleClient:                           directoryRoleClient,
 		DirectoryRoleDefinitionClient:                 directoryRoleDefinitionClient,
 		DirectoryRoleEligibilityScheduleRequestClient: directoryRoleEligibilityScheduleRequestClient,
@@ -3,4 +3,12 @@
 
 package directoryroles
 
+import "github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+
 const directoryRoleMemberResourceName = "azuread_directory_role_member"
+
+var possibleValuesForScheduleExpirationType = []string{
+	string(stable.ExpirationPatternType_AfterDateTime),
+	string(stable.ExpirationPatternType_AfterDuration),
+	string(stable.ExpirationPatternType_NoExpiration),
+}
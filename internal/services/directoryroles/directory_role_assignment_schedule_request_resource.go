@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package directoryroles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/rolemanagement/stable/directoryroleassignmentschedulerequest"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+)
+
+func directoryRoleAssignmentScheduleRequestResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: directoryRoleAssignmentScheduleRequestResourceCreate,
+		ReadContext:   directoryRoleAssignmentScheduleRequestResourceRead,
+		UpdateContext: directoryRoleAssignmentScheduleRequestResourceUpdate,
+		DeleteContext: directoryRoleAssignmentScheduleRequestResourceDelete,
+
+		CustomizeDiff: directoryRoleScheduleRequestCustomizeDiff,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(10 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(10 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			if _, err := uuid.ParseUUID(id); err != nil {
+				return fmt.Errorf("specified ID (%q) is not valid: %s", id, err)
+			}
+			return nil
+		}),
+
+		Schema: directoryRoleScheduleRequestSchema("assignment"),
+	}
+}
+
+func directoryRoleAssignmentScheduleRequestResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	schedule, err := expandDirectoryRoleScheduleRequestSchedule(d, false)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "schedule", "Building schedule for assignment schedule request")
+	}
+
+	if diags := directoryRoleAssignmentScheduleRequestSubmit(ctx, d, meta, stable.UnifiedRoleScheduleRequestActions_AdminAssign, schedule); diags != nil {
+		return diags
+	}
+
+	return directoryRoleAssignmentScheduleRequestResourceRead(ctx, d, meta)
+}
+
+func directoryRoleAssignmentScheduleRequestResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	action := directoryRoleScheduleRequestUpdateAction(d)
+
+	schedule, err := expandDirectoryRoleScheduleRequestSchedule(d, action != stable.UnifiedRoleScheduleRequestActions_AdminUpdate)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "schedule", "Building schedule for assignment schedule request")
+	}
+
+	if diags := directoryRoleAssignmentScheduleRequestSubmit(ctx, d, meta, action, schedule); diags != nil {
+		return diags
+	}
+
+	return directoryRoleAssignmentScheduleRequestResourceRead(ctx, d, meta)
+}
+
+// directoryRoleAssignmentScheduleRequestSubmit creates a new schedule request with the specified action. Schedule
+// requests are immutable, so the resource ID is updated to refer to the new request.
+func directoryRoleAssignmentScheduleRequestSubmit(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, action stable.UnifiedRoleScheduleRequestActions, schedule *stable.RequestSchedule) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).DirectoryRoles.DirectoryRoleAssignmentScheduleRequestClient
+
+	roleDefinitionId := d.Get("role_definition_id").(string)
+	principalId := d.Get("principal_id").(string)
+
+	properties := stable.UnifiedRoleAssignmentScheduleRequest{
+		Action:           pointer.To(action),
+		RoleDefinitionId: nullable.Value(roleDefinitionId),
+		PrincipalId:      nullable.Value(principalId),
+		Justification:    nullable.Value(d.Get("justification").(string)),
+		DirectoryScopeId: nullable.Value(d.Get("directory_scope_id").(string)),
+		ScheduleInfo:     schedule,
+		TicketInfo:       expandDirectoryRoleScheduleRequestTicketInfo(d.Get("ticket_info").([]interface{})),
+	}
+
+	options := directoryroleassignmentschedulerequest.CreateDirectoryRoleAssignmentScheduleRequestOperationOptions{
+		RetryFunc: func(resp *http.Response, o *odata.OData) (bool, error) {
+			if response.WasNotFound(resp) && o.Error != nil {
+				return o.Error.Match("RoleNotFound") || o.Error.Match("SubjectNotFound"), nil
+			}
+			return false, nil
+		},
+	}
+
+	resp, err := client.CreateDirectoryRoleAssignmentScheduleRequest(ctx, properties, options)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Submitting %s assignment schedule request for role %q to principal %q: %+v", action, roleDefinitionId, principalId, err)
+	}
+
+	roleAssignmentScheduleRequest := resp.Model
+	if roleAssignmentScheduleRequest == nil || roleAssignmentScheduleRequest.Id == nil {
+		return tf.ErrorDiagF(errors.New("returned role roleAssignmentScheduleRequest ID was nil"), "API Error")
+	}
+
+	id := stable.NewRoleManagementDirectoryRoleAssignmentScheduleRequestID(*roleAssignmentScheduleRequest.Id)
+	d.SetId(id.UnifiedRoleAssignmentScheduleRequestId)
+
+	if err = consistency.WaitForUpdate(ctx, func(ctx context.Context) (*bool, error) {
+		resp, err := client.GetDirectoryRoleAssignmentScheduleRequest(ctx, id, directoryroleassignmentschedulerequest.DefaultGetDirectoryRoleAssignmentScheduleRequestOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, err
+		}
+		return pointer.To(resp.Model != nil), nil
+	}); err != nil {
+		return tf.ErrorDiagF(err, "Waiting for role assignment schedule request for %q to be created for directory role %q", principalId, roleDefinitionId)
+	}
+
+	return nil
+}
+
+func directoryRoleAssignmentScheduleRequestResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).DirectoryRoles.DirectoryRoleAssignmentScheduleRequestClient
+	id := stable.NewRoleManagementDirectoryRoleAssignmentScheduleRequestID(d.Id())
+
+	resp, err := client.GetDirectoryRoleAssignmentScheduleRequest(ctx, id, directoryroleassignmentschedulerequest.DefaultGetDirectoryRoleAssignmentScheduleRequestOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	roleAssignmentScheduleRequest := resp.Model
+	if roleAssignmentScheduleRequest == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "API Error")
+	}
+
+	tf.Set(d, "role_definition_id", roleAssignmentScheduleRequest.RoleDefinitionId.GetOrZero())
+	tf.Set(d, "principal_id", roleAssignmentScheduleRequest.PrincipalId.GetOrZero())
+	tf.Set(d, "justification", roleAssignmentScheduleRequest.Justification.GetOrZero())
+	tf.Set(d, "directory_scope_id", roleAssignmentScheduleRequest.DirectoryScopeId.GetOrZero())
+	tf.Set(d, "status", pointer.From(roleAssignmentScheduleRequest.Status))
+	tf.Set(d, "ticket_info", flattenDirectoryRoleScheduleRequestTicketInfo(roleAssignmentScheduleRequest.TicketInfo))
+
+	if err = setDirectoryRoleScheduleRequestSchedule(d, roleAssignmentScheduleRequest.ScheduleInfo); err != nil {
+		return tf.ErrorDiagPathF(err, "schedule", "Flattening schedule for %s", id)
+	}
+
+	return nil
+}
+
+func directoryRoleAssignmentScheduleRequestResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).DirectoryRoles.DirectoryRoleAssignmentScheduleRequestClient
+	id := stable.NewRoleManagementDirectoryRoleAssignmentScheduleRequestID(d.Id())
+
+	resp, err := client.GetDirectoryRoleAssignmentScheduleRequest(ctx, id, directoryroleassignmentschedulerequest.DefaultGetDirectoryRoleAssignmentScheduleRequestOperationOptions())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving %s", id)
+	}
+
+	roleAssignmentScheduleRequest := resp.Model
+	if roleAssignmentScheduleRequest == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "API Error")
+	}
+
+	properties := stable.UnifiedRoleAssignmentScheduleRequest{
+		Action:           pointer.To(stable.UnifiedRoleScheduleRequestActions_AdminRemove),
+		RoleDefinitionId: roleAssignmentScheduleRequest.RoleDefinitionId,
+		PrincipalId:      roleAssignmentScheduleRequest.PrincipalId,
+		Justification:    roleAssignmentScheduleRequest.Justification,
+		DirectoryScopeId: roleAssignmentScheduleRequest.DirectoryScopeId,
+	}
+
+	if _, err = client.CreateDirectoryRoleAssignmentScheduleRequest(ctx, properties, directoryroleassignmentschedulerequest.DefaultCreateDirectoryRoleAssignmentScheduleRequestOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Removing role assignment schedule request %q: %+v", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package directoryroles_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/rolemanagement/stable/directoryroleassignmentschedulerequest"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type RoleAssignmentScheduleRequestResource struct{}
+
+func TestAccRoleAssignmentScheduleRequest_builtin(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_assignment_schedule_request", "test")
+	r := RoleAssignmentScheduleRequestResource{}
+
+	data.ResourceTestIgnoreDangling(t, r, []acceptance.TestStep{
+		{
+			Config: r.builtin(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+	})
+}
+
+func TestAccRoleAssignmentScheduleRequest_schedule(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_assignment_schedule_request", "test")
+	r := RoleAssignmentScheduleRequestResource{}
+
+	data.ResourceTestIgnoreDangling(t, r, []acceptance.TestStep{
+		{
+			Config: r.schedule(data, "P90D"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("schedule.0.expiration_type").HasValue("afterDuration"),
+				check.That(data.ResourceName).Key("effective_expiration_date").Exists(),
+				check.That(data.ResourceName).Key("ticket_info.0.ticket_number").HasValue("CHG-1234"),
+			),
+		},
+		{
+			Config: r.schedule(data, "P180D"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("schedule.0.duration").HasValue("P180D"),
+			),
+		},
+	})
+}
+
+func (r RoleAssignmentScheduleRequestResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.DirectoryRoles.DirectoryRoleAssignmentScheduleRequestClient
+	id := stable.NewRoleManagementDirectoryRoleAssignmentScheduleRequestID(state.ID)
+
+	resp, err := client.GetDirectoryRoleAssignmentScheduleRequest(ctx, id, directoryroleassignmentschedulerequest.DefaultGetDirectoryRoleAssignmentScheduleRequestOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %+v", id, err)
+	}
+
+	return pointer.To(true), nil
+}
+
+func (r RoleAssignmentScheduleRequestResource) builtin(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestManager.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestManager-%[1]d"
+  password            = "%[2]s"
+}
+
+resource "azuread_directory_role" "test" {
+  display_name = "Application Administrator"
+}
+
+resource "azuread_directory_role_assignment_schedule_request" "test" {
+  role_definition_id = azuread_directory_role.test.template_id
+  principal_id       = azuread_user.test.object_id
+  directory_scope_id = "/"
+  justification      = "abc"
+}
+`, data.RandomInteger, data.RandomPassword)
+}
+
+func (r RoleAssignmentScheduleRequestResource) schedule(data acceptance.TestData, duration string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestManager.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestManager-%[1]d"
+  password            = "%[2]s"
+}
+
+resource "azuread_directory_role" "test" {
+  display_name = "Application Administrator"
+}
+
+resource "azuread_directory_role_assignment_schedule_request" "test" {
+  role_definition_id = azuread_directory_role.test.template_id
+  principal_id       = azuread_user.test.object_id
+  directory_scope_id = "/"
+  justification      = "abc"
+  renew_before       = "P14D"
+
+  schedule {
+    duration = "%[3]s"
+  }
+
+  ticket_info {
+    ticket_number = "CHG-1234"
+    ticket_system = "ServiceNow"
+  }
+}
+`, data.RandomInteger, data.RandomPassword, duration)
+}
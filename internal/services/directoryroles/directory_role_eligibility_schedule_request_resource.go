@@ -22,18 +22,21 @@ import (
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/consistency"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
-	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
 )
 
 func directoryRoleEligibilityScheduleRequestResource() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		CreateContext: directoryRoleEligibilityScheduleRequestResourceCreate,
 		ReadContext:   directoryRoleEligibilityScheduleRequestResourceRead,
+		UpdateContext: directoryRoleEligibilityScheduleRequestResourceUpdate,
 		DeleteContext: directoryRoleEligibilityScheduleRequestResourceDelete,
 
+		CustomizeDiff: directoryRoleScheduleRequestCustomizeDiff,
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(10 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(10 * time.Minute),
 			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
 		},
 
@@ -44,62 +47,54 @@ func directoryRoleEligibilityScheduleRequestResource() *pluginsdk.Resource {
 			return nil
 		}),
 
-		Schema: map[string]*pluginsdk.Schema{
-			"role_definition_id": {
-				Description:  "The object ID of the directory role for this role eligibility schedule request",
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.IsUUID,
-			},
-
-			"principal_id": {
-				Description:  "The object ID of the member principal",
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.IsUUID,
-			},
-
-			"directory_scope_id": {
-				Description:  "Identifier of the directory object representing the scope of the role eligibility schedule request",
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-
-			"justification": {
-				Description:  "Justification for why the role is assigned",
-				Type:         pluginsdk.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-		},
+		Schema: directoryRoleScheduleRequestSchema("eligibility"),
 	}
 }
 
 func directoryRoleEligibilityScheduleRequestResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	schedule, err := expandDirectoryRoleScheduleRequestSchedule(d, false)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "schedule", "Building schedule for eligibility schedule request")
+	}
+
+	if diags := directoryRoleEligibilityScheduleRequestSubmit(ctx, d, meta, stable.UnifiedRoleScheduleRequestActions_AdminAssign, schedule); diags != nil {
+		return diags
+	}
+
+	return directoryRoleEligibilityScheduleRequestResourceRead(ctx, d, meta)
+}
+
+func directoryRoleEligibilityScheduleRequestResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	action := directoryRoleScheduleRequestUpdateAction(d)
+
+	schedule, err := expandDirectoryRoleScheduleRequestSchedule(d, action != stable.UnifiedRoleScheduleRequestActions_AdminUpdate)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "schedule", "Building schedule for eligibility schedule request")
+	}
+
+	if diags := directoryRoleEligibilityScheduleRequestSubmit(ctx, d, meta, action, schedule); diags != nil {
+		return diags
+	}
+
+	return directoryRoleEligibilityScheduleRequestResourceRead(ctx, d, meta)
+}
+
+// directoryRoleEligibilityScheduleRequestSubmit creates a new schedule request with the specified action. Schedule
+// requests are immutable, so the resource ID is updated to refer to the new request.
+func directoryRoleEligibilityScheduleRequestSubmit(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, action stable.UnifiedRoleScheduleRequestActions, schedule *stable.RequestSchedule) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).DirectoryRoles.DirectoryRoleEligibilityScheduleRequestClient
 
 	roleDefinitionId := d.Get("role_definition_id").(string)
 	principalId := d.Get("principal_id").(string)
-	justification := d.Get("justification").(string)
-	directoryScopeId := d.Get("directory_scope_id").(string)
 
 	properties := stable.UnifiedRoleEligibilityScheduleRequest{
-		Action:           pointer.To(stable.UnifiedRoleScheduleRequestActions_AdminAssign),
+		Action:           pointer.To(action),
 		RoleDefinitionId: nullable.Value(roleDefinitionId),
 		PrincipalId:      nullable.Value(principalId),
-		Justification:    nullable.Value(justification),
-		DirectoryScopeId: nullable.Value(directoryScopeId),
-		ScheduleInfo: &stable.RequestSchedule{
-			StartDateTime: nullable.Value(time.Now().Format(time.RFC3339)),
-			Expiration: &stable.ExpirationPattern{
-				Type: pointer.To(stable.ExpirationPatternType_NoExpiration),
-			},
-		},
+		Justification:    nullable.Value(d.Get("justification").(string)),
+		DirectoryScopeId: nullable.Value(d.Get("directory_scope_id").(string)),
+		ScheduleInfo:     schedule,
+		TicketInfo:       expandDirectoryRoleScheduleRequestTicketInfo(d.Get("ticket_info").([]interface{})),
 	}
 
 	options := directoryroleeligibilityschedulerequest.CreateDirectoryRoleEligibilityScheduleRequestOperationOptions{
@@ -113,7 +108,7 @@ func directoryRoleEligibilityScheduleRequestResourceCreate(ctx context.Context,
 
 	resp, err := client.CreateDirectoryRoleEligibilityScheduleRequest(ctx, properties, options)
 	if err != nil {
-		return tf.ErrorDiagF(err, "Creating eligibility schedule request for role %q to principal %q: %+v", roleDefinitionId, principalId, err)
+		return tf.ErrorDiagF(err, "Submitting %s eligibility schedule request for role %q to principal %q: %+v", action, roleDefinitionId, principalId, err)
 	}
 
 	roleEligibilityScheduleRequest := resp.Model
@@ -137,7 +132,7 @@ func directoryRoleEligibilityScheduleRequestResourceCreate(ctx context.Context,
 		return tf.ErrorDiagF(err, "Waiting for role eligibility schedule request for %q to be created for directory role %q", principalId, roleDefinitionId)
 	}
 
-	return directoryRoleEligibilityScheduleRequestResourceRead(ctx, d, meta)
+	return nil
 }
 
 func directoryRoleEligibilityScheduleRequestResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
@@ -163,6 +158,12 @@ func directoryRoleEligibilityScheduleRequestResourceRead(ctx context.Context, d
 	tf.Set(d, "principal_id", roleEligibilityScheduleRequest.PrincipalId.GetOrZero())
 	tf.Set(d, "justification", roleEligibilityScheduleRequest.Justification.GetOrZero())
 	tf.Set(d, "directory_scope_id", roleEligibilityScheduleRequest.DirectoryScopeId.GetOrZero())
+	tf.Set(d, "status", pointer.From(roleEligibilityScheduleRequest.Status))
+	tf.Set(d, "ticket_info", flattenDirectoryRoleScheduleRequestTicketInfo(roleEligibilityScheduleRequest.TicketInfo))
+
+	if err = setDirectoryRoleScheduleRequestSchedule(d, roleEligibilityScheduleRequest.ScheduleInfo); err != nil {
+		return tf.ErrorDiagPathF(err, "schedule", "Flattening schedule for %s", id)
+	}
 
 	return nil
 }
@@ -34,6 +34,30 @@ func TestAccRoleEligibilityScheduleRequest_builtin(t *testing.T) {
 	})
 }
 
+func TestAccRoleEligibilityScheduleRequest_schedule(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_eligibility_schedule_request", "test")
+	r := RoleEligibilityScheduleRequestResource{}
+
+	data.ResourceTestIgnoreDangling(t, r, []acceptance.TestStep{
+		{
+			Config: r.schedule(data, "P90D"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("schedule.0.expiration_type").HasValue("afterDuration"),
+				check.That(data.ResourceName).Key("effective_expiration_date").Exists(),
+				check.That(data.ResourceName).Key("ticket_info.0.ticket_number").HasValue("CHG-1234"),
+			),
+		},
+		{
+			Config: r.schedule(data, "P180D"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("schedule.0.duration").HasValue("P180D"),
+			),
+		},
+	})
+}
+
 func (r RoleEligibilityScheduleRequestResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.DirectoryRoles.DirectoryRoleEligibilityScheduleRequestClient
 	id := stable.NewRoleManagementDirectoryRoleEligibilityScheduleRequestID(state.ID)
@@ -75,3 +99,40 @@ resource "azuread_directory_role_eligibility_schedule_request" "test" {
 }
 `, data.RandomInteger, data.RandomPassword)
 }
+
+func (r RoleEligibilityScheduleRequestResource) schedule(data acceptance.TestData, duration string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestManager.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestManager-%[1]d"
+  password            = "%[2]s"
+}
+
+resource "azuread_directory_role" "test" {
+  display_name = "Application Administrator"
+}
+
+resource "azuread_directory_role_eligibility_schedule_request" "test" {
+  role_definition_id = azuread_directory_role.test.template_id
+  principal_id       = azuread_user.test.object_id
+  directory_scope_id = "/"
+  justification      = "abc"
+  renew_before       = "P14D"
+
+  schedule {
+    duration = "%[3]s"
+  }
+
+  ticket_info {
+    ticket_number = "CHG-1234"
+    ticket_system = "ServiceNow"
+  }
+}
+`, data.RandomInteger, data.RandomPassword, duration)
+}
@@ -0,0 +1,293 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package directoryroles
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/iso8601"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/requestschedule"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+// directoryRoleScheduleRequestSchema returns the schema shared by directory role assignment and eligibility schedule requests
+func directoryRoleScheduleRequestSchema(kind string) map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"role_definition_id": {
+			Description:  fmt.Sprintf("The object ID of the directory role for this role %s schedule request", kind),
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"principal_id": {
+			Description:  "The object ID of the member principal",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"directory_scope_id": {
+			Description:  fmt.Sprintf("Identifier of the directory object representing the scope of the role %s schedule request", kind),
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"justification": {
+			Description:  "Justification for why the role is assigned",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"schedule": {
+			Description: fmt.Sprintf("The period during which the role %s is valid", kind),
+			Type:        pluginsdk.TypeList,
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"start_date": {
+						Description:  "The date from which the schedule is valid, formatted as an RFC3339 date string in UTC (e.g. 2018-01-01T01:02:03Z)",
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.IsRFC3339Time,
+					},
+
+					"expiration_date": {
+						Description:   "The date at which the schedule expires, formatted as an RFC3339 date string in UTC (e.g. 2018-01-01T01:02:03Z)",
+						Type:          pluginsdk.TypeString,
+						Optional:      true,
+						ConflictsWith: []string{"schedule.0.duration"},
+						ValidateFunc:  validation.IsRFC3339Time,
+						DiffSuppressFunc: func(k, old, new string, d *pluginsdk.ResourceData) bool {
+							// The API may report an expiration date for schedules with a relative expiry
+							return new == "" && d.Get("schedule.0.duration").(string) != ""
+						},
+					},
+
+					"duration": {
+						Description:   "The duration of the schedule, formatted as an ISO8601 duration string (e.g. P90D for 90 days)",
+						Type:          pluginsdk.TypeString,
+						Optional:      true,
+						ConflictsWith: []string{"schedule.0.expiration_date"},
+						ValidateFunc:  iso8601.ValidateDuration,
+					},
+
+					"expiration_type": {
+						Description:  "How the schedule expires",
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.StringInSlice(possibleValuesForScheduleExpirationType, false),
+					},
+				},
+			},
+		},
+
+		"renew_before": {
+			Description:  "When the schedule expires within this duration, it is extended on the next apply. Formatted as an ISO8601 duration string (e.g. P7D for 7 days)",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: iso8601.ValidateDuration,
+		},
+
+		"ticket_info": {
+			Description: fmt.Sprintf("Ticket details linked to the role %s schedule request", kind),
+			Type:        pluginsdk.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"ticket_number": {
+						Description:  "The ticket number",
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"ticket_system": {
+						Description:  "The description of the ticket system",
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+
+		"effective_expiration_date": {
+			Description: "The date at which the schedule currently expires, formatted as an RFC3339 date string",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"status": {
+			Description: "The status of the schedule request",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+// directoryRoleScheduleRequestCustomizeDiff schedules a renewal when a schedule is due to expire within the `renew_before` window
+func directoryRoleScheduleRequestCustomizeDiff(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || diff.HasChange("schedule") {
+		return nil
+	}
+
+	renewBefore := diff.Get("renew_before").(string)
+	effectiveExpirationDate := diff.Get("effective_expiration_date").(string)
+	if renewBefore == "" || effectiveExpirationDate == "" {
+		return nil
+	}
+
+	// Only schedules with a relative expiry can be renewed without changing the configuration
+	if schedule := expandDirectoryRoleScheduleRequestScheduleModel(diff.Get("schedule").([]interface{})); schedule.Duration == "" {
+		return nil
+	}
+
+	window, err := iso8601.ParseDuration(renewBefore)
+	if err != nil {
+		return fmt.Errorf("parsing `renew_before`: %+v", err)
+	}
+
+	expiry, err := time.Parse(time.RFC3339, effectiveExpirationDate)
+	if err != nil {
+		return fmt.Errorf("parsing `effective_expiration_date`: %+v", err)
+	}
+
+	if time.Now().Add(window).After(expiry) {
+		return diff.SetNewComputed("effective_expiration_date")
+	}
+
+	return nil
+}
+
+// directoryRoleScheduleRequestUpdateAction determines whether a schedule should be updated, extended or renewed
+func directoryRoleScheduleRequestUpdateAction(d *pluginsdk.ResourceData) stable.UnifiedRoleScheduleRequestActions {
+	if d.HasChange("schedule") {
+		return stable.UnifiedRoleScheduleRequestActions_AdminUpdate
+	}
+
+	oldExpiry, _ := d.GetChange("effective_expiration_date")
+	if expiry, err := time.Parse(time.RFC3339, oldExpiry.(string)); err == nil && expiry.Before(time.Now()) {
+		return stable.UnifiedRoleScheduleRequestActions_AdminRenew
+	}
+
+	return stable.UnifiedRoleScheduleRequestActions_AdminExtend
+}
+
+func expandDirectoryRoleScheduleRequestScheduleModel(input []interface{}) requestschedule.Schedule {
+	if len(input) == 0 || input[0] == nil {
+		return requestschedule.Schedule{}
+	}
+	in := input[0].(map[string]interface{})
+
+	return requestschedule.Schedule{
+		StartDate:      in["start_date"].(string),
+		ExpirationDate: in["expiration_date"].(string),
+		Duration:       in["duration"].(string),
+		ExpirationType: in["expiration_type"].(string),
+	}
+}
+
+// expandDirectoryRoleScheduleRequestSchedule builds the schedule for a new request. When renewing a schedule with a
+// relative expiry, the schedule is restarted from the current time.
+func expandDirectoryRoleScheduleRequestSchedule(d *pluginsdk.ResourceData, renew bool) (*stable.RequestSchedule, error) {
+	schedule := expandDirectoryRoleScheduleRequestScheduleModel(d.Get("schedule").([]interface{}))
+
+	switch {
+	case schedule.ExpirationDate != "" && schedule.Duration != "":
+		// Both are only present when reported by the API for a schedule with a relative expiry
+		schedule.ExpirationDate = ""
+		schedule.ExpirationType = ""
+	case schedule.ExpirationDate != "" || schedule.Duration != "":
+		// The expiration type is inferred, since it may have been computed for a previous schedule
+		schedule.ExpirationType = ""
+	case schedule.ExpirationType == "":
+		// Schedule requests without a schedule never expire
+		schedule.ExpirationType = string(stable.ExpirationPatternType_NoExpiration)
+	}
+
+	if schedule.StartDate == "" || renew {
+		schedule.StartDate = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	return requestschedule.Expand(schedule, d.HasChange("schedule.0.expiration_date"))
+}
+
+func flattenDirectoryRoleScheduleRequestSchedule(input *stable.RequestSchedule) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	schedule := requestschedule.Flatten(input)
+
+	return []interface{}{
+		map[string]interface{}{
+			"start_date":      schedule.StartDate,
+			"expiration_date": schedule.ExpirationDate,
+			"duration":        schedule.Duration,
+			"expiration_type": schedule.ExpirationType,
+		},
+	}
+}
+
+func setDirectoryRoleScheduleRequestSchedule(d *pluginsdk.ResourceData, input *stable.RequestSchedule) error {
+	tf.Set(d, "schedule", flattenDirectoryRoleScheduleRequestSchedule(input))
+
+	effectiveExpirationDate := ""
+	expiry, err := requestschedule.EndDate(input)
+	if err != nil {
+		return err
+	}
+	if expiry != nil {
+		effectiveExpirationDate = expiry.UTC().Format(time.RFC3339)
+	}
+	tf.Set(d, "effective_expiration_date", effectiveExpirationDate)
+
+	return nil
+}
+
+func expandDirectoryRoleScheduleRequestTicketInfo(input []interface{}) *stable.TicketInfo {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+	in := input[0].(map[string]interface{})
+
+	return &stable.TicketInfo{
+		TicketNumber: nullable.NoZero(in["ticket_number"].(string)),
+		TicketSystem: nullable.NoZero(in["ticket_system"].(string)),
+	}
+}
+
+func flattenDirectoryRoleScheduleRequestTicketInfo(input *stable.TicketInfo) []interface{} {
+	if input == nil || (input.TicketNumber.GetOrZero() == "" && input.TicketSystem.GetOrZero() == "") {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"ticket_number": input.TicketNumber.GetOrZero(),
+			"ticket_system": input.TicketSystem.GetOrZero(),
+		},
+	}
+}
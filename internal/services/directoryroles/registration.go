@@ -40,6 +40,7 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
 		"azuread_custom_directory_role":                       customDirectoryRoleResource(),
 		"azuread_directory_role_assignment":                   directoryRoleAssignmentResource(),
+		"azuread_directory_role_assignment_schedule_request":  directoryRoleAssignmentScheduleRequestResource(),
 		"azuread_directory_role_member":                       directoryRoleMemberResource(),
 		"azuread_directory_role_eligibility_schedule_request": directoryRoleEligibilityScheduleRequestResource(),
 	}
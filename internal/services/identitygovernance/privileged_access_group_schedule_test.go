@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package identitygovernance
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+)
+
+func TestBuildScheduleRequestExpirationDate(t *testing.T) {
+	const pastExpirationDate = "2020-01-01T00:00:00Z"
+
+	model := PrivilegedAccessGroupScheduleModel{
+		ExpirationDate: pastExpirationDate,
+	}
+
+	// A new expiration date in the past is rejected
+	d := schema.TestResourceDataRaw(t, privilegedAccessGroupScheduleArguments(), map[string]interface{}{
+		"expiration_date": pastExpirationDate,
+	})
+	_, err := buildScheduleRequest(&model, &sdk.ResourceMetaData{ResourceData: d})
+	if err == nil {
+		t.Fatalf("expected an error for a changed expiration date in the past")
+	}
+	if !strings.Contains(err.Error(), "at least 5 minutes in the future") {
+		t.Fatalf("unexpected error for a changed expiration date in the past: %+v", err)
+	}
+
+	// An unchanged expiration date which has since passed is accepted, so that other properties can still be updated
+	d = (&schema.Resource{Schema: privilegedAccessGroupScheduleArguments()}).Data(&terraform.InstanceState{
+		ID: "00000000-0000-0000-0000-000000000000",
+		Attributes: map[string]string{
+			"expiration_date": pastExpirationDate,
+		},
+	})
+	if _, err = buildScheduleRequest(&model, &sdk.ResourceMetaData{ResourceData: d}); err != nil {
+		t.Fatalf("unexpected error for an unchanged expiration date in the past: %+v", err)
+	}
+}
+
+func TestBuildScheduleRequestNoExpiration(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, privilegedAccessGroupScheduleArguments(), map[string]interface{}{})
+
+	if _, err := buildScheduleRequest(&PrivilegedAccessGroupScheduleModel{}, &sdk.ResourceMetaData{ResourceData: d}); err == nil || !strings.Contains(err.Error(), "`permanent_assignment` must be true") {
+		t.Fatalf("expected an error requiring `permanent_assignment`, got %+v", err)
+	}
+
+	if _, err := buildScheduleRequest(&PrivilegedAccessGroupScheduleModel{PermanentAssignment: true}, &sdk.ResourceMetaData{ResourceData: d}); err != nil {
+		t.Fatalf("unexpected error for a permanent assignment: %+v", err)
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/adminconsentrequestpolicy"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/policies/parse"
+)
+
+type AdminConsentRequestPolicyModel struct {
+	Enabled               bool                                     `tfschema:"enabled"`
+	NotifyReviewers       bool                                     `tfschema:"notify_reviewers"`
+	RemindersEnabled      bool                                     `tfschema:"reminders_enabled"`
+	RequestDurationInDays int64                                    `tfschema:"request_duration_in_days"`
+	Reviewers             []AdminConsentRequestPolicyReviewerModel `tfschema:"reviewer"`
+	Version               int64                                    `tfschema:"version"`
+}
+
+type AdminConsentRequestPolicyReviewerModel struct {
+	Query     string `tfschema:"query"`
+	QueryRoot string `tfschema:"query_root"`
+	QueryType string `tfschema:"query_type"`
+}
+
+var _ sdk.ResourceWithUpdate = AdminConsentRequestPolicyResource{}
+var _ sdk.ResourceWithCustomizeDiff = AdminConsentRequestPolicyResource{}
+
+type AdminConsentRequestPolicyResource struct{}
+
+func (r AdminConsentRequestPolicyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateAdminConsentRequestPolicyID
+}
+
+func (r AdminConsentRequestPolicyResource) ResourceType() string {
+	return "azuread_admin_consent_request_policy"
+}
+
+func (r AdminConsentRequestPolicyResource) ModelObject() interface{} {
+	return &AdminConsentRequestPolicyModel{}
+}
+
+func (r AdminConsentRequestPolicyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"enabled": {
+			Description: "Whether users can request admin consent for applications which they are unable to consent to",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+
+		"notify_reviewers": {
+			Description: "Whether reviewers will receive notifications when a new request is created",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+
+		"reminders_enabled": {
+			Description: "Whether reviewers will receive reminder emails for pending requests",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+
+		"request_duration_in_days": {
+			Description:  "The number of days a request remains active before it expires",
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      30,
+			ValidateFunc: validation.IntBetween(1, 30),
+		},
+
+		"reviewer": {
+			Description: "A reviewer who can approve or deny admin consent requests",
+			Type:        pluginsdk.TypeList,
+			Optional:    true,
+			MaxItems:    25,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"query": {
+						Description:  "A Microsoft Graph query identifying the reviewers, e.g. `/users/{userId}` or `/groups/{groupId}/transitiveMembers`",
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"query_root": {
+						Description:  "The root from which the query is evaluated",
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"query_type": {
+						Description:  "The type of query",
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Default:      "MicrosoftGraph",
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r AdminConsentRequestPolicyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"version": {
+			Description: "The version of the policy, which is incremented each time the policy is updated",
+			Type:        pluginsdk.TypeInt,
+			Computed:    true,
+		},
+	}
+}
+
+func (r AdminConsentRequestPolicyResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model AdminConsentRequestPolicyModel
+			if err := metadata.DecodeDiff(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if model.Enabled && len(model.Reviewers) == 0 {
+				return errors.New("at least one `reviewer` must be specified when the admin consent request policy is enabled")
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r AdminConsentRequestPolicyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.AdminConsentRequestPolicyClient
+			id := parse.NewAdminConsentRequestPolicyID()
+
+			var model AdminConsentRequestPolicyModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// The admin consent request policy always exists, so creating this resource adopts it and applies the desired configuration
+			if _, err := client.UpdateAdminConsentRequestPolicy(ctx, expandAdminConsentRequestPolicy(model), adminconsentrequestpolicy.DefaultUpdateAdminConsentRequestPolicyOperationOptions()); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			return nil
+		},
+	}
+}
+
+func (r AdminConsentRequestPolicyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.AdminConsentRequestPolicyClient
+
+			id, err := parse.ParseAdminConsentRequestPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetAdminConsentRequestPolicy(ctx, adminconsentrequestpolicy.DefaultGetAdminConsentRequestPolicyOperationOptions())
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			policy := resp.Model
+			if policy == nil {
+				return fmt.Errorf("retrieving %s: model was nil", id)
+			}
+
+			state := AdminConsentRequestPolicyModel{
+				Enabled:               policy.IsEnabled,
+				NotifyReviewers:       policy.NotifyReviewers,
+				RemindersEnabled:      policy.RemindersEnabled,
+				RequestDurationInDays: pointer.From(policy.RequestDurationInDays),
+				Reviewers:             make([]AdminConsentRequestPolicyReviewerModel, 0),
+				Version:               pointer.From(policy.Version),
+			}
+
+			for _, reviewer := range policy.Reviewers {
+				state.Reviewers = append(state.Reviewers, AdminConsentRequestPolicyReviewerModel{
+					Query:     reviewer.Query.GetOrZero(),
+					QueryRoot: reviewer.QueryRoot.GetOrZero(),
+					QueryType: reviewer.QueryType.GetOrZero(),
+				})
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r AdminConsentRequestPolicyResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.AdminConsentRequestPolicyClient
+
+			id, err := parse.ParseAdminConsentRequestPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model AdminConsentRequestPolicyModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// All properties are required by the API, so the policy is always updated in full
+			if _, err = client.UpdateAdminConsentRequestPolicy(ctx, expandAdminConsentRequestPolicy(model), adminconsentrequestpolicy.DefaultUpdateAdminConsentRequestPolicyOperationOptions()); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r AdminConsentRequestPolicyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.AdminConsentRequestPolicyClient
+
+			id, err := parse.ParseAdminConsentRequestPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// The admin consent request policy cannot be deleted, so instead we disable it and remove all reviewers
+			properties := stable.AdminConsentRequestPolicy{
+				IsEnabled:             false,
+				NotifyReviewers:       false,
+				RemindersEnabled:      false,
+				RequestDurationInDays: pointer.To(int64(30)),
+				Reviewers:             []stable.AccessReviewReviewerScope{},
+			}
+
+			if _, err = client.UpdateAdminConsentRequestPolicy(ctx, properties, adminconsentrequestpolicy.DefaultUpdateAdminConsentRequestPolicyOperationOptions()); err != nil {
+				return fmt.Errorf("resetting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandAdminConsentRequestPolicy(model AdminConsentRequestPolicyModel) stable.AdminConsentRequestPolicy {
+	properties := stable.AdminConsentRequestPolicy{
+		IsEnabled:             model.Enabled,
+		NotifyReviewers:       model.NotifyReviewers,
+		RemindersEnabled:      model.RemindersEnabled,
+		RequestDurationInDays: pointer.To(model.RequestDurationInDays),
+		Reviewers:             make([]stable.AccessReviewReviewerScope, 0),
+	}
+
+	for _, reviewer := range model.Reviewers {
+		properties.Reviewers = append(properties.Reviewers, stable.AccessReviewReviewerScope{
+			Query:     nullable.Value(reviewer.Query),
+			QueryRoot: nullable.NoZero(reviewer.QueryRoot),
+			QueryType: nullable.NoZero(reviewer.QueryType),
+		})
+	}
+
+	return properties
+}
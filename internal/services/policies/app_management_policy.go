@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	servicePrincipalAppManagementPolicy "github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/appmanagementpolicy"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/iso8601"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type AppManagementPolicyCredentialRestrictionModel struct {
+	MaxLifetime                 string `tfschema:"max_lifetime"`
+	RestrictForAppsCreatedAfter string `tfschema:"restrict_for_apps_created_after"`
+	RestrictionType             string `tfschema:"restriction_type"`
+}
+
+// Restriction types which require a `max_lifetime`, all other types are outright blocks and do not accept one
+var appManagementPolicyLifetimeRestrictionTypes = []string{
+	string(stable.AppCredentialRestrictionType_PasswordLifetime),
+	string(stable.AppCredentialRestrictionType_SymmetricKeyLifetime),
+	string(stable.AppKeyCredentialRestrictionType_AsymmetricKeyLifetime),
+}
+
+func appManagementPolicyCredentialRestrictionSchema(description string, restrictionTypes []string) *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Description: description,
+		Type:        pluginsdk.TypeSet,
+		Optional:    true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"restriction_type": {
+					Description:  "The type of restriction being applied",
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(restrictionTypes, false),
+				},
+
+				"max_lifetime": {
+					Description:  "The maximum lifetime of a credential, formatted as an ISO8601 duration (e.g. `P90D`). Required for lifetime restrictions",
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: iso8601.ValidateDuration,
+				},
+
+				"restrict_for_apps_created_after": {
+					Description:  "The restriction is only enforced for applications created on or after this date, formatted as an RFC3339 date string (e.g. `2019-01-01T01:02:03Z`)",
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsRFC3339Time,
+				},
+			},
+		},
+	}
+}
+
+func appManagementPolicyRestrictionsSchema(description string) *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Description: description,
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"password_credential": appManagementPolicyCredentialRestrictionSchema("Restrictions on password and symmetric key credentials", stable.PossibleValuesForAppCredentialRestrictionType()),
+				"key_credential":      appManagementPolicyCredentialRestrictionSchema("Restrictions on asymmetric key (certificate) credentials", stable.PossibleValuesForAppKeyCredentialRestrictionType()),
+			},
+		},
+	}
+}
+
+// validateAppManagementPolicyCredentialRestrictions ensures that restriction types are not repeated, and that a
+// `max_lifetime` is specified only for lifetime restrictions
+func validateAppManagementPolicyCredentialRestrictions(field string, restrictions []AppManagementPolicyCredentialRestrictionModel) error {
+	seen := make(map[string]bool)
+
+	for _, restriction := range restrictions {
+		if restriction.RestrictionType == "" {
+			continue
+		}
+		if seen[restriction.RestrictionType] {
+			return fmt.Errorf("`%s` contains more than one restriction with type %q, each restriction type can only be specified once", field, restriction.RestrictionType)
+		}
+		seen[restriction.RestrictionType] = true
+
+		lifetimeRestriction := false
+		for _, t := range appManagementPolicyLifetimeRestrictionTypes {
+			if restriction.RestrictionType == t {
+				lifetimeRestriction = true
+				break
+			}
+		}
+
+		if lifetimeRestriction && restriction.MaxLifetime == "" {
+			return fmt.Errorf("`max_lifetime` must be specified in `%s` for restriction type %q", field, restriction.RestrictionType)
+		}
+		if !lifetimeRestriction && restriction.MaxLifetime != "" {
+			return fmt.Errorf("`max_lifetime` cannot be specified in `%s` for restriction type %q", field, restriction.RestrictionType)
+		}
+	}
+
+	return nil
+}
+
+func expandAppManagementPolicyPasswordCredentials(in []AppManagementPolicyCredentialRestrictionModel) *[]stable.PasswordCredentialConfiguration {
+	result := make([]stable.PasswordCredentialConfiguration, 0)
+
+	for _, restriction := range in {
+		result = append(result, stable.PasswordCredentialConfiguration{
+			MaxLifetime:                         nullable.NoZero(restriction.MaxLifetime),
+			RestrictForAppsCreatedAfterDateTime: nullable.NoZero(restriction.RestrictForAppsCreatedAfter),
+			RestrictionType:                     pointer.To(stable.AppCredentialRestrictionType(restriction.RestrictionType)),
+		})
+	}
+
+	return &result
+}
+
+func expandAppManagementPolicyKeyCredentials(in []AppManagementPolicyCredentialRestrictionModel) *[]stable.KeyCredentialConfiguration {
+	result := make([]stable.KeyCredentialConfiguration, 0)
+
+	for _, restriction := range in {
+		result = append(result, stable.KeyCredentialConfiguration{
+			MaxLifetime:                         nullable.NoZero(restriction.MaxLifetime),
+			RestrictForAppsCreatedAfterDateTime: nullable.NoZero(restriction.RestrictForAppsCreatedAfter),
+			RestrictionType:                     pointer.To(stable.AppKeyCredentialRestrictionType(restriction.RestrictionType)),
+		})
+	}
+
+	return &result
+}
+
+func flattenAppManagementPolicyPasswordCredentials(in *[]stable.PasswordCredentialConfiguration) []AppManagementPolicyCredentialRestrictionModel {
+	result := make([]AppManagementPolicyCredentialRestrictionModel, 0)
+	if in == nil {
+		return result
+	}
+
+	for _, restriction := range *in {
+		result = append(result, AppManagementPolicyCredentialRestrictionModel{
+			MaxLifetime:                 restriction.MaxLifetime.GetOrZero(),
+			RestrictForAppsCreatedAfter: restriction.RestrictForAppsCreatedAfterDateTime.GetOrZero(),
+			RestrictionType:             string(pointer.From(restriction.RestrictionType)),
+		})
+	}
+
+	return result
+}
+
+func flattenAppManagementPolicyKeyCredentials(in *[]stable.KeyCredentialConfiguration) []AppManagementPolicyCredentialRestrictionModel {
+	result := make([]AppManagementPolicyCredentialRestrictionModel, 0)
+	if in == nil {
+		return result
+	}
+
+	for _, restriction := range *in {
+		result = append(result, AppManagementPolicyCredentialRestrictionModel{
+			MaxLifetime:                 restriction.MaxLifetime.GetOrZero(),
+			RestrictForAppsCreatedAfter: restriction.RestrictForAppsCreatedAfterDateTime.GetOrZero(),
+			RestrictionType:             string(pointer.From(restriction.RestrictionType)),
+		})
+	}
+
+	return result
+}
+
+// The SDK does not expose the `$ref` operations for assigning an app management policy to a service principal, so
+// these mirror the equivalent generated operations for applications
+func addServicePrincipalAppManagementPolicyRef(ctx context.Context, c *servicePrincipalAppManagementPolicy.AppManagementPolicyClient, id stable.ServicePrincipalId, input stable.ReferenceCreate) (*http.Response, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusCreated,
+			http.StatusNoContent,
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPost,
+		Path:       fmt.Sprintf("%s/appManagementPolicies/$ref", id.ID()),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = req.Marshal(input); err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Execute(ctx)
+	if resp != nil {
+		return resp.Response, err
+	}
+
+	return nil, err
+}
+
+func removeServicePrincipalAppManagementPolicyRef(ctx context.Context, c *servicePrincipalAppManagementPolicy.AppManagementPolicyClient, id stable.ServicePrincipalIdAppManagementPolicyId) (*http.Response, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodDelete,
+		Path:       fmt.Sprintf("%s/$ref", id.ID()),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Execute(ctx)
+	if resp != nil {
+		return resp.Response, err
+	}
+
+	return nil, err
+}
@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	applicationAppManagementPolicy "github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/appmanagementpolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	servicePrincipalAppManagementPolicy "github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/appmanagementpolicy"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+)
+
+type AppManagementPolicyAssignmentModel struct {
+	AppManagementPolicyId string `tfschema:"app_management_policy_id"`
+	ApplicationId         string `tfschema:"application_id"`
+	ServicePrincipalId    string `tfschema:"service_principal_id"`
+}
+
+var _ sdk.Resource = AppManagementPolicyAssignmentResource{}
+
+type AppManagementPolicyAssignmentResource struct{}
+
+func (r AppManagementPolicyAssignmentResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return func(input interface{}, key string) (warnings []string, errors []error) {
+		v, ok := input.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+			return
+		}
+
+		if strings.HasPrefix(v, "/servicePrincipals/") {
+			return stable.ValidateServicePrincipalIdAppManagementPolicyID(v, key)
+		}
+
+		return stable.ValidateApplicationIdAppManagementPolicyID(v, key)
+	}
+}
+
+func (r AppManagementPolicyAssignmentResource) ResourceType() string {
+	return "azuread_app_management_policy_assignment"
+}
+
+func (r AppManagementPolicyAssignmentResource) ModelObject() interface{} {
+	return &AppManagementPolicyAssignmentModel{}
+}
+
+func (r AppManagementPolicyAssignmentResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"app_management_policy_id": {
+			Description:  "The resource ID of the app management policy to assign",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: stable.ValidatePolicyAppManagementPolicyID,
+		},
+
+		"application_id": {
+			Description:  "The resource ID of the application to which the policy should be assigned",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: []string{"application_id", "service_principal_id"},
+			ValidateFunc: stable.ValidateApplicationID,
+		},
+
+		"service_principal_id": {
+			Description:  "The resource ID of the service principal to which the policy should be assigned",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: []string{"application_id", "service_principal_id"},
+			ValidateFunc: stable.ValidateServicePrincipalID,
+		},
+	}
+}
+
+func (r AppManagementPolicyAssignmentResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r AppManagementPolicyAssignmentResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			applicationClient := metadata.Client.Policies.ApplicationAppManagementPolicyClient
+			servicePrincipalClient := metadata.Client.Policies.ServicePrincipalAppManagementPolicyClient
+
+			var model AppManagementPolicyAssignmentModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			policyId, err := stable.ParsePolicyAppManagementPolicyID(model.AppManagementPolicyId)
+			if err != nil {
+				return err
+			}
+
+			ref := stable.ReferenceCreate{
+				ODataId: pointer.To(applicationClient.Client.BaseUri + stable.NewDirectoryObjectID(policyId.AppManagementPolicyId).ID()),
+			}
+
+			var id resourceids.Id
+
+			if model.ApplicationId != "" {
+				applicationId, err := stable.ParseApplicationID(model.ApplicationId)
+				if err != nil {
+					return err
+				}
+
+				id = stable.NewApplicationIdAppManagementPolicyID(applicationId.ApplicationId, policyId.AppManagementPolicyId)
+
+				existing, err := findApplicationAppManagementPolicy(ctx, applicationClient, *applicationId, policyId.AppManagementPolicyId)
+				if err != nil {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+				if existing != nil {
+					return metadata.ResourceRequiresImport(r.ResourceType(), id)
+				}
+
+				if _, err = applicationClient.AddAppManagementPolicyRef(ctx, *applicationId, ref, applicationAppManagementPolicy.DefaultAddAppManagementPolicyRefOperationOptions()); err != nil {
+					return fmt.Errorf("creating %s: %+v", id, err)
+				}
+			} else {
+				servicePrincipalId, err := stable.ParseServicePrincipalID(model.ServicePrincipalId)
+				if err != nil {
+					return err
+				}
+
+				id = stable.NewServicePrincipalIdAppManagementPolicyID(servicePrincipalId.ServicePrincipalId, policyId.AppManagementPolicyId)
+
+				existing, err := findServicePrincipalAppManagementPolicy(ctx, servicePrincipalClient, *servicePrincipalId, policyId.AppManagementPolicyId)
+				if err != nil {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+				if existing != nil {
+					return metadata.ResourceRequiresImport(r.ResourceType(), id)
+				}
+
+				if _, err = addServicePrincipalAppManagementPolicyRef(ctx, servicePrincipalClient, *servicePrincipalId, ref); err != nil {
+					return fmt.Errorf("creating %s: %+v", id, err)
+				}
+			}
+
+			metadata.SetID(id)
+
+			return nil
+		},
+	}
+}
+
+func (r AppManagementPolicyAssignmentResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			applicationClient := metadata.Client.Policies.ApplicationAppManagementPolicyClient
+			servicePrincipalClient := metadata.Client.Policies.ServicePrincipalAppManagementPolicyClient
+
+			state := AppManagementPolicyAssignmentModel{}
+
+			if strings.HasPrefix(metadata.ResourceData.Id(), "/servicePrincipals/") {
+				id, err := stable.ParseServicePrincipalIdAppManagementPolicyID(metadata.ResourceData.Id())
+				if err != nil {
+					return err
+				}
+
+				servicePrincipalId := stable.NewServicePrincipalID(id.ServicePrincipalId)
+				policy, err := findServicePrincipalAppManagementPolicy(ctx, servicePrincipalClient, servicePrincipalId, id.AppManagementPolicyId)
+				if err != nil {
+					return fmt.Errorf("retrieving %s: %+v", id, err)
+				}
+				if policy == nil {
+					return metadata.MarkAsGone(id)
+				}
+
+				state.AppManagementPolicyId = stable.NewPolicyAppManagementPolicyID(id.AppManagementPolicyId).ID()
+				state.ServicePrincipalId = servicePrincipalId.ID()
+			} else {
+				id, err := stable.ParseApplicationIdAppManagementPolicyID(metadata.ResourceData.Id())
+				if err != nil {
+					return err
+				}
+
+				applicationId := stable.NewApplicationID(id.ApplicationId)
+				policy, err := findApplicationAppManagementPolicy(ctx, applicationClient, applicationId, id.AppManagementPolicyId)
+				if err != nil {
+					return fmt.Errorf("retrieving %s: %+v", id, err)
+				}
+				if policy == nil {
+					return metadata.MarkAsGone(id)
+				}
+
+				state.AppManagementPolicyId = stable.NewPolicyAppManagementPolicyID(id.AppManagementPolicyId).ID()
+				state.ApplicationId = applicationId.ID()
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r AppManagementPolicyAssignmentResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			applicationClient := metadata.Client.Policies.ApplicationAppManagementPolicyClient
+			servicePrincipalClient := metadata.Client.Policies.ServicePrincipalAppManagementPolicyClient
+
+			if strings.HasPrefix(metadata.ResourceData.Id(), "/servicePrincipals/") {
+				id, err := stable.ParseServicePrincipalIdAppManagementPolicyID(metadata.ResourceData.Id())
+				if err != nil {
+					return err
+				}
+
+				if resp, err := removeServicePrincipalAppManagementPolicyRef(ctx, servicePrincipalClient, *id); err != nil && !response.WasNotFound(resp) {
+					return fmt.Errorf("deleting %s: %+v", id, err)
+				}
+
+				return nil
+			}
+
+			id, err := stable.ParseApplicationIdAppManagementPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if resp, err := applicationClient.RemoveAppManagementPolicyRef(ctx, *id, applicationAppManagementPolicy.DefaultRemoveAppManagementPolicyRefOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func findApplicationAppManagementPolicy(ctx context.Context, client *applicationAppManagementPolicy.AppManagementPolicyClient, id stable.ApplicationId, policyId string) (*stable.AppManagementPolicy, error) {
+	resp, err := client.ListAppManagementPolicies(ctx, id, applicationAppManagementPolicy.DefaultListAppManagementPoliciesOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing app management policies for %s: %+v", id, err)
+	}
+	if resp.Model == nil {
+		return nil, errors.New("model was nil")
+	}
+
+	for _, policy := range *resp.Model {
+		if strings.EqualFold(pointer.From(policy.Id), policyId) {
+			return &policy, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func findServicePrincipalAppManagementPolicy(ctx context.Context, client *servicePrincipalAppManagementPolicy.AppManagementPolicyClient, id stable.ServicePrincipalId, policyId string) (*stable.AppManagementPolicy, error) {
+	resp, err := client.ListAppManagementPolicies(ctx, id, servicePrincipalAppManagementPolicy.DefaultListAppManagementPoliciesOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing app management policies for %s: %+v", id, err)
+	}
+	if resp.Model == nil {
+		return nil, errors.New("model was nil")
+	}
+
+	for _, policy := range *resp.Model {
+		if strings.EqualFold(pointer.From(policy.Id), policyId) {
+			return &policy, nil
+		}
+	}
+
+	return nil, nil
+}
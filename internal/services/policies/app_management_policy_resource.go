@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/appmanagementpolicy"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+)
+
+type AppManagementPolicyModel struct {
+	Description         string                                          `tfschema:"description"`
+	DisplayName         string                                          `tfschema:"display_name"`
+	Enabled             bool                                            `tfschema:"enabled"`
+	KeyCredentials      []AppManagementPolicyCredentialRestrictionModel `tfschema:"key_credential"`
+	PasswordCredentials []AppManagementPolicyCredentialRestrictionModel `tfschema:"password_credential"`
+}
+
+var _ sdk.ResourceWithUpdate = AppManagementPolicyResource{}
+var _ sdk.ResourceWithCustomizeDiff = AppManagementPolicyResource{}
+
+type AppManagementPolicyResource struct{}
+
+func (r AppManagementPolicyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return stable.ValidatePolicyAppManagementPolicyID
+}
+
+func (r AppManagementPolicyResource) ResourceType() string {
+	return "azuread_app_management_policy"
+}
+
+func (r AppManagementPolicyResource) ModelObject() interface{} {
+	return &AppManagementPolicyModel{}
+}
+
+func (r AppManagementPolicyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"display_name": {
+			Description:  "The display name for this policy",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"description": {
+			Description:  "The description for this policy",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"enabled": {
+			Description: "Whether this policy is enabled",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+
+		"password_credential": appManagementPolicyCredentialRestrictionSchema("Restrictions on password and symmetric key credentials", stable.PossibleValuesForAppCredentialRestrictionType()),
+
+		"key_credential": appManagementPolicyCredentialRestrictionSchema("Restrictions on asymmetric key (certificate) credentials", stable.PossibleValuesForAppKeyCredentialRestrictionType()),
+	}
+}
+
+func (r AppManagementPolicyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r AppManagementPolicyResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model AppManagementPolicyModel
+			if err := metadata.DecodeDiff(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if err := validateAppManagementPolicyCredentialRestrictions("password_credential", model.PasswordCredentials); err != nil {
+				return err
+			}
+
+			return validateAppManagementPolicyCredentialRestrictions("key_credential", model.KeyCredentials)
+		},
+	}
+}
+
+func (r AppManagementPolicyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.AppManagementPolicyClient
+
+			var model AppManagementPolicyModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			properties := stable.AppManagementPolicy{
+				Description: nullable.Value(model.Description),
+				DisplayName: nullable.Value(model.DisplayName),
+				IsEnabled:   pointer.To(model.Enabled),
+				Restrictions: &stable.CustomAppManagementConfiguration{
+					KeyCredentials:      expandAppManagementPolicyKeyCredentials(model.KeyCredentials),
+					PasswordCredentials: expandAppManagementPolicyPasswordCredentials(model.PasswordCredentials),
+				},
+			}
+
+			resp, err := client.CreateAppManagementPolicy(ctx, properties, appmanagementpolicy.DefaultCreateAppManagementPolicyOperationOptions())
+			if err != nil {
+				return fmt.Errorf("creating app management policy: %+v", err)
+			}
+
+			policy := resp.Model
+			if policy == nil {
+				return errors.New("creating app management policy: model was nil")
+			}
+			if policy.Id == nil {
+				return errors.New("creating app management policy: returned with nil ID")
+			}
+
+			id := stable.NewPolicyAppManagementPolicyID(*policy.Id)
+			metadata.SetID(id)
+
+			return nil
+		},
+	}
+}
+
+func (r AppManagementPolicyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.AppManagementPolicyClient
+
+			id, err := stable.ParsePolicyAppManagementPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetAppManagementPolicy(ctx, *id, appmanagementpolicy.DefaultGetAppManagementPolicyOperationOptions())
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			policy := resp.Model
+			if policy == nil {
+				return fmt.Errorf("retrieving %s: model was nil", id)
+			}
+
+			state := AppManagementPolicyModel{
+				Description: policy.Description.GetOrZero(),
+				DisplayName: policy.DisplayName.GetOrZero(),
+				Enabled:     pointer.From(policy.IsEnabled),
+			}
+
+			if policy.Restrictions != nil {
+				state.KeyCredentials = flattenAppManagementPolicyKeyCredentials(policy.Restrictions.KeyCredentials)
+				state.PasswordCredentials = flattenAppManagementPolicyPasswordCredentials(policy.Restrictions.PasswordCredentials)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r AppManagementPolicyResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.AppManagementPolicyClient
+
+			id, err := stable.ParsePolicyAppManagementPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model AppManagementPolicyModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			properties := stable.AppManagementPolicy{}
+
+			if metadata.ResourceData.HasChange("description") {
+				properties.Description = nullable.Value(model.Description)
+			}
+
+			if metadata.ResourceData.HasChange("display_name") {
+				properties.DisplayName = nullable.Value(model.DisplayName)
+			}
+
+			if metadata.ResourceData.HasChange("enabled") {
+				properties.IsEnabled = pointer.To(model.Enabled)
+			}
+
+			if metadata.ResourceData.HasChanges("key_credential", "password_credential") {
+				// Restrictions must be sent in full, as omitting either collection will clear it
+				properties.Restrictions = &stable.CustomAppManagementConfiguration{
+					KeyCredentials:      expandAppManagementPolicyKeyCredentials(model.KeyCredentials),
+					PasswordCredentials: expandAppManagementPolicyPasswordCredentials(model.PasswordCredentials),
+				}
+			}
+
+			if _, err = client.UpdateAppManagementPolicy(ctx, *id, properties, appmanagementpolicy.DefaultUpdateAppManagementPolicyOperationOptions()); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r AppManagementPolicyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.AppManagementPolicyClient
+
+			id, err := stable.ParsePolicyAppManagementPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err = client.DeleteAppManagementPolicy(ctx, *id, appmanagementpolicy.DefaultDeleteAppManagementPolicyOperationOptions()); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/defaultappmanagementpolicy"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/policies/parse"
+)
+
+type DefaultAppManagementPolicyModel struct {
+	ApplicationRestrictions      []DefaultAppManagementPolicyRestrictionsModel `tfschema:"application_restrictions"`
+	Description                  string                                        `tfschema:"description"`
+	DisplayName                  string                                        `tfschema:"display_name"`
+	Enabled                      bool                                          `tfschema:"enabled"`
+	ServicePrincipalRestrictions []DefaultAppManagementPolicyRestrictionsModel `tfschema:"service_principal_restrictions"`
+}
+
+type DefaultAppManagementPolicyRestrictionsModel struct {
+	KeyCredentials      []AppManagementPolicyCredentialRestrictionModel `tfschema:"key_credential"`
+	PasswordCredentials []AppManagementPolicyCredentialRestrictionModel `tfschema:"password_credential"`
+}
+
+var _ sdk.ResourceWithUpdate = DefaultAppManagementPolicyResource{}
+var _ sdk.ResourceWithCustomizeDiff = DefaultAppManagementPolicyResource{}
+
+type DefaultAppManagementPolicyResource struct{}
+
+func (r DefaultAppManagementPolicyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateDefaultAppManagementPolicyID
+}
+
+func (r DefaultAppManagementPolicyResource) ResourceType() string {
+	return "azuread_default_app_management_policy"
+}
+
+func (r DefaultAppManagementPolicyResource) ModelObject() interface{} {
+	return &DefaultAppManagementPolicyModel{}
+}
+
+func (r DefaultAppManagementPolicyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"display_name": {
+			Description:  "The display name for the default app management policy",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"description": {
+			Description:  "The description for the default app management policy",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"enabled": {
+			Description: "Whether the default app management policy is enabled",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+
+		"application_restrictions": appManagementPolicyRestrictionsSchema("Default restrictions that apply to all application objects in the tenant"),
+
+		"service_principal_restrictions": appManagementPolicyRestrictionsSchema("Default restrictions that apply to all service principal objects in the tenant"),
+	}
+}
+
+func (r DefaultAppManagementPolicyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r DefaultAppManagementPolicyResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model DefaultAppManagementPolicyModel
+			if err := metadata.DecodeDiff(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			for field, restrictions := range map[string][]DefaultAppManagementPolicyRestrictionsModel{
+				"application_restrictions":       model.ApplicationRestrictions,
+				"service_principal_restrictions": model.ServicePrincipalRestrictions,
+			} {
+				for _, restriction := range restrictions {
+					if err := validateAppManagementPolicyCredentialRestrictions(field+".0.password_credential", restriction.PasswordCredentials); err != nil {
+						return err
+					}
+					if err := validateAppManagementPolicyCredentialRestrictions(field+".0.key_credential", restriction.KeyCredentials); err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r DefaultAppManagementPolicyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.DefaultAppManagementPolicyClient
+			id := parse.NewDefaultAppManagementPolicyID()
+
+			var model DefaultAppManagementPolicyModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// The default policy always exists, so creating this resource adopts it and applies the desired configuration
+			properties := expandDefaultAppManagementPolicy(model)
+
+			if _, err := client.UpdateDefaultAppManagementPolicy(ctx, properties, defaultappmanagementpolicy.DefaultUpdateDefaultAppManagementPolicyOperationOptions()); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			return nil
+		},
+	}
+}
+
+func (r DefaultAppManagementPolicyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.DefaultAppManagementPolicyClient
+
+			id, err := parse.ParseDefaultAppManagementPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetDefaultAppManagementPolicy(ctx, defaultappmanagementpolicy.DefaultGetDefaultAppManagementPolicyOperationOptions())
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			policy := resp.Model
+			if policy == nil {
+				return fmt.Errorf("retrieving %s: model was nil", id)
+			}
+
+			state := DefaultAppManagementPolicyModel{
+				Description:                  policy.Description.GetOrZero(),
+				DisplayName:                  policy.DisplayName.GetOrZero(),
+				Enabled:                      pointer.From(policy.IsEnabled),
+				ApplicationRestrictions:      make([]DefaultAppManagementPolicyRestrictionsModel, 0),
+				ServicePrincipalRestrictions: make([]DefaultAppManagementPolicyRestrictionsModel, 0),
+			}
+
+			if restrictions := policy.ApplicationRestrictions; restrictions != nil && (len(pointer.From(restrictions.KeyCredentials)) > 0 || len(pointer.From(restrictions.PasswordCredentials)) > 0) {
+				state.ApplicationRestrictions = append(state.ApplicationRestrictions, DefaultAppManagementPolicyRestrictionsModel{
+					KeyCredentials:      flattenAppManagementPolicyKeyCredentials(restrictions.KeyCredentials),
+					PasswordCredentials: flattenAppManagementPolicyPasswordCredentials(restrictions.PasswordCredentials),
+				})
+			}
+
+			if restrictions := policy.ServicePrincipalRestrictions; restrictions != nil && (len(pointer.From(restrictions.KeyCredentials)) > 0 || len(pointer.From(restrictions.PasswordCredentials)) > 0) {
+				state.ServicePrincipalRestrictions = append(state.ServicePrincipalRestrictions, DefaultAppManagementPolicyRestrictionsModel{
+					KeyCredentials:      flattenAppManagementPolicyKeyCredentials(restrictions.KeyCredentials),
+					PasswordCredentials: flattenAppManagementPolicyPasswordCredentials(restrictions.PasswordCredentials),
+				})
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r DefaultAppManagementPolicyResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.DefaultAppManagementPolicyClient
+
+			id, err := parse.ParseDefaultAppManagementPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model DefaultAppManagementPolicyModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if _, err = client.UpdateDefaultAppManagementPolicy(ctx, expandDefaultAppManagementPolicy(model), defaultappmanagementpolicy.DefaultUpdateDefaultAppManagementPolicyOperationOptions()); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r DefaultAppManagementPolicyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.DefaultAppManagementPolicyClient
+
+			id, err := parse.ParseDefaultAppManagementPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// The default policy cannot be deleted, so instead we disable it and remove all restrictions
+			properties := stable.TenantAppManagementPolicy{
+				IsEnabled: pointer.To(false),
+				ApplicationRestrictions: &stable.AppManagementApplicationConfiguration{
+					KeyCredentials:      &[]stable.KeyCredentialConfiguration{},
+					PasswordCredentials: &[]stable.PasswordCredentialConfiguration{},
+				},
+				ServicePrincipalRestrictions: &stable.AppManagementServicePrincipalConfiguration{
+					KeyCredentials:      &[]stable.KeyCredentialConfiguration{},
+					PasswordCredentials: &[]stable.PasswordCredentialConfiguration{},
+				},
+			}
+
+			if _, err = client.UpdateDefaultAppManagementPolicy(ctx, properties, defaultappmanagementpolicy.DefaultUpdateDefaultAppManagementPolicyOperationOptions()); err != nil {
+				return fmt.Errorf("resetting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandDefaultAppManagementPolicy(model DefaultAppManagementPolicyModel) stable.TenantAppManagementPolicy {
+	properties := stable.TenantAppManagementPolicy{
+		Description: nullable.NoZero(model.Description),
+		DisplayName: nullable.NoZero(model.DisplayName),
+		IsEnabled:   pointer.To(model.Enabled),
+		ApplicationRestrictions: &stable.AppManagementApplicationConfiguration{
+			KeyCredentials:      &[]stable.KeyCredentialConfiguration{},
+			PasswordCredentials: &[]stable.PasswordCredentialConfiguration{},
+		},
+		ServicePrincipalRestrictions: &stable.AppManagementServicePrincipalConfiguration{
+			KeyCredentials:      &[]stable.KeyCredentialConfiguration{},
+			PasswordCredentials: &[]stable.PasswordCredentialConfiguration{},
+		},
+	}
+
+	if len(model.ApplicationRestrictions) > 0 {
+		properties.ApplicationRestrictions.KeyCredentials = expandAppManagementPolicyKeyCredentials(model.ApplicationRestrictions[0].KeyCredentials)
+		properties.ApplicationRestrictions.PasswordCredentials = expandAppManagementPolicyPasswordCredentials(model.ApplicationRestrictions[0].PasswordCredentials)
+	}
+
+	if len(model.ServicePrincipalRestrictions) > 0 {
+		properties.ServicePrincipalRestrictions.KeyCredentials = expandAppManagementPolicyKeyCredentials(model.ServicePrincipalRestrictions[0].KeyCredentials)
+		properties.ServicePrincipalRestrictions.PasswordCredentials = expandAppManagementPolicyPasswordCredentials(model.ServicePrincipalRestrictions[0].PasswordCredentials)
+	}
+
+	return properties
+}
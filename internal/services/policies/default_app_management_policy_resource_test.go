@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/defaultappmanagementpolicy"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type DefaultAppManagementPolicyResource struct{}
+
+func TestAccDefaultAppManagementPolicy_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_default_app_management_policy", "test")
+	r := DefaultAppManagementPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("application_restrictions.0.password_credential.#").HasValue("1"),
+				check.That(data.ResourceName).Key("service_principal_restrictions.0.key_credential.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r DefaultAppManagementPolicyResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Policies.DefaultAppManagementPolicyClient
+
+	resp, err := client.GetDefaultAppManagementPolicy(ctx, defaultappmanagementpolicy.DefaultGetDefaultAppManagementPolicyOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve default app management policy: %v", err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (DefaultAppManagementPolicyResource) basic() string {
+	return `
+provider "azuread" {}
+
+resource "azuread_default_app_management_policy" "test" {
+  enabled = false
+}
+`
+}
+
+func (DefaultAppManagementPolicyResource) complete() string {
+	return `
+provider "azuread" {}
+
+resource "azuread_default_app_management_policy" "test" {
+  enabled = true
+
+  application_restrictions {
+    password_credential {
+      restriction_type                = "passwordLifetime"
+      max_lifetime                    = "P4000D"
+      restrict_for_apps_created_after = "2099-01-01T00:00:00Z"
+    }
+  }
+
+  service_principal_restrictions {
+    key_credential {
+      restriction_type                = "asymmetricKeyLifetime"
+      max_lifetime                    = "P4000D"
+      restrict_for_apps_created_after = "2099-01-01T00:00:00Z"
+    }
+  }
+}
+`
+}
@@ -0,0 +1,295 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/rolemanagementpolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/rolemanagementpolicyassignment"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/rolemanagementrules"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/policies/parse"
+)
+
+const directoryRoleManagementPolicyScopeType = "DirectoryRole"
+
+type DirectoryRoleManagementPolicyModel struct {
+	Description             string                                        `tfschema:"description"`
+	DisplayName             string                                        `tfschema:"display_name"`
+	RoleDefinitionId        string                                        `tfschema:"role_definition_id"`
+	Scope                   string                                        `tfschema:"scope"`
+	ActiveAssignmentRules   []rolemanagementrules.ActiveAssignmentRules   `tfschema:"active_assignment_rules"`
+	EligibleAssignmentRules []rolemanagementrules.EligibleAssignmentRules `tfschema:"eligible_assignment_rules"`
+	ActivationRules         []rolemanagementrules.ActivationRules         `tfschema:"activation_rules"`
+	NotificationRules       []rolemanagementrules.NotificationEvents      `tfschema:"notification_rules"`
+}
+
+var _ sdk.ResourceWithUpdate = DirectoryRoleManagementPolicyResource{}
+
+type DirectoryRoleManagementPolicyResource struct{}
+
+func (r DirectoryRoleManagementPolicyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateDirectoryRoleRoleManagementPolicyID
+}
+
+func (r DirectoryRoleManagementPolicyResource) ResourceType() string {
+	return "azuread_directory_role_management_policy"
+}
+
+func (r DirectoryRoleManagementPolicyResource) ModelObject() interface{} {
+	return &DirectoryRoleManagementPolicyModel{}
+}
+
+func (r DirectoryRoleManagementPolicyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"role_definition_id": {
+			Description:  "The template ID of the directory role to which this policy applies",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"scope": {
+			Description: "The scope to which this policy applies, either `/` for the tenant or an administrative unit in the format `/administrativeUnits/{id}`",
+			Type:        pluginsdk.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     "/",
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^/(administrativeUnits/[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})?$`),
+				"scope must be `/` or `/administrativeUnits/{id}`",
+			),
+		},
+
+		"eligible_assignment_rules": rolemanagementrules.EligibleAssignmentRulesSchema(),
+
+		"active_assignment_rules": rolemanagementrules.ActiveAssignmentRulesSchema(),
+
+		"activation_rules": rolemanagementrules.ActivationRulesSchema(),
+
+		"notification_rules": rolemanagementrules.NotificationRulesSchema(),
+	}
+}
+
+func (r DirectoryRoleManagementPolicyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"display_name": {
+			Description: "The display name of the policy",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"description": {
+			Description: "Description of the policy",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func (r DirectoryRoleManagementPolicyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model DirectoryRoleManagementPolicyModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// Policies for directory roles always exist, so we find the existing policy and apply our configuration to it
+			policyId, err := getPolicyId(ctx, metadata, directoryRoleManagementPolicyScopeType, model.Scope, model.RoleDefinitionId)
+			if err != nil {
+				return fmt.Errorf("parsing policy ID: %v", err)
+			}
+
+			if err = r.updatePolicy(ctx, metadata, model, *policyId); err != nil {
+				return err
+			}
+
+			// Update the ID as it changes on modification
+			policyId, err = getPolicyId(ctx, metadata, directoryRoleManagementPolicyScopeType, model.Scope, model.RoleDefinitionId)
+			if err != nil {
+				return fmt.Errorf("parsing policy ID: %v", err)
+			}
+
+			metadata.SetID(policyId)
+
+			return nil
+		},
+	}
+}
+
+func (r DirectoryRoleManagementPolicyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.RoleManagementPolicyClient
+			assignmentClient := metadata.Client.Policies.RoleManagementPolicyAssignmentClient
+
+			policyId, err := parse.ParseRoleManagementPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return fmt.Errorf("parsing policy ID: %v", err)
+			}
+
+			var model DirectoryRoleManagementPolicyModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := stable.NewPolicyRoleManagementPolicyID(policyId.ID())
+
+			policyOptions := rolemanagementpolicy.GetRoleManagementPolicyOperationOptions{
+				Expand: &odata.Expand{
+					Relationship: "*",
+				},
+			}
+
+			policyResp, err := client.GetRoleManagementPolicy(ctx, id, policyOptions)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %v", id, err)
+			}
+
+			policy := policyResp.Model
+			if policy == nil {
+				return fmt.Errorf("retrieving %s: API error, model was nil", id)
+			}
+
+			assignmentOptions := rolemanagementpolicyassignment.ListRoleManagementPolicyAssignmentsOperationOptions{
+				Filter: pointer.To(fmt.Sprintf("scopeType eq '%s' and scopeId eq '%s' and policyId eq '%s'", directoryRoleManagementPolicyScopeType, odata.EscapeSingleQuote(policy.ScopeId), odata.EscapeSingleQuote(id.UnifiedRoleManagementPolicyId))),
+			}
+			resp, err := assignmentClient.ListRoleManagementPolicyAssignments(ctx, assignmentOptions)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %v", id, err)
+			}
+
+			if resp.Model == nil {
+				return fmt.Errorf("retrieving %s: expected 1 assignment, got nil result", id)
+			}
+			if len(*resp.Model) != 1 {
+				return fmt.Errorf("retrieving %s: expected 1 assignment, got %d", id, len(*resp.Model))
+			}
+
+			assignment := (*resp.Model)[0]
+
+			model.Description = pointer.From(policy.Description)
+			model.DisplayName = pointer.From(policy.DisplayName)
+			model.RoleDefinitionId = assignment.RoleDefinitionId.GetOrZero()
+			model.Scope = policy.ScopeId
+
+			rules := rolemanagementrules.Rules{
+				ActiveAssignmentRules:   model.ActiveAssignmentRules,
+				EligibleAssignmentRules: model.EligibleAssignmentRules,
+				ActivationRules:         model.ActivationRules,
+				NotificationRules:       model.NotificationRules,
+			}
+			if err = rolemanagementrules.FlattenRules(policy.Rules, &rules); err != nil {
+				return fmt.Errorf("flattening rules for %s: %v", id, err)
+			}
+
+			model.ActiveAssignmentRules = rules.ActiveAssignmentRules
+			model.EligibleAssignmentRules = rules.EligibleAssignmentRules
+			model.ActivationRules = rules.ActivationRules
+			model.NotificationRules = rules.NotificationRules
+
+			return metadata.Encode(&model)
+		},
+	}
+}
+
+func (r DirectoryRoleManagementPolicyResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			policyId, err := parse.ParseRoleManagementPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return fmt.Errorf("parsing policy ID: %v", err)
+			}
+
+			var model DirectoryRoleManagementPolicyModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if err = r.updatePolicy(ctx, metadata, model, *policyId); err != nil {
+				return err
+			}
+
+			// Update the ID as it changes on modification
+			policyId, err = getPolicyId(ctx, metadata, directoryRoleManagementPolicyScopeType, model.Scope, model.RoleDefinitionId)
+			if err != nil {
+				return fmt.Errorf("parsing policy ID: %v", err)
+			}
+
+			metadata.SetID(policyId)
+
+			return nil
+		},
+	}
+}
+
+func (r DirectoryRoleManagementPolicyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// Policy cannot be destroyed, so this is a noop
+			return nil
+		},
+	}
+}
+
+func (r DirectoryRoleManagementPolicyResource) updatePolicy(ctx context.Context, metadata sdk.ResourceMetaData, model DirectoryRoleManagementPolicyModel, policyId parse.RoleManagementPolicyId) error {
+	client := metadata.Client.Policies.RoleManagementPolicyClient
+	id := stable.NewPolicyRoleManagementPolicyID(policyId.ID())
+
+	options := rolemanagementpolicy.GetRoleManagementPolicyOperationOptions{
+		Expand: &odata.Expand{
+			Relationship: "*",
+		},
+	}
+
+	resp, err := client.GetRoleManagementPolicy(ctx, id, options)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %v", id, err)
+	}
+
+	policy := resp.Model
+	if policy == nil {
+		return fmt.Errorf("retrieving %s: API error, model was nil", id)
+	}
+
+	rules := rolemanagementrules.Rules{
+		ActiveAssignmentRules:   model.ActiveAssignmentRules,
+		EligibleAssignmentRules: model.EligibleAssignmentRules,
+		ActivationRules:         model.ActivationRules,
+		NotificationRules:       model.NotificationRules,
+	}
+
+	updatedRules, err := rolemanagementrules.ExpandRules(metadata.ResourceData, rules, policy.Rules)
+	if err != nil {
+		return fmt.Errorf("building update request: %v", err)
+	}
+
+	properties := stable.UnifiedRoleManagementPolicy{
+		Id:        policy.Id,
+		Rules:     pointer.To(updatedRules),
+		ScopeId:   policy.ScopeId,
+		ScopeType: policy.ScopeType,
+	}
+
+	if _, err = client.UpdateRoleManagementPolicy(ctx, id, properties, rolemanagementpolicy.DefaultUpdateRoleManagementPolicyOperationOptions()); err != nil {
+		return fmt.Errorf("updating %s: %v", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/rolemanagementpolicy"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/policies/parse"
+)
+
+type DirectoryRoleManagementPolicyResource struct{}
+
+func TestAccDirectoryRoleManagementPolicy_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_management_policy", "test")
+	r := DirectoryRoleManagementPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("scope").HasValue("/"),
+				check.That(data.ResourceName).Key("display_name").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDirectoryRoleManagementPolicy_globalAdministrator(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_management_policy", "test")
+	r := DirectoryRoleManagementPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.globalAdministrator(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("activation_rules.0.require_approval").HasValue("true"),
+				check.That(data.ResourceName).Key("activation_rules.0.require_multifactor_authentication").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (DirectoryRoleManagementPolicyResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Policies.RoleManagementPolicyClient
+
+	policyId, err := parse.ParseRoleManagementPolicyID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse policy ID: %v", err)
+	}
+
+	id := stable.NewPolicyRoleManagementPolicyID(policyId.ID())
+
+	resp, err := client.GetRoleManagementPolicy(ctx, id, rolemanagementpolicy.DefaultGetRoleManagementPolicyOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %v", id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (DirectoryRoleManagementPolicyResource) basic(data acceptance.TestData) string {
+	return `
+provider "azuread" {}
+
+resource "azuread_directory_role_management_policy" "test" {
+  role_definition_id = "62e90394-69f5-4237-9190-012177145e10"
+
+  activation_rules {
+    maximum_duration                   = "PT8H"
+    require_approval                   = false
+    require_justification              = true
+    require_multifactor_authentication = true
+  }
+}
+`
+}
+
+func (DirectoryRoleManagementPolicyResource) globalAdministrator(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_group" "approvers" {
+  display_name     = "acctestGlobalAdminApprovers-%[1]d"
+  security_enabled = true
+}
+
+resource "azuread_directory_role_management_policy" "test" {
+  role_definition_id = "62e90394-69f5-4237-9190-012177145e10"
+  scope              = "/"
+
+  eligible_assignment_rules {
+    expiration_required = true
+    expire_after        = "P180D"
+  }
+
+  activation_rules {
+    maximum_duration                   = "PT2H"
+    require_approval                   = true
+    require_justification              = true
+    require_multifactor_authentication = true
+
+    approval_stage {
+      primary_approver {
+        object_id = azuread_group.approvers.object_id
+        type      = "groupMembers"
+      }
+    }
+  }
+
+  notification_rules {
+    eligible_activations {
+      admin_notifications {
+        notification_level    = "All"
+        default_recipients    = true
+        additional_recipients = ["security@example.com"]
+      }
+    }
+  }
+}
+`, data.RandomInteger)
+}
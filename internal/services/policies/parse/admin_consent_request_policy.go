@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+)
+
+// AdminConsentRequestPolicyId represents the tenant-wide admin consent request policy, of which there is exactly one per tenant
+type AdminConsentRequestPolicyId struct{}
+
+func NewAdminConsentRequestPolicyID() *AdminConsentRequestPolicyId {
+	return &AdminConsentRequestPolicyId{}
+}
+
+func ParseAdminConsentRequestPolicyID(input string) (*AdminConsentRequestPolicyId, error) {
+	id := AdminConsentRequestPolicyId{}
+	if input != id.ID() {
+		return nil, fmt.Errorf("parsing AdminConsentRequestPolicyId: expected %q, got %q", id.ID(), input)
+	}
+
+	return &id, nil
+}
+
+func ValidateAdminConsentRequestPolicyID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if _, err := ParseAdminConsentRequestPolicyID(v); err != nil {
+		errors = append(errors, err)
+	}
+
+	return
+}
+
+func (id *AdminConsentRequestPolicyId) ID() string {
+	return "/policies/adminConsentRequestPolicy"
+}
+
+func (id *AdminConsentRequestPolicyId) String() string {
+	return "Admin Consent Request Policy"
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+)
+
+// DefaultAppManagementPolicyId represents the tenant-wide app management policy, of which there is exactly one per tenant
+type DefaultAppManagementPolicyId struct{}
+
+func NewDefaultAppManagementPolicyID() *DefaultAppManagementPolicyId {
+	return &DefaultAppManagementPolicyId{}
+}
+
+func ParseDefaultAppManagementPolicyID(input string) (*DefaultAppManagementPolicyId, error) {
+	id := DefaultAppManagementPolicyId{}
+	if input != id.ID() {
+		return nil, fmt.Errorf("parsing DefaultAppManagementPolicyId: expected %q, got %q", id.ID(), input)
+	}
+
+	return &id, nil
+}
+
+func ValidateDefaultAppManagementPolicyID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if _, err := ParseDefaultAppManagementPolicyID(v); err != nil {
+		errors = append(errors, err)
+	}
+
+	return
+}
+
+func (id *DefaultAppManagementPolicyId) ID() string {
+	return "/policies/defaultAppManagementPolicy"
+}
+
+func (id *DefaultAppManagementPolicyId) String() string {
+	return "Default App Management Policy"
+}
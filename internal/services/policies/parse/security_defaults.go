@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+)
+
+// SecurityDefaultsId represents the tenant-wide security defaults enforcement policy, of which there is exactly one per tenant
+type SecurityDefaultsId struct{}
+
+func NewSecurityDefaultsID() *SecurityDefaultsId {
+	return &SecurityDefaultsId{}
+}
+
+func ParseSecurityDefaultsID(input string) (*SecurityDefaultsId, error) {
+	id := SecurityDefaultsId{}
+	if input != id.ID() {
+		return nil, fmt.Errorf("parsing SecurityDefaultsId: expected %q, got %q", id.ID(), input)
+	}
+
+	return &id, nil
+}
+
+func ValidateSecurityDefaultsID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if _, err := ParseSecurityDefaultsID(v); err != nil {
+		errors = append(errors, err)
+	}
+
+	return
+}
+
+func (id *SecurityDefaultsId) ID() string {
+	return "/policies/identitySecurityDefaultsEnforcementPolicy"
+}
+
+func (id *SecurityDefaultsId) String() string {
+	return "Security Defaults"
+}
@@ -0,0 +1,496 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/permissiongrantpolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/permissiongrantpolicyexclude"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/permissiongrantpolicyinclude"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+)
+
+type PermissionGrantPolicyModel struct {
+	Description string                             `tfschema:"description"`
+	DisplayName string                             `tfschema:"display_name"`
+	Exclude     []PermissionGrantConditionSetModel `tfschema:"exclude"`
+	Include     []PermissionGrantConditionSetModel `tfschema:"include"`
+	PolicyId    string                             `tfschema:"policy_id"`
+}
+
+type PermissionGrantConditionSetModel struct {
+	ClientApplicationIds                        []string `tfschema:"client_application_ids"`
+	ClientApplicationPublisherIds               []string `tfschema:"client_application_publisher_ids"`
+	ClientApplicationTenantIds                  []string `tfschema:"client_application_tenant_ids"`
+	ClientApplicationsFromVerifiedPublisherOnly bool     `tfschema:"client_applications_from_verified_publisher_only"`
+	PermissionClassification                    string   `tfschema:"permission_classification"`
+	PermissionType                              string   `tfschema:"permission_type"`
+	Permissions                                 []string `tfschema:"permissions"`
+	ResourceApplication                         string   `tfschema:"resource_application"`
+}
+
+// The API uses a value of `all` to indicate that a condition matches everything, which is also the default value when
+// a condition is omitted. In the provider, an unset condition conveys the same meaning.
+const permissionGrantConditionAll = "all"
+
+var _ sdk.ResourceWithUpdate = PermissionGrantPolicyResource{}
+
+type PermissionGrantPolicyResource struct{}
+
+func (r PermissionGrantPolicyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return stable.ValidatePolicyPermissionGrantPolicyID
+}
+
+func (r PermissionGrantPolicyResource) ResourceType() string {
+	return "azuread_permission_grant_policy"
+}
+
+func (r PermissionGrantPolicyResource) ModelObject() interface{} {
+	return &PermissionGrantPolicyModel{}
+}
+
+func (r PermissionGrantPolicyResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"policy_id": {
+			Description:  "The ID of the permission grant policy",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"display_name": {
+			Description:  "The display name for the permission grant policy",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"description": {
+			Description:  "The description for the permission grant policy",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"include": permissionGrantConditionSetSchema("A condition set describing permission grants which are included in this policy"),
+
+		"exclude": permissionGrantConditionSetSchema("A condition set describing permission grants which are excluded from this policy"),
+	}
+}
+
+func (r PermissionGrantPolicyResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func permissionGrantConditionSetSchema(description string) *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Description: description,
+		Type:        pluginsdk.TypeSet,
+		Optional:    true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"permission_type": {
+					Description:  "The permission type of the permission being granted",
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(stable.PossibleValuesForPermissionType(), false),
+				},
+
+				"permission_classification": {
+					Description:  "The permission classification for the permission being granted",
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Default:      permissionGrantConditionAll,
+					ValidateFunc: validation.StringInSlice([]string{permissionGrantConditionAll, "low", "medium", "high"}, false),
+				},
+
+				"permissions": {
+					Description: "A list of permission IDs for the specific permissions to match against. When omitted, all permissions are matched",
+					Type:        pluginsdk.TypeList,
+					Optional:    true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.IsUUID,
+					},
+				},
+
+				"resource_application": {
+					Description:  "The application ID of the resource application (e.g. the API) for which a permission is being granted, or `any` to match any resource application",
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					Default:      "any",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"client_application_ids": {
+					Description: "A list of application IDs for the client applications to match against. When omitted, all client applications are matched",
+					Type:        pluginsdk.TypeList,
+					Optional:    true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.IsUUID,
+					},
+				},
+
+				"client_application_publisher_ids": {
+					Description: "A list of Microsoft Partner Network (MPN) IDs for verified publishers of the client application to match against. When omitted, all publishers are matched",
+					Type:        pluginsdk.TypeList,
+					Optional:    true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"client_application_tenant_ids": {
+					Description: "A list of tenant IDs in which the client application is registered to match against. When omitted, all tenants are matched",
+					Type:        pluginsdk.TypeList,
+					Optional:    true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.IsUUID,
+					},
+				},
+
+				"client_applications_from_verified_publisher_only": {
+					Description: "Whether to only match client applications with a verified publisher",
+					Type:        pluginsdk.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+			},
+		},
+	}
+}
+
+func (r PermissionGrantPolicyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.PermissionGrantPolicyClient
+
+			var model PermissionGrantPolicyModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := stable.NewPolicyPermissionGrantPolicyID(model.PolicyId)
+
+			existing, err := client.GetPermissionGrantPolicy(ctx, id, permissiongrantpolicy.DefaultGetPermissionGrantPolicyOperationOptions())
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			} else {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			properties := stable.PermissionGrantPolicy{
+				Id:          pointer.To(model.PolicyId),
+				Description: nullable.Value(model.Description),
+				DisplayName: nullable.Value(model.DisplayName),
+			}
+
+			if _, err = client.CreatePermissionGrantPolicy(ctx, properties, permissiongrantpolicy.DefaultCreatePermissionGrantPolicyOperationOptions()); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			if err = reconcilePermissionGrantConditionSets(ctx, metadata, id, model); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r PermissionGrantPolicyResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.PermissionGrantPolicyClient
+			includeClient := metadata.Client.Policies.PermissionGrantPolicyIncludeClient
+			excludeClient := metadata.Client.Policies.PermissionGrantPolicyExcludeClient
+
+			id, err := stable.ParsePolicyPermissionGrantPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetPermissionGrantPolicy(ctx, *id, permissiongrantpolicy.DefaultGetPermissionGrantPolicyOperationOptions())
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			policy := resp.Model
+			if policy == nil {
+				return fmt.Errorf("retrieving %s: model was nil", id)
+			}
+
+			state := PermissionGrantPolicyModel{
+				Description: policy.Description.GetOrZero(),
+				DisplayName: policy.DisplayName.GetOrZero(),
+				PolicyId:    id.PermissionGrantPolicyId,
+			}
+
+			includesResp, err := includeClient.ListPermissionGrantPolicyIncludes(ctx, *id, permissiongrantpolicyinclude.DefaultListPermissionGrantPolicyIncludesOperationOptions())
+			if err != nil {
+				return fmt.Errorf("listing included condition sets for %s: %+v", id, err)
+			}
+			state.Include = flattenPermissionGrantConditionSets(includesResp.Model)
+
+			excludesResp, err := excludeClient.ListPermissionGrantPolicyExcludes(ctx, *id, permissiongrantpolicyexclude.DefaultListPermissionGrantPolicyExcludesOperationOptions())
+			if err != nil {
+				return fmt.Errorf("listing excluded condition sets for %s: %+v", id, err)
+			}
+			state.Exclude = flattenPermissionGrantConditionSets(excludesResp.Model)
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r PermissionGrantPolicyResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.PermissionGrantPolicyClient
+
+			id, err := stable.ParsePolicyPermissionGrantPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model PermissionGrantPolicyModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if metadata.ResourceData.HasChanges("description", "display_name") {
+				properties := stable.PermissionGrantPolicy{
+					Description: nullable.Value(model.Description),
+					DisplayName: nullable.Value(model.DisplayName),
+				}
+
+				if _, err = client.UpdatePermissionGrantPolicy(ctx, *id, properties, permissiongrantpolicy.DefaultUpdatePermissionGrantPolicyOperationOptions()); err != nil {
+					return fmt.Errorf("updating %s: %+v", id, err)
+				}
+			}
+
+			if metadata.ResourceData.HasChanges("include", "exclude") {
+				if err = reconcilePermissionGrantConditionSets(ctx, metadata, *id, model); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r PermissionGrantPolicyResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.PermissionGrantPolicyClient
+
+			id, err := stable.ParsePolicyPermissionGrantPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err = client.DeletePermissionGrantPolicy(ctx, *id, permissiongrantpolicy.DefaultDeletePermissionGrantPolicyOperationOptions()); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// reconcilePermissionGrantConditionSets brings the included and excluded condition sets for a policy in line with the
+// configuration. Condition sets have no user-facing identity, so existing sets which exactly match a configured set are
+// retained, whilst all others are removed and any missing sets are created.
+func reconcilePermissionGrantConditionSets(ctx context.Context, metadata sdk.ResourceMetaData, id stable.PolicyPermissionGrantPolicyId, model PermissionGrantPolicyModel) error {
+	includeClient := metadata.Client.Policies.PermissionGrantPolicyIncludeClient
+	excludeClient := metadata.Client.Policies.PermissionGrantPolicyExcludeClient
+
+	includesResp, err := includeClient.ListPermissionGrantPolicyIncludes(ctx, id, permissiongrantpolicyinclude.DefaultListPermissionGrantPolicyIncludesOperationOptions())
+	if err != nil {
+		return fmt.Errorf("listing included condition sets for %s: %+v", id, err)
+	}
+	if includesResp.Model == nil {
+		return fmt.Errorf("listing included condition sets for %s: model was nil", id)
+	}
+
+	toCreate, toDelete := diffPermissionGrantConditionSets(*includesResp.Model, model.Include)
+	for _, setId := range toDelete {
+		includeId := stable.NewPolicyPermissionGrantPolicyIdIncludeID(id.PermissionGrantPolicyId, setId)
+		log.Printf("[DEBUG] Removing %s", includeId)
+		if resp, err := includeClient.DeletePermissionGrantPolicyInclude(ctx, includeId, permissiongrantpolicyinclude.DefaultDeletePermissionGrantPolicyIncludeOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+			return fmt.Errorf("removing %s: %+v", includeId, err)
+		}
+	}
+	for _, set := range toCreate {
+		if _, err = includeClient.CreatePermissionGrantPolicyInclude(ctx, id, expandPermissionGrantConditionSet(set), permissiongrantpolicyinclude.DefaultCreatePermissionGrantPolicyIncludeOperationOptions()); err != nil {
+			return fmt.Errorf("adding included condition set for %s: %+v", id, err)
+		}
+	}
+
+	excludesResp, err := excludeClient.ListPermissionGrantPolicyExcludes(ctx, id, permissiongrantpolicyexclude.DefaultListPermissionGrantPolicyExcludesOperationOptions())
+	if err != nil {
+		return fmt.Errorf("listing excluded condition sets for %s: %+v", id, err)
+	}
+	if excludesResp.Model == nil {
+		return fmt.Errorf("listing excluded condition sets for %s: model was nil", id)
+	}
+
+	toCreate, toDelete = diffPermissionGrantConditionSets(*excludesResp.Model, model.Exclude)
+	for _, setId := range toDelete {
+		excludeId := stable.NewPolicyPermissionGrantPolicyIdExcludeID(id.PermissionGrantPolicyId, setId)
+		log.Printf("[DEBUG] Removing %s", excludeId)
+		if resp, err := excludeClient.DeletePermissionGrantPolicyExclude(ctx, excludeId, permissiongrantpolicyexclude.DefaultDeletePermissionGrantPolicyExcludeOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+			return fmt.Errorf("removing %s: %+v", excludeId, err)
+		}
+	}
+	for _, set := range toCreate {
+		if _, err = excludeClient.CreatePermissionGrantPolicyExclude(ctx, id, expandPermissionGrantConditionSet(set), permissiongrantpolicyexclude.DefaultCreatePermissionGrantPolicyExcludeOperationOptions()); err != nil {
+			return fmt.Errorf("adding excluded condition set for %s: %+v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// diffPermissionGrantConditionSets returns the configured condition sets which do not yet exist, and the IDs of existing
+// condition sets which are not configured
+func diffPermissionGrantConditionSets(existing []stable.PermissionGrantConditionSet, desired []PermissionGrantConditionSetModel) (toCreate []PermissionGrantConditionSetModel, toDelete []string) {
+	existingKeys := make(map[string]string)
+	for _, set := range existing {
+		if set.Id == nil {
+			continue
+		}
+		key := permissionGrantConditionSetKey(flattenPermissionGrantConditionSet(set))
+		if _, ok := existingKeys[key]; ok {
+			// Remove duplicate condition sets, retaining only one of them
+			toDelete = append(toDelete, *set.Id)
+			continue
+		}
+		existingKeys[key] = *set.Id
+	}
+
+	desiredKeys := make(map[string]bool)
+	for _, set := range desired {
+		key := permissionGrantConditionSetKey(set)
+		if desiredKeys[key] {
+			continue
+		}
+		desiredKeys[key] = true
+		if _, ok := existingKeys[key]; !ok {
+			toCreate = append(toCreate, set)
+		}
+	}
+
+	for key, setId := range existingKeys {
+		if !desiredKeys[key] {
+			toDelete = append(toDelete, setId)
+		}
+	}
+
+	return
+}
+
+// permissionGrantConditionSetKey returns a canonical representation of a condition set, suitable for comparison
+func permissionGrantConditionSetKey(in PermissionGrantConditionSetModel) string {
+	normalize := func(v []string) string {
+		out := make([]string, 0, len(v))
+		for _, s := range v {
+			out = append(out, strings.ToLower(s))
+		}
+		slices.Sort(out)
+		return strings.Join(out, ",")
+	}
+
+	return strings.Join([]string{
+		in.PermissionType,
+		strings.ToLower(in.PermissionClassification),
+		normalize(in.Permissions),
+		strings.ToLower(in.ResourceApplication),
+		normalize(in.ClientApplicationIds),
+		normalize(in.ClientApplicationPublisherIds),
+		normalize(in.ClientApplicationTenantIds),
+		fmt.Sprintf("%t", in.ClientApplicationsFromVerifiedPublisherOnly),
+	}, "|")
+}
+
+func expandPermissionGrantConditionSet(in PermissionGrantConditionSetModel) stable.PermissionGrantConditionSet {
+	expandAll := func(v []string) *[]string {
+		if len(v) == 0 {
+			return &[]string{permissionGrantConditionAll}
+		}
+		return pointer.To(v)
+	}
+
+	return stable.PermissionGrantConditionSet{
+		ClientApplicationIds:                        expandAll(in.ClientApplicationIds),
+		ClientApplicationPublisherIds:               expandAll(in.ClientApplicationPublisherIds),
+		ClientApplicationTenantIds:                  expandAll(in.ClientApplicationTenantIds),
+		ClientApplicationsFromVerifiedPublisherOnly: nullable.Value(in.ClientApplicationsFromVerifiedPublisherOnly),
+		PermissionClassification:                    nullable.NoZero(in.PermissionClassification),
+		PermissionType:                              stable.PermissionType(in.PermissionType),
+		Permissions:                                 expandAll(in.Permissions),
+		ResourceApplication:                         nullable.NoZero(in.ResourceApplication),
+	}
+}
+
+func flattenPermissionGrantConditionSet(in stable.PermissionGrantConditionSet) PermissionGrantConditionSetModel {
+	flattenAll := func(v *[]string) []string {
+		if v == nil || (len(*v) == 1 && strings.EqualFold((*v)[0], permissionGrantConditionAll)) {
+			return []string{}
+		}
+		return *v
+	}
+
+	return PermissionGrantConditionSetModel{
+		ClientApplicationIds:                        flattenAll(in.ClientApplicationIds),
+		ClientApplicationPublisherIds:               flattenAll(in.ClientApplicationPublisherIds),
+		ClientApplicationTenantIds:                  flattenAll(in.ClientApplicationTenantIds),
+		ClientApplicationsFromVerifiedPublisherOnly: in.ClientApplicationsFromVerifiedPublisherOnly.GetOrZero(),
+		PermissionClassification:                    in.PermissionClassification.GetOrZero(),
+		PermissionType:                              string(in.PermissionType),
+		Permissions:                                 flattenAll(in.Permissions),
+		ResourceApplication:                         in.ResourceApplication.GetOrZero(),
+	}
+}
+
+func flattenPermissionGrantConditionSets(in *[]stable.PermissionGrantConditionSet) []PermissionGrantConditionSetModel {
+	result := make([]PermissionGrantConditionSetModel, 0)
+	if in == nil {
+		return result
+	}
+
+	for _, set := range *in {
+		result = append(result, flattenPermissionGrantConditionSet(set))
+	}
+
+	return result
+}
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/permissiongrantpolicy"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type PermissionGrantPolicyResource struct{}
+
+func TestAccPermissionGrantPolicy_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_permission_grant_policy", "test")
+	r := PermissionGrantPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("include.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccPermissionGrantPolicy_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_permission_grant_policy", "test")
+	r := PermissionGrantPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("include.#").HasValue("2"),
+				check.That(data.ResourceName).Key("exclude.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccPermissionGrantPolicy_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_permission_grant_policy", "test")
+	r := PermissionGrantPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccPermissionGrantPolicy_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_permission_grant_policy", "test")
+	r := PermissionGrantPolicyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r PermissionGrantPolicyResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Policies.PermissionGrantPolicyClient
+
+	id, err := stable.ParsePolicyPermissionGrantPolicyID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetPermissionGrantPolicy(ctx, *id, permissiongrantpolicy.DefaultGetPermissionGrantPolicyOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve %s: %v", id, err)
+	}
+
+	return pointer.To(true), nil
+}
+
+func (PermissionGrantPolicyResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_permission_grant_policy" "test" {
+  policy_id    = "acctest-pgp-%[1]s"
+  display_name = "acctest-PGP-%[1]s"
+  description  = "Acceptance test permission grant policy"
+
+  include {
+    permission_type           = "delegated"
+    permission_classification = "low"
+  }
+}
+`, data.RandomString)
+}
+
+func (PermissionGrantPolicyResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_application_published_app_ids" "well_known" {}
+
+data "azuread_client_config" "current" {}
+
+resource "azuread_permission_grant_policy" "test" {
+  policy_id    = "acctest-pgp-%[1]s"
+  display_name = "acctest-PGP-%[1]s-updated"
+  description  = "Acceptance test permission grant policy with multiple condition sets"
+
+  include {
+    permission_type                                  = "delegated"
+    permission_classification                        = "low"
+    client_applications_from_verified_publisher_only = true
+  }
+
+  include {
+    permission_type      = "delegated"
+    resource_application = data.azuread_application_published_app_ids.well_known.result.MicrosoftGraph
+    permissions          = ["e1fe6dd8-ba31-4d61-89e7-88639da4683d"]
+  }
+
+  exclude {
+    permission_type               = "delegated"
+    client_application_tenant_ids = [data.azuread_client_config.current.tenant_id]
+  }
+}
+`, data.RandomString)
+}
+
+func (r PermissionGrantPolicyResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_permission_grant_policy" "import" {
+  policy_id    = azuread_permission_grant_policy.test.policy_id
+  display_name = azuread_permission_grant_policy.test.display_name
+  description  = azuread_permission_grant_policy.test.description
+
+  include {
+    permission_type           = "delegated"
+    permission_classification = "low"
+  }
+}
+`, r.basic(data))
+}
@@ -9,16 +9,17 @@ import (
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/rolemanagementpolicyassignment"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
 	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azuread/internal/services/policies/parse"
 )
 
 // There isn't a reliable way to get the policy ID from the policy API, as the policy ID changes with each modification
-func getPolicyId(ctx context.Context, metadata sdk.ResourceMetaData, scopeId, roleDefinitionId string) (*parse.RoleManagementPolicyId, error) {
+func getPolicyId(ctx context.Context, metadata sdk.ResourceMetaData, scopeType, scopeId, roleDefinitionId string) (*parse.RoleManagementPolicyId, error) {
 	client := metadata.Client.Policies.RoleManagementPolicyAssignmentClient
 
 	options := rolemanagementpolicyassignment.ListRoleManagementPolicyAssignmentsOperationOptions{
-		Filter: pointer.To(fmt.Sprintf("scopeType eq 'Group' and scopeId eq '%s' and roleDefinitionId eq '%s'", scopeId, roleDefinitionId)),
+		Filter: pointer.To(fmt.Sprintf("scopeType eq '%s' and scopeId eq '%s' and roleDefinitionId eq '%s'", odata.EscapeSingleQuote(scopeType), odata.EscapeSingleQuote(scopeId), odata.EscapeSingleQuote(roleDefinitionId))),
 	}
 
 	resp, err := client.ListRoleManagementPolicyAssignments(ctx, options)
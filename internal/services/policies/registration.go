@@ -50,6 +50,13 @@ func (r Registration) DataSources() []sdk.DataSource {
 // Resources returns the typed Resources supported by this service
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
+		AdminConsentRequestPolicyResource{},
+		AppManagementPolicyAssignmentResource{},
+		AppManagementPolicyResource{},
+		DefaultAppManagementPolicyResource{},
+		DirectoryRoleManagementPolicyResource{},
 		GroupRoleManagementPolicyResource{},
+		PermissionGrantPolicyResource{},
+		SecurityDefaultsResource{},
 	}
 }
@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/identity/stable/conditionalaccesspolicy"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/identitysecuritydefaultsenforcementpolicy"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/policies/parse"
+)
+
+type SecurityDefaultsModel struct {
+	Description string `tfschema:"description"`
+	DisplayName string `tfschema:"display_name"`
+	Enabled     bool   `tfschema:"enabled"`
+}
+
+var _ sdk.ResourceWithUpdate = SecurityDefaultsResource{}
+
+type SecurityDefaultsResource struct{}
+
+func (r SecurityDefaultsResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateSecurityDefaultsID
+}
+
+func (r SecurityDefaultsResource) ResourceType() string {
+	return "azuread_security_defaults"
+}
+
+func (r SecurityDefaultsResource) ModelObject() interface{} {
+	return &SecurityDefaultsModel{}
+}
+
+func (r SecurityDefaultsResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"enabled": {
+			Description: "Whether security defaults are enabled for the tenant",
+			Type:        pluginsdk.TypeBool,
+			Required:    true,
+		},
+	}
+}
+
+func (r SecurityDefaultsResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"description": {
+			Description: "The description of the security defaults policy",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+
+		"display_name": {
+			Description: "The display name of the security defaults policy",
+			Type:        pluginsdk.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func (r SecurityDefaultsResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id := parse.NewSecurityDefaultsID()
+
+			var model SecurityDefaultsModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// Security defaults always exist, so creating this resource adopts them and applies the desired configuration
+			if err := updateSecurityDefaults(ctx, metadata, *id, model.Enabled); err != nil {
+				return err
+			}
+
+			metadata.SetID(id)
+
+			return nil
+		},
+	}
+}
+
+func (r SecurityDefaultsResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Policies.IdentitySecurityDefaultsEnforcementPolicyClient
+
+			id, err := parse.ParseSecurityDefaultsID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.GetIdentitySecurityDefaultsEnforcementPolicy(ctx, identitysecuritydefaultsenforcementpolicy.DefaultGetIdentitySecurityDefaultsEnforcementPolicyOperationOptions())
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			policy := resp.Model
+			if policy == nil {
+				return fmt.Errorf("retrieving %s: model was nil", id)
+			}
+
+			state := SecurityDefaultsModel{
+				Description: policy.Description.GetOrZero(),
+				DisplayName: policy.DisplayName.GetOrZero(),
+				Enabled:     pointer.From(policy.IsEnabled),
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r SecurityDefaultsResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ParseSecurityDefaultsID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model SecurityDefaultsModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			return updateSecurityDefaults(ctx, metadata, *id, model.Enabled)
+		},
+	}
+}
+
+func (r SecurityDefaultsResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// Security defaults cannot be deleted, and toggling them on destroy could leave the tenant either without
+			// baseline protection or in conflict with conditional access, so the current state is left as-is
+			log.Printf("[DEBUG] %s cannot be deleted, leaving the current configuration in place", parse.NewSecurityDefaultsID())
+			return nil
+		},
+	}
+}
+
+func updateSecurityDefaults(ctx context.Context, metadata sdk.ResourceMetaData, id parse.SecurityDefaultsId, enabled bool) error {
+	client := metadata.Client.Policies.IdentitySecurityDefaultsEnforcementPolicyClient
+
+	if enabled {
+		enabledPolicies, err := enabledConditionalAccessPolicyNames(ctx, metadata)
+		if err != nil {
+			return err
+		}
+		if len(enabledPolicies) > 0 {
+			return fmt.Errorf("security defaults cannot be enabled whilst conditional access policies are enabled, found %d enabled policies: %s", len(enabledPolicies), strings.Join(enabledPolicies, ", "))
+		}
+	}
+
+	properties := stable.IdentitySecurityDefaultsEnforcementPolicy{
+		IsEnabled: pointer.To(enabled),
+	}
+
+	if _, err := client.UpdateIdentitySecurityDefaultsEnforcementPolicy(ctx, properties, identitysecuritydefaultsenforcementpolicy.DefaultUpdateIdentitySecurityDefaultsEnforcementPolicyOperationOptions()); err != nil {
+		return fmt.Errorf("updating %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+// enabledConditionalAccessPolicyNames returns the display names of any conditional access policies that are enforced,
+// since security defaults and enforced conditional access policies are mutually exclusive
+func enabledConditionalAccessPolicyNames(ctx context.Context, metadata sdk.ResourceMetaData) ([]string, error) {
+	client := metadata.Client.Policies.ConditionalAccessPolicyClient
+
+	resp, err := client.ListConditionalAccessPolicies(ctx, conditionalaccesspolicy.DefaultListConditionalAccessPoliciesOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing conditional access policies: %+v", err)
+	}
+
+	result := make([]string, 0)
+	if resp.Model != nil {
+		for _, policy := range *resp.Model {
+			if pointer.From(policy.State) == stable.ConditionalAccessPolicyState_Enabled {
+				result = append(result, fmt.Sprintf("%q", pointer.From(policy.DisplayName)))
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policies_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/policies/stable/identitysecuritydefaultsenforcementpolicy"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type SecurityDefaultsResource struct{}
+
+func TestAccSecurityDefaults_disabled(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_security_defaults", "test")
+	r := SecurityDefaultsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.disabled(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("enabled").HasValue("false"),
+				check.That(data.ResourceName).Key("display_name").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r SecurityDefaultsResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Policies.IdentitySecurityDefaultsEnforcementPolicyClient
+
+	resp, err := client.GetIdentitySecurityDefaultsEnforcementPolicy(ctx, identitysecuritydefaultsenforcementpolicy.DefaultGetIdentitySecurityDefaultsEnforcementPolicyOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve security defaults: %v", err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (SecurityDefaultsResource) disabled() string {
+	return `
+provider "azuread" {}
+
+resource "azuread_security_defaults" "test" {
+  enabled = false
+}
+`
+}
@@ -0,0 +1,835 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/applications/stable/application"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/oauth2permissiongrants/stable/oauth2permissiongrant"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/approleassignment"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+)
+
+type AdminConsentModel struct {
+	ApplicationId             string                                      `tfschema:"application_id"`
+	ResourceAppIds            []string                                    `tfschema:"resource_app_ids"`
+	ServicePrincipalId        string                                      `tfschema:"service_principal_id"`
+	AppRoleAssignments        []AdminConsentAppRoleAssignmentModel        `tfschema:"app_role_assignment"`
+	DelegatedPermissionGrants []AdminConsentDelegatedPermissionGrantModel `tfschema:"delegated_permission_grant"`
+}
+
+type AdminConsentAppRoleAssignmentModel struct {
+	AppRoleId                        string `tfschema:"app_role_id"`
+	Id                               string `tfschema:"id"`
+	ResourceServicePrincipalObjectId string `tfschema:"resource_service_principal_object_id"`
+}
+
+type AdminConsentDelegatedPermissionGrantModel struct {
+	ClaimValues                      []string `tfschema:"claim_values"`
+	Id                               string   `tfschema:"id"`
+	ResourceServicePrincipalObjectId string   `tfschema:"resource_service_principal_object_id"`
+}
+
+var (
+	_ sdk.Resource                  = AdminConsentResource{}
+	_ sdk.ResourceWithUpdate        = AdminConsentResource{}
+	_ sdk.ResourceWithCustomizeDiff = AdminConsentResource{}
+)
+
+type AdminConsentResource struct{}
+
+func (r AdminConsentResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateAdminConsentID
+}
+
+func (r AdminConsentResource) ResourceType() string {
+	return "azuread_admin_consent"
+}
+
+func (r AdminConsentResource) ModelObject() interface{} {
+	return &AdminConsentModel{}
+}
+
+func (r AdminConsentResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"application_id": {
+			Description:  "The resource ID of the client application, whose required resource access should be consented to",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: stable.ValidateApplicationID,
+		},
+
+		"resource_app_ids": {
+			Description: "A set of client IDs of resource applications, to limit which of the required resource access entries are consented to",
+			Type:        pluginsdk.TypeSet,
+			Optional:    true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.IsUUID,
+			},
+		},
+
+		"service_principal_id": {
+			Description:  "The resource ID of the service principal for the client application, to which consent should be granted",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: stable.ValidateServicePrincipalID,
+		},
+	}
+}
+
+func (r AdminConsentResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"app_role_assignment": {
+			Description: "The app role assignments granted to the client service principal for application permissions by this resource",
+			Type:        pluginsdk.TypeList,
+			Computed:    true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"app_role_id": {
+						Description: "The ID of the assigned app role",
+						Type:        pluginsdk.TypeString,
+						Computed:    true,
+					},
+
+					"id": {
+						Description: "The ID of the app role assignment",
+						Type:        pluginsdk.TypeString,
+						Computed:    true,
+					},
+
+					"resource_service_principal_object_id": {
+						Description: "The object ID of the service principal representing the resource",
+						Type:        pluginsdk.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+
+		"delegated_permission_grant": {
+			Description: "The tenant-wide delegated permission grants for the client service principal, which were granted by this resource",
+			Type:        pluginsdk.TypeList,
+			Computed:    true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"claim_values": {
+						Description: "The claim values for the delegated permission scopes which are granted",
+						Type:        pluginsdk.TypeList,
+						Computed:    true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+
+					"id": {
+						Description: "The ID of the delegated permission grant",
+						Type:        pluginsdk.TypeString,
+						Computed:    true,
+					},
+
+					"resource_service_principal_object_id": {
+						Description: "The object ID of the service principal representing the resource",
+						Type:        pluginsdk.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r AdminConsentResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			diff := metadata.ResourceDiff
+			if diff.Id() == "" {
+				return nil
+			}
+
+			// Permissions may be added to or removed from the client application independently of this resource, so the
+			// required consent is resolved at plan time and compared with the consent which has been granted
+			var model AdminConsentModel
+			if err := metadata.DecodeDiff(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			applicationId, err := stable.ParseApplicationID(model.ApplicationId)
+			if err != nil {
+				return err
+			}
+
+			grants, appRoleAssignments, err := r.requiredConsent(ctx, metadata, *applicationId, model.ResourceAppIds)
+			if err != nil {
+				return err
+			}
+
+			if !r.consentMatches(grants, appRoleAssignments, model.DelegatedPermissionGrants, model.AppRoleAssignments) {
+				for _, key := range []string{"app_role_assignment", "delegated_permission_grant"} {
+					if err = diff.SetNewComputed(key); err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r AdminConsentResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			applicationClient := metadata.Client.Applications.ApplicationClient
+			servicePrincipalClient := metadata.Client.ServicePrincipals.ServicePrincipalClient
+
+			var model AdminConsentModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			applicationId, err := stable.ParseApplicationID(model.ApplicationId)
+			if err != nil {
+				return err
+			}
+
+			servicePrincipalId, err := stable.ParseServicePrincipalID(model.ServicePrincipalId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewAdminConsentID(servicePrincipalId.ServicePrincipalId)
+
+			// Ensure that the service principal belongs to the client application
+			applicationResp, err := applicationClient.GetApplication(ctx, *applicationId, application.GetApplicationOperationOptions{Select: pointer.To([]string{"appId"})})
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", applicationId, err)
+			}
+			if applicationResp.Model == nil {
+				return fmt.Errorf("retrieving %s: model was nil", applicationId)
+			}
+
+			servicePrincipalResp, err := servicePrincipalClient.GetServicePrincipal(ctx, *servicePrincipalId, serviceprincipal.GetServicePrincipalOperationOptions{Select: pointer.To([]string{"appId"})})
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", servicePrincipalId, err)
+			}
+			if servicePrincipalResp.Model == nil {
+				return fmt.Errorf("retrieving %s: model was nil", servicePrincipalId)
+			}
+
+			if !strings.EqualFold(applicationResp.Model.AppId.GetOrZero(), servicePrincipalResp.Model.AppId.GetOrZero()) {
+				return fmt.Errorf("%s does not belong to %s", servicePrincipalId, applicationId)
+			}
+
+			tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+			defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+			grants, appRoleAssignments, grantErr := r.grantConsent(ctx, metadata, *applicationId, *servicePrincipalId, model.ResourceAppIds, nil, nil)
+			if grantErr != nil && len(grants) == 0 && len(appRoleAssignments) == 0 {
+				return fmt.Errorf("granting %s: %+v", id, grantErr)
+			}
+
+			// Consent granted before any error is recorded in state, so that it can later be updated or removed
+			model.DelegatedPermissionGrants = grants
+			model.AppRoleAssignments = appRoleAssignments
+			metadata.SetID(id)
+			if err = metadata.Encode(&model); err != nil {
+				return fmt.Errorf("encoding: %+v", err)
+			}
+
+			if grantErr != nil {
+				return fmt.Errorf("granting %s: %+v", id, grantErr)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r AdminConsentResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			servicePrincipalClient := metadata.Client.ServicePrincipals.ServicePrincipalClient
+			applicationClient := metadata.Client.Applications.ApplicationClient
+
+			id, err := parse.ParseAdminConsentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			servicePrincipalId := stable.NewServicePrincipalID(id.ServicePrincipalId)
+
+			var model AdminConsentModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			resp, err := servicePrincipalClient.GetServicePrincipal(ctx, servicePrincipalId, serviceprincipal.GetServicePrincipalOperationOptions{Select: pointer.To([]string{"appId"})})
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", servicePrincipalId, err)
+			}
+			if resp.Model == nil {
+				return fmt.Errorf("retrieving %s: model was nil", servicePrincipalId)
+			}
+
+			state := AdminConsentModel{
+				ApplicationId:             model.ApplicationId,
+				ResourceAppIds:            model.ResourceAppIds,
+				ServicePrincipalId:        servicePrincipalId.ID(),
+				AppRoleAssignments:        make([]AdminConsentAppRoleAssignmentModel, 0),
+				DelegatedPermissionGrants: make([]AdminConsentDelegatedPermissionGrantModel, 0),
+			}
+
+			grants, err := r.listDelegatedPermissionGrants(ctx, metadata, servicePrincipalId)
+			if err != nil {
+				return err
+			}
+
+			appRoleAssignments, err := r.listAppRoleAssignments(ctx, metadata, servicePrincipalId)
+			if err != nil {
+				return err
+			}
+
+			// Only consent which was granted by this resource is managed
+			managedIds := make(map[string]bool)
+			for _, grant := range model.DelegatedPermissionGrants {
+				managedIds[strings.ToLower(grant.Id)] = true
+			}
+			for _, assignment := range model.AppRoleAssignments {
+				managedIds[strings.ToLower(assignment.Id)] = true
+			}
+
+			// The client application is not known when importing, so look it up using the client ID of the service principal
+			if state.ApplicationId == "" {
+				options := application.ListApplicationsOperationOptions{
+					Filter: pointer.To(fmt.Sprintf("appId eq '%s'", odata.EscapeSingleQuote(resp.Model.AppId.GetOrZero()))),
+				}
+				applicationsResp, err := applicationClient.ListApplications(ctx, options)
+				if err != nil {
+					return fmt.Errorf("retrieving application for %s: %+v", servicePrincipalId, err)
+				}
+				if applicationsResp.Model == nil || len(*applicationsResp.Model) != 1 || (*applicationsResp.Model)[0].Id == nil {
+					return fmt.Errorf("retrieving application for %s: expected exactly one application with client ID %q", servicePrincipalId, resp.Model.AppId.GetOrZero())
+				}
+				applicationId := stable.NewApplicationID(*(*applicationsResp.Model)[0].Id)
+				state.ApplicationId = applicationId.ID()
+
+				// When importing, existing consent is managed where it matches the required resource access of the client application
+				requiredGrants, requiredAppRoleAssignments, err := r.requiredConsent(ctx, metadata, applicationId, nil)
+				if err != nil {
+					return err
+				}
+
+				requiredKeys := make(map[string]bool)
+				for _, grant := range requiredGrants {
+					requiredKeys[strings.ToLower(grant.ResourceServicePrincipalObjectId)] = true
+				}
+				for _, grant := range grants {
+					if requiredKeys[strings.ToLower(pointer.From(grant.ResourceId))] {
+						managedIds[strings.ToLower(pointer.From(grant.Id))] = true
+					}
+				}
+
+				for _, assignment := range requiredAppRoleAssignments {
+					requiredKeys[r.appRoleAssignmentKey(assignment.ResourceServicePrincipalObjectId, assignment.AppRoleId)] = true
+				}
+				for _, assignment := range appRoleAssignments {
+					if requiredKeys[r.appRoleAssignmentKey(assignment.ResourceId.GetOrZero(), pointer.From(assignment.AppRoleId))] {
+						managedIds[strings.ToLower(pointer.From(assignment.Id))] = true
+					}
+				}
+			}
+
+			for _, grant := range grants {
+				if managedIds[strings.ToLower(pointer.From(grant.Id))] {
+					state.DelegatedPermissionGrants = append(state.DelegatedPermissionGrants, r.flattenDelegatedPermissionGrant(grant))
+				}
+			}
+
+			for _, assignment := range appRoleAssignments {
+				if managedIds[strings.ToLower(pointer.From(assignment.Id))] {
+					state.AppRoleAssignments = append(state.AppRoleAssignments, r.flattenAppRoleAssignment(assignment))
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r AdminConsentResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ParseAdminConsentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model AdminConsentModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			applicationId, err := stable.ParseApplicationID(model.ApplicationId)
+			if err != nil {
+				return err
+			}
+
+			managedGrants, managedAppRoleAssignments := r.managedConsent(metadata)
+
+			tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+			defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+			grants, appRoleAssignments, grantErr := r.grantConsent(ctx, metadata, *applicationId, stable.NewServicePrincipalID(id.ServicePrincipalId), model.ResourceAppIds, managedGrants, managedAppRoleAssignments)
+
+			// Consent granted or revoked before any error is recorded in state
+			model.DelegatedPermissionGrants = grants
+			model.AppRoleAssignments = appRoleAssignments
+			if err = metadata.Encode(&model); err != nil {
+				return fmt.Errorf("encoding: %+v", err)
+			}
+
+			if grantErr != nil {
+				return fmt.Errorf("updating %s: %+v", id, grantErr)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r AdminConsentResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ParseAdminConsentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			managedGrants, managedAppRoleAssignments := r.managedConsent(metadata)
+
+			tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+			defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+			if _, _, err = r.reconcileConsent(ctx, metadata, stable.NewServicePrincipalID(id.ServicePrincipalId), managedGrants, managedAppRoleAssignments, nil, nil); err != nil {
+				return fmt.Errorf("revoking %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// requiredConsent resolves the delegated permission grants and app role assignments required by the client application,
+// optionally limited to the specified resource applications
+func (r AdminConsentResource) requiredConsent(ctx context.Context, metadata sdk.ResourceMetaData, applicationId stable.ApplicationId, resourceAppIds []string) ([]AdminConsentDelegatedPermissionGrantModel, []AdminConsentAppRoleAssignmentModel, error) {
+	applicationClient := metadata.Client.Applications.ApplicationClient
+	servicePrincipalClient := metadata.Client.ServicePrincipals.ServicePrincipalClient
+
+	resp, err := applicationClient.GetApplication(ctx, applicationId, application.GetApplicationOperationOptions{Select: pointer.To([]string{"requiredResourceAccess"})})
+	if err != nil {
+		return nil, nil, fmt.Errorf("retrieving %s: %+v", applicationId, err)
+	}
+	if resp.Model == nil {
+		return nil, nil, fmt.Errorf("retrieving %s: model was nil", applicationId)
+	}
+
+	grants := make([]AdminConsentDelegatedPermissionGrantModel, 0)
+	appRoleAssignments := make([]AdminConsentAppRoleAssignmentModel, 0)
+
+	for _, requiredResourceAccess := range pointer.From(resp.Model.RequiredResourceAccess) {
+		resourceAppId := pointer.From(requiredResourceAccess.ResourceAppId)
+		if len(resourceAppIds) > 0 && len(tf.Difference([]string{strings.ToLower(resourceAppId)}, r.lowerCase(resourceAppIds))) > 0 {
+			continue
+		}
+
+		options := serviceprincipal.ListServicePrincipalsOperationOptions{
+			Filter: pointer.To(fmt.Sprintf("appId eq '%s'", odata.EscapeSingleQuote(resourceAppId))),
+		}
+		servicePrincipalsResp, err := servicePrincipalClient.ListServicePrincipals(ctx, options)
+		if err != nil {
+			return nil, nil, fmt.Errorf("retrieving service principal for resource application %q: %+v", resourceAppId, err)
+		}
+		if servicePrincipalsResp.Model == nil || len(*servicePrincipalsResp.Model) == 0 {
+			return nil, nil, fmt.Errorf("no service principal was found for resource application %q, which must exist in the tenant before consent can be granted", resourceAppId)
+		}
+		resourceServicePrincipal := (*servicePrincipalsResp.Model)[0]
+		resourceServicePrincipalObjectId := pointer.From(resourceServicePrincipal.Id)
+
+		scopeValues := make(map[string]string)
+		for _, scope := range pointer.From(resourceServicePrincipal.OAuth2PermissionScopes) {
+			scopeValues[strings.ToLower(pointer.From(scope.Id))] = scope.Value.GetOrZero()
+		}
+
+		claimValues := make([]string, 0)
+		for _, resourceAccess := range pointer.From(requiredResourceAccess.ResourceAccess) {
+			switch resourceAccess.Type.GetOrZero() {
+			case ResourceAccessTypeRole:
+				appRoleAssignments = append(appRoleAssignments, AdminConsentAppRoleAssignmentModel{
+					AppRoleId:                        strings.ToLower(pointer.From(resourceAccess.Id)),
+					ResourceServicePrincipalObjectId: resourceServicePrincipalObjectId,
+				})
+			case ResourceAccessTypeScope:
+				value, ok := scopeValues[strings.ToLower(pointer.From(resourceAccess.Id))]
+				if !ok || value == "" {
+					return nil, nil, fmt.Errorf("delegated permission with ID %q was not found for resource application %q", pointer.From(resourceAccess.Id), resourceAppId)
+				}
+				claimValues = append(claimValues, value)
+			}
+		}
+
+		if len(claimValues) > 0 {
+			sort.Strings(claimValues)
+			grants = append(grants, AdminConsentDelegatedPermissionGrantModel{
+				ClaimValues:                      claimValues,
+				ResourceServicePrincipalObjectId: resourceServicePrincipalObjectId,
+			})
+		}
+	}
+
+	return grants, appRoleAssignments, nil
+}
+
+// consentMatches returns whether the required consent matches the consent which has been granted
+func (r AdminConsentResource) consentMatches(requiredGrants []AdminConsentDelegatedPermissionGrantModel, requiredAppRoleAssignments []AdminConsentAppRoleAssignmentModel, grants []AdminConsentDelegatedPermissionGrantModel, appRoleAssignments []AdminConsentAppRoleAssignmentModel) bool {
+	keys := func(grants []AdminConsentDelegatedPermissionGrantModel, appRoleAssignments []AdminConsentAppRoleAssignmentModel) []string {
+		result := make([]string, 0)
+		for _, grant := range grants {
+			claimValues := append([]string{}, grant.ClaimValues...)
+			sort.Strings(claimValues)
+			result = append(result, strings.ToLower(fmt.Sprintf("grant/%s/%s", grant.ResourceServicePrincipalObjectId, strings.Join(claimValues, " "))))
+		}
+		for _, assignment := range appRoleAssignments {
+			result = append(result, strings.ToLower(fmt.Sprintf("role/%s/%s", assignment.ResourceServicePrincipalObjectId, assignment.AppRoleId)))
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	required := keys(requiredGrants, requiredAppRoleAssignments)
+	existing := keys(grants, appRoleAssignments)
+
+	return len(tf.Difference(required, existing)) == 0 && len(tf.Difference(existing, required)) == 0
+}
+
+// grantConsent resolves the consent required by the client application, and grants it to the client service principal
+func (r AdminConsentResource) grantConsent(ctx context.Context, metadata sdk.ResourceMetaData, applicationId stable.ApplicationId, servicePrincipalId stable.ServicePrincipalId, resourceAppIds []string, managedGrants []AdminConsentDelegatedPermissionGrantModel, managedAppRoleAssignments []AdminConsentAppRoleAssignmentModel) ([]AdminConsentDelegatedPermissionGrantModel, []AdminConsentAppRoleAssignmentModel, error) {
+	grants, appRoleAssignments, err := r.requiredConsent(ctx, metadata, applicationId, resourceAppIds)
+	if err != nil {
+		return managedGrants, managedAppRoleAssignments, err
+	}
+
+	return r.reconcileConsent(ctx, metadata, servicePrincipalId, managedGrants, managedAppRoleAssignments, grants, appRoleAssignments)
+}
+
+// managedConsent returns the delegated permission grants and app role assignments which were previously granted by this
+// resource. These are read from prior state, since they are unknown in the planned values when consent is being updated.
+func (r AdminConsentResource) managedConsent(metadata sdk.ResourceMetaData) ([]AdminConsentDelegatedPermissionGrantModel, []AdminConsentAppRoleAssignmentModel) {
+	grants := make([]AdminConsentDelegatedPermissionGrantModel, 0)
+	appRoleAssignments := make([]AdminConsentAppRoleAssignmentModel, 0)
+
+	oldGrants, _ := metadata.ResourceData.GetChange("delegated_permission_grant")
+	for _, raw := range oldGrants.([]interface{}) {
+		if v, ok := raw.(map[string]interface{}); ok {
+			grants = append(grants, AdminConsentDelegatedPermissionGrantModel{
+				ClaimValues:                      tf.ExpandStringSlice(v["claim_values"].([]interface{})),
+				Id:                               v["id"].(string),
+				ResourceServicePrincipalObjectId: v["resource_service_principal_object_id"].(string),
+			})
+		}
+	}
+
+	oldAppRoleAssignments, _ := metadata.ResourceData.GetChange("app_role_assignment")
+	for _, raw := range oldAppRoleAssignments.([]interface{}) {
+		if v, ok := raw.(map[string]interface{}); ok {
+			appRoleAssignments = append(appRoleAssignments, AdminConsentAppRoleAssignmentModel{
+				AppRoleId:                        v["app_role_id"].(string),
+				Id:                               v["id"].(string),
+				ResourceServicePrincipalObjectId: v["resource_service_principal_object_id"].(string),
+			})
+		}
+	}
+
+	return grants, appRoleAssignments
+}
+
+// reconcileConsent creates and updates tenant-wide delegated permission grants and app role assignments for the client
+// service principal, and removes those previously granted by this resource which are no longer required. Consent which
+// was not granted by this resource is never modified. The consent now managed by this resource is returned, including
+// when an error occurs.
+func (r AdminConsentResource) reconcileConsent(ctx context.Context, metadata sdk.ResourceMetaData, servicePrincipalId stable.ServicePrincipalId, managedGrants []AdminConsentDelegatedPermissionGrantModel, managedAppRoleAssignments []AdminConsentAppRoleAssignmentModel, grants []AdminConsentDelegatedPermissionGrantModel, appRoleAssignments []AdminConsentAppRoleAssignmentModel) ([]AdminConsentDelegatedPermissionGrantModel, []AdminConsentAppRoleAssignmentModel, error) {
+	grantClient := metadata.Client.ServicePrincipals.OAuth2PermissionGrantClient
+	appRoleAssignmentClient := metadata.Client.ServicePrincipals.AppRoleAssignmentClient
+
+	existingGrants, err := r.listDelegatedPermissionGrants(ctx, metadata, servicePrincipalId)
+	if err != nil {
+		return managedGrants, managedAppRoleAssignments, err
+	}
+
+	managedGrantIds := make(map[string]bool)
+	for _, grant := range managedGrants {
+		managedGrantIds[strings.ToLower(grant.Id)] = true
+	}
+
+	// Managed grants which no longer exist are forgotten, and are recreated below if still required
+	managedGrantsByResource := make(map[string]stable.OAuth2PermissionGrant)
+	unmanagedGrantsByResource := make(map[string]stable.OAuth2PermissionGrant)
+	for _, grant := range existingGrants {
+		resourceKey := strings.ToLower(pointer.From(grant.ResourceId))
+		if managedGrantIds[strings.ToLower(pointer.From(grant.Id))] {
+			managedGrantsByResource[resourceKey] = grant
+		} else {
+			unmanagedGrantsByResource[resourceKey] = grant
+		}
+	}
+
+	resultGrants := make([]AdminConsentDelegatedPermissionGrantModel, 0)
+	currentGrants := func() []AdminConsentDelegatedPermissionGrantModel {
+		result := append([]AdminConsentDelegatedPermissionGrantModel{}, resultGrants...)
+		for _, grant := range managedGrantsByResource {
+			result = append(result, r.flattenDelegatedPermissionGrant(grant))
+		}
+		return result
+	}
+
+	for _, grant := range grants {
+		scope := strings.Join(grant.ClaimValues, " ")
+		resourceKey := strings.ToLower(grant.ResourceServicePrincipalObjectId)
+
+		if existing, ok := managedGrantsByResource[resourceKey]; ok {
+			if strings.Join(r.sortedClaimValues(existing.Scope.GetOrZero()), " ") != scope {
+				grantId := stable.NewOAuth2PermissionGrantID(pointer.From(existing.Id))
+				properties := stable.OAuth2PermissionGrant{
+					Scope: nullable.Value(scope),
+				}
+				if _, err = grantClient.UpdateOAuth2PermissionGrant(ctx, grantId, properties, oauth2permissiongrant.DefaultUpdateOAuth2PermissionGrantOperationOptions()); err != nil {
+					return currentGrants(), managedAppRoleAssignments, fmt.Errorf("updating %s: %+v", grantId, err)
+				}
+				existing.Scope = nullable.Value(scope)
+			}
+
+			delete(managedGrantsByResource, resourceKey)
+			resultGrants = append(resultGrants, r.flattenDelegatedPermissionGrant(existing))
+			continue
+		}
+
+		if existing, ok := unmanagedGrantsByResource[resourceKey]; ok {
+			return currentGrants(), managedAppRoleAssignments, fmt.Errorf("a tenant-wide delegated permission grant with ID %q already exists for resource service principal %q, which was not granted by this resource and must be removed or imported", pointer.From(existing.Id), grant.ResourceServicePrincipalObjectId)
+		}
+
+		properties := stable.OAuth2PermissionGrant{
+			ClientId:    servicePrincipalId.ServicePrincipalId,
+			ConsentType: nullable.Value(DelegatedPermissionGrantConsentTypeAllPrincipals),
+			ResourceId:  pointer.To(grant.ResourceServicePrincipalObjectId),
+			Scope:       nullable.Value(scope),
+		}
+
+		options := oauth2permissiongrant.CreateOAuth2PermissionGrantOperationOptions{
+			RetryFunc: func(resp *http.Response, o *odata.OData) (bool, error) {
+				if response.WasNotFound(resp) {
+					return true, nil
+				} else if response.WasBadRequest(resp) && o != nil && o.Error != nil {
+					return o.Error.Match("does not exist or one of its queried reference-property objects are not present"), nil
+				}
+				return false, nil
+			},
+		}
+
+		resp, err := grantClient.CreateOAuth2PermissionGrant(ctx, properties, options)
+		if err != nil {
+			return currentGrants(), managedAppRoleAssignments, fmt.Errorf("creating delegated permission grant for resource service principal %q: %+v", grant.ResourceServicePrincipalObjectId, err)
+		}
+		if resp.Model == nil || resp.Model.Id == nil {
+			return currentGrants(), managedAppRoleAssignments, fmt.Errorf("creating delegated permission grant for resource service principal %q: API returned nil object ID", grant.ResourceServicePrincipalObjectId)
+		}
+
+		resultGrants = append(resultGrants, r.flattenDelegatedPermissionGrant(*resp.Model))
+	}
+
+	// Any remaining managed grants are stale
+	for resourceKey, grant := range managedGrantsByResource {
+		grantId := stable.NewOAuth2PermissionGrantID(pointer.From(grant.Id))
+		if resp, err := grantClient.DeleteOAuth2PermissionGrant(ctx, grantId, oauth2permissiongrant.DefaultDeleteOAuth2PermissionGrantOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+			return currentGrants(), managedAppRoleAssignments, fmt.Errorf("removing stale %s: %+v", grantId, err)
+		}
+		delete(managedGrantsByResource, resourceKey)
+	}
+
+	existingAppRoleAssignments, err := r.listAppRoleAssignments(ctx, metadata, servicePrincipalId)
+	if err != nil {
+		return resultGrants, managedAppRoleAssignments, err
+	}
+
+	managedAppRoleAssignmentIds := make(map[string]bool)
+	for _, assignment := range managedAppRoleAssignments {
+		managedAppRoleAssignmentIds[strings.ToLower(assignment.Id)] = true
+	}
+
+	// Managed app role assignments which no longer exist are forgotten, and are recreated below if still required
+	managedAppRoleAssignmentsByKey := make(map[string]stable.AppRoleAssignment)
+	unmanagedAppRoleAssignmentsByKey := make(map[string]stable.AppRoleAssignment)
+	for _, assignment := range existingAppRoleAssignments {
+		key := r.appRoleAssignmentKey(assignment.ResourceId.GetOrZero(), pointer.From(assignment.AppRoleId))
+		if managedAppRoleAssignmentIds[strings.ToLower(pointer.From(assignment.Id))] {
+			managedAppRoleAssignmentsByKey[key] = assignment
+		} else {
+			unmanagedAppRoleAssignmentsByKey[key] = assignment
+		}
+	}
+
+	resultAppRoleAssignments := make([]AdminConsentAppRoleAssignmentModel, 0)
+	currentAppRoleAssignments := func() []AdminConsentAppRoleAssignmentModel {
+		result := append([]AdminConsentAppRoleAssignmentModel{}, resultAppRoleAssignments...)
+		for _, assignment := range managedAppRoleAssignmentsByKey {
+			result = append(result, r.flattenAppRoleAssignment(assignment))
+		}
+		return result
+	}
+
+	for _, assignment := range appRoleAssignments {
+		key := r.appRoleAssignmentKey(assignment.ResourceServicePrincipalObjectId, assignment.AppRoleId)
+		if existing, ok := managedAppRoleAssignmentsByKey[key]; ok {
+			delete(managedAppRoleAssignmentsByKey, key)
+			resultAppRoleAssignments = append(resultAppRoleAssignments, r.flattenAppRoleAssignment(existing))
+			continue
+		}
+
+		if existing, ok := unmanagedAppRoleAssignmentsByKey[key]; ok {
+			return resultGrants, currentAppRoleAssignments(), fmt.Errorf("an assignment with ID %q already exists for app role %q of resource service principal %q, which was not granted by this resource and must be removed or imported", pointer.From(existing.Id), assignment.AppRoleId, assignment.ResourceServicePrincipalObjectId)
+		}
+
+		properties := stable.AppRoleAssignment{
+			AppRoleId:   pointer.To(assignment.AppRoleId),
+			PrincipalId: nullable.Value(servicePrincipalId.ServicePrincipalId),
+			ResourceId:  nullable.Value(assignment.ResourceServicePrincipalObjectId),
+		}
+
+		options := approleassignment.CreateAppRoleAssignmentOperationOptions{
+			RetryFunc: func(resp *http.Response, o *odata.OData) (bool, error) {
+				if response.WasNotFound(resp) {
+					return true, nil
+				} else if response.WasBadRequest(resp) && o != nil && o.Error != nil {
+					return o.Error.Match("Not a valid reference update"), nil
+				}
+				return false, nil
+			},
+		}
+
+		resp, err := appRoleAssignmentClient.CreateAppRoleAssignment(ctx, servicePrincipalId, properties, options)
+		if err != nil {
+			return resultGrants, currentAppRoleAssignments(), fmt.Errorf("assigning app role %q for resource service principal %q: %+v", assignment.AppRoleId, assignment.ResourceServicePrincipalObjectId, err)
+		}
+		if resp.Model == nil || resp.Model.Id == nil {
+			return resultGrants, currentAppRoleAssignments(), fmt.Errorf("assigning app role %q for resource service principal %q: API returned nil object ID", assignment.AppRoleId, assignment.ResourceServicePrincipalObjectId)
+		}
+
+		resultAppRoleAssignments = append(resultAppRoleAssignments, r.flattenAppRoleAssignment(*resp.Model))
+	}
+
+	// Any remaining managed app role assignments are stale
+	for key, assignment := range managedAppRoleAssignmentsByKey {
+		assignmentId := stable.NewServicePrincipalIdAppRoleAssignmentID(servicePrincipalId.ServicePrincipalId, pointer.From(assignment.Id))
+		if resp, err := appRoleAssignmentClient.DeleteAppRoleAssignment(ctx, assignmentId, approleassignment.DefaultDeleteAppRoleAssignmentOperationOptions()); err != nil && !response.WasNotFound(resp.HttpResponse) {
+			return resultGrants, currentAppRoleAssignments(), fmt.Errorf("removing stale %s: %+v", assignmentId, err)
+		}
+		delete(managedAppRoleAssignmentsByKey, key)
+	}
+
+	return resultGrants, resultAppRoleAssignments, nil
+}
+
+// listDelegatedPermissionGrants returns the tenant-wide delegated permission grants for the client service principal
+func (r AdminConsentResource) listDelegatedPermissionGrants(ctx context.Context, metadata sdk.ResourceMetaData, servicePrincipalId stable.ServicePrincipalId) ([]stable.OAuth2PermissionGrant, error) {
+	client := metadata.Client.ServicePrincipals.OAuth2PermissionGrantClient
+
+	options := oauth2permissiongrant.ListOAuth2PermissionGrantsOperationOptions{
+		Filter: pointer.To(fmt.Sprintf("clientId eq '%s' and consentType eq '%s'", servicePrincipalId.ServicePrincipalId, DelegatedPermissionGrantConsentTypeAllPrincipals)),
+	}
+
+	resp, err := client.ListOAuth2PermissionGrants(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("listing delegated permission grants for %s: %+v", servicePrincipalId, err)
+	}
+	if resp.Model == nil {
+		return nil, errors.New("listing delegated permission grants: model was nil")
+	}
+
+	return *resp.Model, nil
+}
+
+// listAppRoleAssignments returns the app role assignments for the client service principal
+func (r AdminConsentResource) listAppRoleAssignments(ctx context.Context, metadata sdk.ResourceMetaData, servicePrincipalId stable.ServicePrincipalId) ([]stable.AppRoleAssignment, error) {
+	client := metadata.Client.ServicePrincipals.AppRoleAssignmentClient
+
+	resp, err := client.ListAppRoleAssignments(ctx, servicePrincipalId, approleassignment.DefaultListAppRoleAssignmentsOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing app role assignments for %s: %+v", servicePrincipalId, err)
+	}
+	if resp.Model == nil {
+		return nil, errors.New("listing app role assignments: model was nil")
+	}
+
+	return *resp.Model, nil
+}
+
+func (r AdminConsentResource) sortedClaimValues(scope string) []string {
+	claimValues := tf.FromSpaceSeparated(scope)
+	sort.Strings(claimValues)
+	return claimValues
+}
+
+func (r AdminConsentResource) lowerCase(in []string) []string {
+	result := make([]string, 0, len(in))
+	for _, v := range in {
+		result = append(result, strings.ToLower(v))
+	}
+	return result
+}
+
+func (r AdminConsentResource) appRoleAssignmentKey(resourceServicePrincipalObjectId, appRoleId string) string {
+	return strings.ToLower(fmt.Sprintf("%s/%s", resourceServicePrincipalObjectId, appRoleId))
+}
+
+func (r AdminConsentResource) flattenDelegatedPermissionGrant(grant stable.OAuth2PermissionGrant) AdminConsentDelegatedPermissionGrantModel {
+	return AdminConsentDelegatedPermissionGrantModel{
+		ClaimValues:                      r.sortedClaimValues(grant.Scope.GetOrZero()),
+		Id:                               pointer.From(grant.Id),
+		ResourceServicePrincipalObjectId: pointer.From(grant.ResourceId),
+	}
+}
+
+func (r AdminConsentResource) flattenAppRoleAssignment(assignment stable.AppRoleAssignment) AdminConsentAppRoleAssignmentModel {
+	return AdminConsentAppRoleAssignmentModel{
+		AppRoleId:                        pointer.From(assignment.AppRoleId),
+		Id:                               pointer.From(assignment.Id),
+		ResourceServicePrincipalObjectId: assignment.ResourceId.GetOrZero(),
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/oauth2permissiongrants/stable/oauth2permissiongrant"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+)
+
+type AdminConsentResource struct{}
+
+func TestAccAdminConsent_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_admin_consent", "test")
+	r := AdminConsentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("delegated_permission_grant.#").HasValue("1"),
+				check.That(data.ResourceName).Key("delegated_permission_grant.0.claim_values.#").HasValue("2"),
+				check.That(data.ResourceName).Key("app_role_assignment.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccAdminConsent_removePermissions(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_admin_consent", "test")
+	r := AdminConsentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.reduced(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("delegated_permission_grant.#").HasValue("1"),
+				check.That(data.ResourceName).Key("delegated_permission_grant.0.claim_values.#").HasValue("1"),
+				check.That(data.ResourceName).Key("app_role_assignment.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("delegated_permission_grant.0.claim_values.#").HasValue("2"),
+				check.That(data.ResourceName).Key("app_role_assignment.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccAdminConsent_resourceAppIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_admin_consent", "test")
+	r := AdminConsentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.resourceAppIds(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("delegated_permission_grant.#").HasValue("1"),
+				check.That(data.ResourceName).Key("resource_app_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("resource_app_ids"),
+	})
+}
+
+func TestAccAdminConsent_existingConsent(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_admin_consent", "test")
+	r := AdminConsentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.existingConsent(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("delegated_permission_grant.#").HasValue("1"),
+				check.That(data.ResourceName).Key("delegated_permission_grant.0.resource_service_principal_object_id").MatchesOtherKey(check.That("azuread_service_principal.msgraph").Key("object_id")),
+				check.That("azuread_service_principal_delegated_permission_grant.test").ExistsInAzure(ServicePrincipalDelegatedPermissionGrantResource{}),
+			),
+		},
+	})
+}
+
+func (r AdminConsentResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	servicePrincipalClient := clients.ServicePrincipals.ServicePrincipalClient
+	grantClient := clients.ServicePrincipals.OAuth2PermissionGrantClient
+
+	id, err := parse.ParseAdminConsentID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	servicePrincipalId := stable.NewServicePrincipalID(id.ServicePrincipalId)
+
+	resp, err := servicePrincipalClient.GetServicePrincipal(ctx, servicePrincipalId, serviceprincipal.DefaultGetServicePrincipalOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", servicePrincipalId, err)
+	}
+
+	options := oauth2permissiongrant.ListOAuth2PermissionGrantsOperationOptions{
+		Filter: pointer.To(fmt.Sprintf("clientId eq '%s' and consentType eq 'AllPrincipals'", id.ServicePrincipalId)),
+	}
+	grantsResp, err := grantClient.ListOAuth2PermissionGrants(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("listing delegated permission grants for %s: %+v", servicePrincipalId, err)
+	}
+
+	return pointer.To(grantsResp.Model != nil && len(*grantsResp.Model) > 0), nil
+}
+
+func (AdminConsentResource) template(data acceptance.TestData) string {
+	return `
+provider "azuread" {}
+
+data "azuread_application_published_app_ids" "well_known" {}
+
+resource "azuread_service_principal" "msgraph" {
+  client_id    = data.azuread_application_published_app_ids.well_known.result.MicrosoftGraph
+  use_existing = true
+}
+`
+}
+
+func (r AdminConsentResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application" "test" {
+  display_name = "acctest-AdminConsent-%[2]d"
+
+  required_resource_access {
+    resource_app_id = data.azuread_application_published_app_ids.well_known.result.MicrosoftGraph
+
+    resource_access {
+      id   = azuread_service_principal.msgraph.oauth2_permission_scope_ids["openid"]
+      type = "Scope"
+    }
+
+    resource_access {
+      id   = azuread_service_principal.msgraph.oauth2_permission_scope_ids["User.Read"]
+      type = "Scope"
+    }
+
+    resource_access {
+      id   = azuread_service_principal.msgraph.app_role_ids["User.Read.All"]
+      type = "Role"
+    }
+  }
+}
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application.test.client_id
+}
+
+resource "azuread_admin_consent" "test" {
+  application_id       = azuread_application.test.id
+  service_principal_id = azuread_service_principal.test.id
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r AdminConsentResource) reduced(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application" "test" {
+  display_name = "acctest-AdminConsent-%[2]d"
+
+  required_resource_access {
+    resource_app_id = data.azuread_application_published_app_ids.well_known.result.MicrosoftGraph
+
+    resource_access {
+      id   = azuread_service_principal.msgraph.oauth2_permission_scope_ids["User.Read"]
+      type = "Scope"
+    }
+  }
+}
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application.test.client_id
+}
+
+resource "azuread_admin_consent" "test" {
+  application_id       = azuread_application.test.id
+  service_principal_id = azuread_service_principal.test.id
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r AdminConsentResource) resourceAppIds(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application" "test" {
+  display_name = "acctest-AdminConsent-%[2]d"
+
+  required_resource_access {
+    resource_app_id = data.azuread_application_published_app_ids.well_known.result.MicrosoftGraph
+
+    resource_access {
+      id   = azuread_service_principal.msgraph.oauth2_permission_scope_ids["User.Read"]
+      type = "Scope"
+    }
+  }
+
+  required_resource_access {
+    resource_app_id = data.azuread_application_published_app_ids.well_known.result.Office365SharePointOnline
+
+    resource_access {
+      id   = "4e0d77b0-96ba-4398-af14-3baa780278f4" # AllSites.Read
+      type = "Scope"
+    }
+  }
+}
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application.test.client_id
+}
+
+resource "azuread_admin_consent" "test" {
+  application_id       = azuread_application.test.id
+  service_principal_id = azuread_service_principal.test.id
+  resource_app_ids     = [data.azuread_application_published_app_ids.well_known.result.MicrosoftGraph]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r AdminConsentResource) existingConsent(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal" "sharepoint" {
+  client_id    = data.azuread_application_published_app_ids.well_known.result.Office365SharePointOnline
+  use_existing = true
+}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-AdminConsent-%[2]d"
+
+  required_resource_access {
+    resource_app_id = data.azuread_application_published_app_ids.well_known.result.MicrosoftGraph
+
+    resource_access {
+      id   = azuread_service_principal.msgraph.oauth2_permission_scope_ids["User.Read"]
+      type = "Scope"
+    }
+  }
+}
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application.test.client_id
+}
+
+resource "azuread_service_principal_delegated_permission_grant" "test" {
+  service_principal_object_id          = azuread_service_principal.test.object_id
+  resource_service_principal_object_id = azuread_service_principal.sharepoint.object_id
+  claim_values                         = ["AllSites.Read"]
+}
+
+resource "azuread_admin_consent" "test" {
+  application_id       = azuread_application.test.id
+  service_principal_id = azuread_service_principal.test.id
+
+  depends_on = [azuread_service_principal_delegated_permission_grant.test]
+}
+`, r.template(data), data.RandomInteger)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/directoryobjects/stable/directoryobject"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/oauth2permissiongrants/stable/oauth2permissiongrant"
 	serviceprincipalBeta "github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/beta/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/approleassignment"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/claimsmappingpolicy"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/owner"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
@@ -15,6 +16,7 @@ import (
 )
 
 type Client struct {
+	AppRoleAssignmentClient     *approleassignment.AppRoleAssignmentClient
 	ClaimsMappingPolicyClient   *claimsmappingpolicy.ClaimsMappingPolicyClient
 	DirectoryObjectClient       *directoryobject.DirectoryObjectClient
 	OAuth2PermissionGrantClient *oauth2permissiongrant.OAuth2PermissionGrantClient
@@ -25,6 +27,12 @@ type Client struct {
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
+	appRoleAssignmentClient, err := approleassignment.NewAppRoleAssignmentClientWithBaseURI(o.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, err
+	}
+	o.Configure(appRoleAssignmentClient.Client)
+
 	claimsMappingPolicyClient, err := claimsmappingpolicy.NewClaimsMappingPolicyClientWithBaseURI(o.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, err
@@ -69,6 +77,7 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	o.Configure(synchronizationJobClient.Client)
 
 	return &Client{
+		AppRoleAssignmentClient:     appRoleAssignmentClient,
 		ClaimsMappingPolicyClient:   claimsMappingPolicyClient,
 		DirectoryObjectClient:       directoryObjectClient,
 		OAuth2PermissionGrantClient: oAuth2PermissionGrantClient,
@@ -8,6 +8,11 @@ const (
 	DelegatedPermissionGrantConsentTypePrincipal     = "Principal"
 )
 
+const (
+	ResourceAccessTypeRole  = "Role"
+	ResourceAccessTypeScope = "Scope"
+)
+
 const (
 	KeyCredentialTypeAsymmetricX509Cert  = "AsymmetricX509Cert"
 	KeyCredentialTypeX509CertAndPassword = "X509CertAndPassword"
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type AdminConsentId struct {
+	ServicePrincipalId string
+}
+
+func NewAdminConsentID(servicePrincipalId string) *AdminConsentId {
+	return &AdminConsentId{
+		ServicePrincipalId: servicePrincipalId,
+	}
+}
+
+// ParseAdminConsentID parses 'input' into an AdminConsentId
+func ParseAdminConsentID(input string) (*AdminConsentId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&AdminConsentId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	var ok bool
+	id := &AdminConsentId{}
+
+	if id.ServicePrincipalId, ok = parsed.Parsed["servicePrincipalId"]; !ok {
+		return nil, resourceids.NewSegmentNotSpecifiedError(id, "servicePrincipalId", *parsed)
+	}
+
+	return id, nil
+}
+
+// ValidateAdminConsentID checks that 'input' can be parsed as an Admin Consent ID
+func ValidateAdminConsentID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := ParseAdminConsentID(v)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+
+	return validation.IsUUID(id.ServicePrincipalId, "ID")
+}
+
+func (id *AdminConsentId) ID() string {
+	fmtString := "/servicePrincipals/%s/adminConsent"
+	return fmt.Sprintf(fmtString, id.ServicePrincipalId)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this ID
+func (id *AdminConsentId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("servicePrincipals", "servicePrincipals", "servicePrincipals"),
+		resourceids.UserSpecifiedSegment("servicePrincipalId", "00000000-0000-0000-0000-000000000000"),
+		resourceids.StaticSegment("adminConsent", "adminConsent", "adminConsent"),
+	}
+}
+
+func (id *AdminConsentId) String() string {
+	return fmt.Sprintf("Admin Consent (Service Principal ID: %q)", id.ServicePrincipalId)
+}
+
+func (id *AdminConsentId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.ServicePrincipalId, ok = input.Parsed["servicePrincipalId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "servicePrincipalId", input)
+	}
+
+	return nil
+}
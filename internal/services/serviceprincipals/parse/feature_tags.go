@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type FeatureTagsId struct {
+	ServicePrincipalId string
+}
+
+func NewFeatureTagsID(servicePrincipalId string) *FeatureTagsId {
+	return &FeatureTagsId{
+		ServicePrincipalId: servicePrincipalId,
+	}
+}
+
+// ParseFeatureTagsID parses 'input' into a FeatureTagsId
+func ParseFeatureTagsID(input string) (*FeatureTagsId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&FeatureTagsId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	var ok bool
+	id := &FeatureTagsId{}
+
+	if id.ServicePrincipalId, ok = parsed.Parsed["servicePrincipalId"]; !ok {
+		return nil, resourceids.NewSegmentNotSpecifiedError(id, "servicePrincipalId", *parsed)
+	}
+
+	return id, nil
+}
+
+// ValidateFeatureTagsID checks that 'input' can be parsed as a Feature Tags ID
+func ValidateFeatureTagsID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := ParseFeatureTagsID(v)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+
+	return validation.IsUUID(id.ServicePrincipalId, "ID")
+}
+
+func (id *FeatureTagsId) ID() string {
+	fmtString := "/servicePrincipals/%s/featureTags"
+	return fmt.Sprintf(fmtString, id.ServicePrincipalId)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this ID
+func (id *FeatureTagsId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("servicePrincipals", "servicePrincipals", "servicePrincipals"),
+		resourceids.UserSpecifiedSegment("servicePrincipalId", "00000000-0000-0000-0000-000000000000"),
+		resourceids.StaticSegment("featureTags", "featureTags", "featureTags"),
+	}
+}
+
+func (id *FeatureTagsId) String() string {
+	return fmt.Sprintf("Feature Tags (Service Principal ID: %q)", id.ServicePrincipalId)
+}
+
+func (id *FeatureTagsId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.ServicePrincipalId, ok = input.Parsed["servicePrincipalId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "servicePrincipalId", input)
+	}
+
+	return nil
+}
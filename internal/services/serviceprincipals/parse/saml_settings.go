@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+type SamlSettingsId struct {
+	ServicePrincipalId string
+}
+
+func NewSamlSettingsID(servicePrincipalId string) *SamlSettingsId {
+	return &SamlSettingsId{
+		ServicePrincipalId: servicePrincipalId,
+	}
+}
+
+// ParseSamlSettingsID parses 'input' into a SamlSettingsId
+func ParseSamlSettingsID(input string) (*SamlSettingsId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&SamlSettingsId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	var ok bool
+	id := &SamlSettingsId{}
+
+	if id.ServicePrincipalId, ok = parsed.Parsed["servicePrincipalId"]; !ok {
+		return nil, resourceids.NewSegmentNotSpecifiedError(id, "servicePrincipalId", *parsed)
+	}
+
+	return id, nil
+}
+
+// ValidateSamlSettingsID checks that 'input' can be parsed as a SAML Settings ID
+func ValidateSamlSettingsID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	id, err := ParseSamlSettingsID(v)
+	if err != nil {
+		errors = append(errors, err)
+		return
+	}
+
+	return validation.IsUUID(id.ServicePrincipalId, "ID")
+}
+
+func (id *SamlSettingsId) ID() string {
+	fmtString := "/servicePrincipals/%s/samlSettings"
+	return fmt.Sprintf(fmtString, id.ServicePrincipalId)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this ID
+func (id *SamlSettingsId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("servicePrincipals", "servicePrincipals", "servicePrincipals"),
+		resourceids.UserSpecifiedSegment("servicePrincipalId", "00000000-0000-0000-0000-000000000000"),
+		resourceids.StaticSegment("samlSettings", "samlSettings", "samlSettings"),
+	}
+}
+
+func (id *SamlSettingsId) String() string {
+	return fmt.Sprintf("SAML Settings (Service Principal ID: %q)", id.ServicePrincipalId)
+}
+
+func (id *SamlSettingsId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.ServicePrincipalId, ok = input.Parsed["servicePrincipalId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "servicePrincipalId", input)
+	}
+
+	return nil
+}
@@ -56,5 +56,10 @@ func (r Registration) DataSources() []sdk.DataSource {
 
 // Resources returns the typed Resources supported by this service
 func (r Registration) Resources() []sdk.Resource {
-	return []sdk.Resource{}
+	return []sdk.Resource{
+		AdminConsentResource{},
+		ServicePrincipalFeatureTagsResource{},
+		ServicePrincipalOwnerResource{},
+		ServicePrincipalSamlSettingsResource{},
+	}
 }
@@ -0,0 +1,266 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/applications"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+)
+
+type ServicePrincipalFeatureTagsModel struct {
+	ServicePrincipalId string `tfschema:"service_principal_id"`
+	CustomSingleSignOn bool   `tfschema:"custom_single_sign_on"`
+	Enterprise         bool   `tfschema:"enterprise"`
+	Gallery            bool   `tfschema:"gallery"`
+	Hide               bool   `tfschema:"hide"`
+}
+
+var (
+	_ sdk.Resource           = ServicePrincipalFeatureTagsResource{}
+	_ sdk.ResourceWithUpdate = ServicePrincipalFeatureTagsResource{}
+)
+
+type ServicePrincipalFeatureTagsResource struct{}
+
+func (r ServicePrincipalFeatureTagsResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateFeatureTagsID
+}
+
+func (r ServicePrincipalFeatureTagsResource) ResourceType() string {
+	return "azuread_service_principal_feature_tags"
+}
+
+func (r ServicePrincipalFeatureTagsResource) ModelObject() interface{} {
+	return &ServicePrincipalFeatureTagsModel{}
+}
+
+func (r ServicePrincipalFeatureTagsResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"service_principal_id": {
+			Description:  "The resource ID of the service principal for which to manage feature tags",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: stable.ValidateServicePrincipalID,
+		},
+
+		"custom_single_sign_on": {
+			Description: "Whether this service principal represents a custom SAML application",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+		},
+
+		"enterprise": {
+			Description: "Whether this service principal represents an Enterprise Application",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+		},
+
+		"gallery": {
+			Description: "Whether this service principal represents a gallery application",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+		},
+
+		"hide": {
+			Description: "Whether this app is invisible to users in My Apps and Office 365 Launcher",
+			Type:        pluginsdk.TypeBool,
+			Optional:    true,
+		},
+	}
+}
+
+func (r ServicePrincipalFeatureTagsResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r ServicePrincipalFeatureTagsResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model ServicePrincipalFeatureTagsModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			servicePrincipalId, err := stable.ParseServicePrincipalID(model.ServicePrincipalId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewFeatureTagsID(servicePrincipalId.ServicePrincipalId)
+
+			tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+			defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+			if err = r.setFeatureTags(ctx, metadata, *servicePrincipalId, r.expandFeatureTags(model)); err != nil {
+				return fmt.Errorf("setting %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ServicePrincipalFeatureTagsResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ServicePrincipals.ServicePrincipalClient
+
+			id, err := parse.ParseFeatureTagsID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			servicePrincipalId := stable.NewServicePrincipalID(id.ServicePrincipalId)
+
+			options := serviceprincipal.GetServicePrincipalOperationOptions{
+				Select: pointer.To([]string{"tags"}),
+			}
+
+			resp, err := client.GetServicePrincipal(ctx, servicePrincipalId, options)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if resp.Model == nil {
+				return fmt.Errorf("retrieving %s: result was nil", id)
+			}
+
+			features := applications.FlattenFeatures(resp.Model.Tags, false)[0].(map[string]bool)
+
+			state := ServicePrincipalFeatureTagsModel{
+				ServicePrincipalId: servicePrincipalId.ID(),
+				CustomSingleSignOn: features["custom_single_sign_on"],
+				Enterprise:         features["enterprise"],
+				Gallery:            features["gallery"],
+				Hide:               features["hide"],
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ServicePrincipalFeatureTagsResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ParseFeatureTagsID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model ServicePrincipalFeatureTagsModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+			defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+			if err = r.setFeatureTags(ctx, metadata, stable.NewServicePrincipalID(id.ServicePrincipalId), r.expandFeatureTags(model)); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ServicePrincipalFeatureTagsResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ParseFeatureTagsID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+			defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+			if err = r.setFeatureTags(ctx, metadata, stable.NewServicePrincipalID(id.ServicePrincipalId), []string{}); err != nil {
+				return fmt.Errorf("removing %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ServicePrincipalFeatureTagsResource) expandFeatureTags(model ServicePrincipalFeatureTagsModel) []string {
+	return applications.ExpandFeatures([]interface{}{map[string]interface{}{
+		"custom_single_sign_on": model.CustomSingleSignOn,
+		"enterprise":            model.Enterprise,
+		"gallery":               model.Gallery,
+		"hide":                  model.Hide,
+	}})
+}
+
+// setFeatureTags replaces the feature tags for a service principal, whilst retaining any other tags
+func (r ServicePrincipalFeatureTagsResource) setFeatureTags(ctx context.Context, metadata sdk.ResourceMetaData, servicePrincipalId stable.ServicePrincipalId, featureTags []string) error {
+	client := metadata.Client.ServicePrincipals.ServicePrincipalClient
+
+	options := serviceprincipal.GetServicePrincipalOperationOptions{
+		Select: pointer.To([]string{"tags"}),
+	}
+
+	resp, err := client.GetServicePrincipal(ctx, servicePrincipalId, options)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", servicePrincipalId, err)
+	}
+	if resp.Model == nil {
+		return fmt.Errorf("retrieving %s: result was nil", servicePrincipalId)
+	}
+
+	// Feature tags are identified by expanding all features, so that other tags can be retained
+	allFeatureTags := applications.ExpandFeatures([]interface{}{map[string]interface{}{
+		"custom_single_sign_on": true,
+		"enterprise":            true,
+		"gallery":               true,
+		"hide":                  true,
+	}})
+
+	tags := make([]string, 0)
+	for _, tag := range pointer.From(resp.Model.Tags) {
+		isFeatureTag := false
+		for _, featureTag := range allFeatureTags {
+			if strings.EqualFold(tag, featureTag) {
+				isFeatureTag = true
+				break
+			}
+		}
+		if !isFeatureTag {
+			tags = append(tags, tag)
+		}
+	}
+	tags = append(tags, featureTags...)
+
+	properties := stable.ServicePrincipal{
+		Tags: &tags,
+	}
+
+	if _, err = client.UpdateServicePrincipal(ctx, servicePrincipalId, properties, serviceprincipal.DefaultUpdateServicePrincipalOperationOptions()); err != nil {
+		return err
+	}
+
+	return nil
+}
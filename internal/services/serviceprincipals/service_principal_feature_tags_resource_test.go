@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+)
+
+type ServicePrincipalFeatureTagsResource struct{}
+
+func TestAccServicePrincipalFeatureTags_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_feature_tags", "test")
+	r := ServicePrincipalFeatureTagsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("enterprise").HasValue("true"),
+				check.That(data.ResourceName).Key("hide").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccServicePrincipalFeatureTags_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_feature_tags", "test")
+	r := ServicePrincipalFeatureTagsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("custom_single_sign_on").HasValue("true"),
+				check.That(data.ResourceName).Key("gallery").HasValue("true"),
+				check.That(data.ResourceName).Key("hide").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ServicePrincipalFeatureTagsResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ServicePrincipals.ServicePrincipalClient
+
+	id, err := parse.ParseFeatureTagsID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	servicePrincipalId := stable.NewServicePrincipalID(id.ServicePrincipalId)
+
+	resp, err := client.GetServicePrincipal(ctx, servicePrincipalId, serviceprincipal.DefaultGetServicePrincipalOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", servicePrincipalId, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (ServicePrincipalFeatureTagsResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application_registration" "test" {
+  display_name = "acctest-SpFeatureTags-%[1]d"
+}
+`, data.RandomInteger)
+}
+
+func (r ServicePrincipalFeatureTagsResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application_registration.test.client_id
+
+  lifecycle {
+    ignore_changes = [
+      feature_tags,
+      tags,
+    ]
+  }
+}
+
+resource "azuread_service_principal_feature_tags" "test" {
+  service_principal_id = azuread_service_principal.test.id
+  enterprise           = true
+  hide                 = true
+}
+`, r.template(data))
+}
+
+func (r ServicePrincipalFeatureTagsResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application_registration.test.client_id
+
+  lifecycle {
+    ignore_changes = [
+      feature_tags,
+      tags,
+    ]
+  }
+}
+
+resource "azuread_service_principal_feature_tags" "test" {
+  service_principal_id  = azuread_service_principal.test.id
+  custom_single_sign_on = true
+  enterprise            = true
+  gallery               = true
+  hide                  = false
+}
+`, r.template(data))
+}
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/owner"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+)
+
+type ServicePrincipalOwnerModel struct {
+	ServicePrincipalId string `tfschema:"service_principal_id"`
+	OwnerObjectId      string `tfschema:"owner_object_id"`
+}
+
+var _ sdk.Resource = ServicePrincipalOwnerResource{}
+
+type ServicePrincipalOwnerResource struct{}
+
+func (r ServicePrincipalOwnerResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return stable.ValidateServicePrincipalIdOwnerID
+}
+
+func (r ServicePrincipalOwnerResource) ResourceType() string {
+	return "azuread_service_principal_owner"
+}
+
+func (r ServicePrincipalOwnerResource) ModelObject() interface{} {
+	return &ServicePrincipalOwnerModel{}
+}
+
+func (r ServicePrincipalOwnerResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"service_principal_id": {
+			Description:  "The resource ID of the service principal to which the owner should be added",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: stable.ValidateServicePrincipalID,
+		},
+
+		"owner_object_id": {
+			Description:  "Object ID of the principal that will be granted ownership of the service principal",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+	}
+}
+
+func (r ServicePrincipalOwnerResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r ServicePrincipalOwnerResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ServicePrincipals.ServicePrincipalOwnerClient
+
+			var model ServicePrincipalOwnerModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			servicePrincipalId, err := stable.ParseServicePrincipalID(model.ServicePrincipalId)
+			if err != nil {
+				return err
+			}
+
+			id := stable.NewServicePrincipalIdOwnerID(servicePrincipalId.ServicePrincipalId, model.OwnerObjectId)
+
+			tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+			defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+			o, err := getServicePrincipalOwner(ctx, client, id)
+			if err != nil {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if o != nil {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			properties := stable.ReferenceCreate{
+				ODataId: pointer.To(client.Client.BaseUri + stable.NewDirectoryObjectID(id.DirectoryObjectId).ID()),
+			}
+
+			options := owner.AddOwnerRefOperationOptions{
+				RetryFunc: func(resp *http.Response, _ *odata.OData) (bool, error) {
+					if response.WasNotFound(resp) {
+						return true, nil
+					}
+					return false, nil
+				},
+			}
+
+			if _, err = client.AddOwnerRef(ctx, *servicePrincipalId, properties, options); err != nil {
+				return fmt.Errorf("adding %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ServicePrincipalOwnerResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ServicePrincipals.ServicePrincipalOwnerClient
+
+			id, err := stable.ParseServicePrincipalIdOwnerID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			o, err := getServicePrincipalOwner(ctx, client, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if o == nil {
+				return metadata.MarkAsGone(id)
+			}
+
+			state := ServicePrincipalOwnerModel{
+				ServicePrincipalId: stable.NewServicePrincipalID(id.ServicePrincipalId).ID(),
+				OwnerObjectId:      id.DirectoryObjectId,
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ServicePrincipalOwnerResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ServicePrincipals.ServicePrincipalOwnerClient
+
+			id, err := stable.ParseServicePrincipalIdOwnerID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+			defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+			if _, err = client.RemoveOwnerRef(ctx, *id, owner.DefaultRemoveOwnerRefOperationOptions()); err != nil {
+				return fmt.Errorf("removing %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/owner"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+type ServicePrincipalOwnerResource struct{}
+
+func TestAccServicePrincipalOwner_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_owner", "test")
+	r := ServicePrincipalOwnerResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("service_principal_id").Exists(),
+				check.That(data.ResourceName).Key("owner_object_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccServicePrincipalOwner_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_owner", "test")
+	r := ServicePrincipalOwnerResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r ServicePrincipalOwnerResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ServicePrincipals.ServicePrincipalOwnerClient
+
+	id, err := stable.ParseServicePrincipalIdOwnerID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := owner.ListOwnersOperationOptions{
+		Filter: pointer.To(fmt.Sprintf("id eq '%s'", id.DirectoryObjectId)),
+	}
+
+	resp, err := client.ListOwners(ctx, stable.NewServicePrincipalID(id.ServicePrincipalId), options)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	if resp.Model != nil {
+		for _, o := range *resp.Model {
+			if strings.EqualFold(pointer.From(o.DirectoryObject().Id), id.DirectoryObjectId) {
+				return pointer.To(true), nil
+			}
+		}
+	}
+
+	return pointer.To(false), nil
+}
+
+func (ServicePrincipalOwnerResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_application_registration" "test" {
+  display_name = "acctest-SpOwner-%[1]d"
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestSpOwner.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestSpOwner-%[1]d"
+  password            = "%[2]s"
+}
+`, data.RandomInteger, data.RandomPassword)
+}
+
+func (r ServicePrincipalOwnerResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application_registration.test.client_id
+
+  lifecycle {
+    ignore_changes = [
+      owners,
+    ]
+  }
+}
+
+resource "azuread_service_principal_owner" "test" {
+  service_principal_id = azuread_service_principal.test.id
+  owner_object_id      = azuread_user.test.object_id
+}
+`, r.template(data))
+}
+
+func (r ServicePrincipalOwnerResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_owner" "import" {
+  service_principal_id = azuread_service_principal_owner.test.service_principal_id
+  owner_object_id      = azuread_service_principal_owner.test.owner_object_id
+}
+`, r.basic(data))
+}
@@ -5,7 +5,6 @@ package serviceprincipals
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"log"
 	"time"
@@ -217,14 +216,12 @@ func servicePrincipalPasswordResourceRead(ctx context.Context, d *pluginsdk.Reso
 		return nil
 	}
 
-	if credential.DisplayName != nil {
-		tf.Set(d, "display_name", credential.DisplayName.GetOrZero())
-	} else if !credential.CustomKeyIdentifier.IsNull() {
-		displayName, err := base64.StdEncoding.DecodeString(credential.CustomKeyIdentifier.GetOrZero())
-		if err != nil {
-			return tf.ErrorDiagPathF(err, "display_name", "Parsing CustomKeyIdentifier")
-		}
-		tf.Set(d, "display_name", string(displayName))
+	displayName, err := credentials.PasswordCredentialDisplayName(*credential)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "display_name", "Parsing display name for password credential %q", id.KeyId)
+	}
+	if displayName != nil {
+		tf.Set(d, "display_name", *displayName)
 	}
 
 	tf.Set(d, "key_id", id.KeyId)
@@ -248,6 +248,30 @@ func TestAccServicePrincipal_owners(t *testing.T) {
 	})
 }
 
+func TestAccServicePrincipal_ownersOmitted(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal", "test")
+	r := ServicePrincipalResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.singleOwner(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("owners.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.ownersOmitted(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("owners.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccServicePrincipal_createWithNoOwners(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_service_principal", "test")
 	r := ServicePrincipalResource{}
@@ -557,6 +581,20 @@ resource "azuread_service_principal" "test" {
 `, r.templateThreeUsers(data), data.RandomInteger)
 }
 
+func (r ServicePrincipalResource) ownersOmitted(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application" "test" {
+  display_name = "acctestServicePrincipal-%[2]d"
+}
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application.test.client_id
+}
+`, r.templateThreeUsers(data), data.RandomInteger)
+}
+
 func (r ServicePrincipalResource) singleOwner(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
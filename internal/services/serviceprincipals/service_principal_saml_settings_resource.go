@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+)
+
+type ServicePrincipalSamlSettingsModel struct {
+	ServicePrincipalId         string   `tfschema:"service_principal_id"`
+	NotificationEmailAddresses []string `tfschema:"notification_email_addresses"`
+	RelayState                 string   `tfschema:"relay_state"`
+}
+
+var (
+	_ sdk.Resource           = ServicePrincipalSamlSettingsResource{}
+	_ sdk.ResourceWithUpdate = ServicePrincipalSamlSettingsResource{}
+)
+
+type ServicePrincipalSamlSettingsResource struct{}
+
+func (r ServicePrincipalSamlSettingsResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateSamlSettingsID
+}
+
+func (r ServicePrincipalSamlSettingsResource) ResourceType() string {
+	return "azuread_service_principal_saml_settings"
+}
+
+func (r ServicePrincipalSamlSettingsResource) ModelObject() interface{} {
+	return &ServicePrincipalSamlSettingsModel{}
+}
+
+func (r ServicePrincipalSamlSettingsResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"service_principal_id": {
+			Description:  "The resource ID of the service principal for which to manage SAML settings",
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: stable.ValidateServicePrincipalID,
+		},
+
+		"notification_email_addresses": {
+			Description: "List of email addresses where Azure AD sends a notification when the active certificate is near the expiration date. This is only for the certificates used to sign the SAML token issued for Azure AD Gallery applications",
+			Type:        pluginsdk.TypeSet,
+			Optional:    true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"relay_state": {
+			Description:  "The relative URI the service provider would redirect to after completion of the single sign-on flow",
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+	}
+}
+
+func (r ServicePrincipalSamlSettingsResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r ServicePrincipalSamlSettingsResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ServicePrincipals.ServicePrincipalClient
+
+			var model ServicePrincipalSamlSettingsModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			servicePrincipalId, err := stable.ParseServicePrincipalID(model.ServicePrincipalId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewSamlSettingsID(servicePrincipalId.ServicePrincipalId)
+
+			tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+			defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+			if _, err = client.UpdateServicePrincipal(ctx, *servicePrincipalId, r.expandSamlSettings(model), serviceprincipal.DefaultUpdateServicePrincipalOperationOptions()); err != nil {
+				return fmt.Errorf("setting %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ServicePrincipalSamlSettingsResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ServicePrincipals.ServicePrincipalClient
+
+			id, err := parse.ParseSamlSettingsID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			servicePrincipalId := stable.NewServicePrincipalID(id.ServicePrincipalId)
+
+			options := serviceprincipal.GetServicePrincipalOperationOptions{
+				Select: pointer.To([]string{"notificationEmailAddresses", "samlSingleSignOnSettings"}),
+			}
+
+			resp, err := client.GetServicePrincipal(ctx, servicePrincipalId, options)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if resp.Model == nil {
+				return fmt.Errorf("retrieving %s: result was nil", id)
+			}
+
+			state := ServicePrincipalSamlSettingsModel{
+				ServicePrincipalId:         servicePrincipalId.ID(),
+				NotificationEmailAddresses: pointer.From(resp.Model.NotificationEmailAddresses),
+			}
+
+			if resp.Model.SamlSingleSignOnSettings != nil {
+				state.RelayState = resp.Model.SamlSingleSignOnSettings.RelayState.GetOrZero()
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ServicePrincipalSamlSettingsResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ServicePrincipals.ServicePrincipalClient
+
+			id, err := parse.ParseSamlSettingsID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model ServicePrincipalSamlSettingsModel
+			if err = metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+			defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+			if _, err = client.UpdateServicePrincipal(ctx, stable.NewServicePrincipalID(id.ServicePrincipalId), r.expandSamlSettings(model), serviceprincipal.DefaultUpdateServicePrincipalOperationOptions()); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ServicePrincipalSamlSettingsResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ServicePrincipals.ServicePrincipalClient
+
+			id, err := parse.ParseSamlSettingsID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+			defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+			properties := r.expandSamlSettings(ServicePrincipalSamlSettingsModel{})
+
+			if _, err = client.UpdateServicePrincipal(ctx, stable.NewServicePrincipalID(id.ServicePrincipalId), properties, serviceprincipal.DefaultUpdateServicePrincipalOperationOptions()); err != nil {
+				return fmt.Errorf("removing %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ServicePrincipalSamlSettingsResource) expandSamlSettings(model ServicePrincipalSamlSettingsModel) stable.ServicePrincipal {
+	notificationEmailAddresses := make([]string, 0)
+	if model.NotificationEmailAddresses != nil {
+		notificationEmailAddresses = model.NotificationEmailAddresses
+	}
+
+	result := stable.ServicePrincipal{
+		NotificationEmailAddresses: &notificationEmailAddresses,
+		SamlSingleSignOnSettings:   &stable.SamlSingleSignOnSettings{},
+	}
+
+	if model.RelayState != "" {
+		result.SamlSingleSignOnSettings.RelayState = nullable.Value(model.RelayState)
+	} else {
+		result.SamlSingleSignOnSettings.RelayState.SetNull()
+	}
+
+	return result
+}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceprincipals_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+)
+
+type ServicePrincipalSamlSettingsResource struct{}
+
+func TestAccServicePrincipalSamlSettings_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_saml_settings", "test")
+	r := ServicePrincipalSamlSettingsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("relay_state").HasValue("/samlHome"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccServicePrincipalSamlSettings_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_saml_settings", "test")
+	r := ServicePrincipalSamlSettingsResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("notification_email_addresses.#").HasValue("2"),
+				check.That(data.ResourceName).Key("relay_state").HasValue("/samlIndex"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("notification_email_addresses.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ServicePrincipalSamlSettingsResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ServicePrincipals.ServicePrincipalClient
+
+	id, err := parse.ParseSamlSettingsID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	servicePrincipalId := stable.NewServicePrincipalID(id.ServicePrincipalId)
+
+	resp, err := client.GetServicePrincipal(ctx, servicePrincipalId, serviceprincipal.DefaultGetServicePrincipalOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", servicePrincipalId, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (ServicePrincipalSamlSettingsResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application_registration" "test" {
+  display_name = "acctest-SpSamlSettings-%[1]d"
+}
+
+resource "azuread_service_principal" "test" {
+  client_id                     = azuread_application_registration.test.client_id
+  preferred_single_sign_on_mode = "saml"
+
+  lifecycle {
+    ignore_changes = [
+      notification_email_addresses,
+      saml_single_sign_on,
+    ]
+  }
+}
+`, data.RandomInteger)
+}
+
+func (r ServicePrincipalSamlSettingsResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_saml_settings" "test" {
+  service_principal_id = azuread_service_principal.test.id
+  relay_state          = "/samlHome"
+}
+`, r.template(data))
+}
+
+func (r ServicePrincipalSamlSettingsResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_saml_settings" "test" {
+  service_principal_id = azuread_service_principal.test.id
+  relay_state          = "/samlIndex"
+
+  notification_email_addresses = [
+    "alerts.%[2]d@contoso.com",
+    "cert-alerts.%[2]d@contoso.com",
+  ]
+}
+`, r.template(data), data.RandomInteger)
+}
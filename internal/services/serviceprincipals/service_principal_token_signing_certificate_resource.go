@@ -30,11 +30,15 @@ func servicePrincipalTokenSigningCertificateResource() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		CreateContext: servicePrincipalTokenSigningCertificateResourceCreate,
 		ReadContext:   servicePrincipalTokenSigningCertificateResourceRead,
+		UpdateContext: servicePrincipalTokenSigningCertificateResourceUpdate,
 		DeleteContext: servicePrincipalTokenSigningCertificateResourceDelete,
 
+		CustomizeDiff: servicePrincipalTokenSigningCertificateResourceCustomizeDiff,
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
 			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
 		},
 
@@ -62,12 +66,52 @@ func servicePrincipalTokenSigningCertificateResource() *pluginsdk.Resource {
 			},
 
 			"end_date": {
-				Description:  "The end date until which the certificate is valid, formatted as an RFC3339 date string (e.g. `2018-01-01T01:02:03Z`). Default is 3 years from current date.",
-				Type:         pluginsdk.TypeString,
-				Optional:     true,
-				Computed:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.IsRFC3339Time,
+				Description:   "The end date until which the certificate is valid, formatted as an RFC3339 date string (e.g. `2018-01-01T01:02:03Z`). Default is 3 years from current date.",
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"rotation"},
+				ValidateFunc:  validation.IsRFC3339Time,
+			},
+
+			"rotation": {
+				Description: "Manage rotation of the certificate, by generating a new certificate before the current certificate expires",
+				Type:        pluginsdk.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"overlap_days": {
+							Description:  "The number of days for which the previous certificate remains valid after rotation",
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      7,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
+						"rotate_before_days": {
+							Description:  "The number of days before the certificate expires, from which a new certificate is generated",
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      30,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"validity_days": {
+							Description:  "The number of days for which generated certificates are valid",
+							Type:         pluginsdk.TypeInt,
+							Optional:     true,
+							Default:      1095,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+
+			"certificate_pem": {
+				Description: "The PEM encoded certificate, including the header/footer",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
 			},
 
 			"key_id": {
@@ -76,6 +120,24 @@ func servicePrincipalTokenSigningCertificateResource() *pluginsdk.Resource {
 				Computed:    true,
 			},
 
+			"previous_key_id": {
+				Description: "The key ID of the previous verify certificate, which remains valid until `previous_key_removal_date`",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"previous_key_removal_date": {
+				Description: "The date from which the previous certificate will be removed, formatted as an RFC3339 date string",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
+			"rotation_date": {
+				Description: "The date from which the certificate will be rotated, formatted as an RFC3339 date string",
+				Type:        pluginsdk.TypeString,
+				Computed:    true,
+			},
+
 			"thumbprint": {
 				Description: "The thumbprint of the certificate.",
 				Type:        pluginsdk.TypeString,
@@ -98,6 +160,35 @@ func servicePrincipalTokenSigningCertificateResource() *pluginsdk.Resource {
 	}
 }
 
+func servicePrincipalTokenSigningCertificateResourceCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	rotation := diff.Get("rotation").([]interface{})
+
+	if len(rotation) > 0 && rotation[0] != nil {
+		in := rotation[0].(map[string]interface{})
+		if in["rotate_before_days"].(int) >= in["validity_days"].(int) {
+			return fmt.Errorf("`rotation.0.rotate_before_days` must be less than `rotation.0.validity_days`")
+		}
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	// Enabling or disabling rotation requires a new certificate
+	if oldRotation, _ := diff.GetChange("rotation"); len(oldRotation.([]interface{})) != len(rotation) {
+		return diff.ForceNew("rotation")
+	}
+
+	if len(rotation) == 0 {
+		if diff.HasChange("end_date") {
+			return diff.ForceNew("end_date")
+		}
+		return nil
+	}
+
+	return credentials.RotationCustomizeDiff(diff, credentials.RotationDue(diff), "certificate_pem", "thumbprint", "value")
+}
+
 func servicePrincipalTokenSigningCertificateResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalClient
 
@@ -114,73 +205,16 @@ func servicePrincipalTokenSigningCertificateResourceCreate(ctx context.Context,
 
 	if v, ok := d.GetOk("end_date"); ok {
 		properties.EndDateTime = nullable.NoZero(v.(string))
+	} else if v := d.Get("rotation").([]interface{}); len(v) > 0 {
+		properties.EndDateTime = nullable.Value(time.Now().UTC().AddDate(0, 0, d.Get("rotation.0.validity_days").(int)).Format(time.RFC3339))
 	}
 
 	tf.LockByName(servicePrincipalResourceName, servicePrincipalId.ServicePrincipalId)
 	defer tf.UnlockByName(servicePrincipalResourceName, servicePrincipalId.ServicePrincipalId)
 
-	resp, err := client.AddTokenSigningCertificate(ctx, *servicePrincipalId, properties, serviceprincipal.DefaultAddTokenSigningCertificateOperationOptions())
-	if err != nil {
-		return tf.ErrorDiagF(err, "Could not add token signing certificate to %s", servicePrincipalId)
-	}
-
-	key := resp.Model
-	if key == nil {
-		return tf.ErrorDiagF(errors.New("model was nil"), "API error adding token signing certificate for %s", servicePrincipalId)
-	}
-
-	// Wait for the credential to appear in the service principal manifest, this can take several minutes
-	timeout, _ := ctx.Deadline()
-	polledForCredential, err := (&pluginsdk.StateChangeConf{ //nolint:staticcheck
-		Pending:                   []string{"Waiting"},
-		Target:                    []string{"Done"},
-		Timeout:                   time.Until(timeout),
-		MinTimeout:                1 * time.Second,
-		ContinuousTargetOccurence: 5,
-		Refresh: func() (interface{}, string, error) {
-			resp, err := client.GetServicePrincipal(ctx, *servicePrincipalId, serviceprincipal.DefaultGetServicePrincipalOperationOptions())
-			if err != nil {
-				return nil, "Error", err
-			}
-
-			servicePrincipal := resp.Model
-			if servicePrincipal == nil {
-				return nil, "Error", errors.New("model was nil")
-			}
-
-			if servicePrincipal.KeyCredentials != nil {
-				for _, cred := range *servicePrincipal.KeyCredentials {
-					if strings.EqualFold(cred.KeyId.GetOrZero(), key.KeyId.GetOrZero()) {
-						return &cred, "Done", nil
-					}
-				}
-			}
-
-			return nil, "Waiting", nil
-		},
-	}).WaitForStateContext(ctx)
-
-	if err != nil {
-		return tf.ErrorDiagF(err, "Waiting for token signing certificate credential for %s", servicePrincipalId)
-	} else if polledForCredential == nil {
-		return tf.ErrorDiagF(errors.New("certificate credential not found in service principal manifest"), "Waiting for certificate credential for %s", servicePrincipalId)
-	}
-
-	// Workaround b/c the returned keyId is for the Sign key, rather than Verify key,
-	// so we need to get the Verify keyId based on the customKeyIdentifier
-	servicePrincipalResponse, err := client.GetServicePrincipal(ctx, *servicePrincipalId, serviceprincipal.DefaultGetServicePrincipalOperationOptions())
+	credential, err := addServicePrincipalTokenSigningCertificate(ctx, client, *servicePrincipalId, properties)
 	if err != nil {
-		return tf.ErrorDiagF(err, "Retrieving %s", servicePrincipalId)
-	}
-
-	servicePrincipal := servicePrincipalResponse.Model
-	if servicePrincipal == nil {
-		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", servicePrincipalId)
-	}
-
-	credential := credentials.GetVerifyKeyCredentialFromCustomKeyId(servicePrincipal.KeyCredentials, key.CustomKeyIdentifier.GetOrZero())
-	if credential == nil {
-		return tf.ErrorDiagF(errors.New("returned credential was nil"), "Could not determine key ID for newly added token signing certificate for %s", servicePrincipalId)
+		return tf.ErrorDiagF(err, "Adding token signing certificate for %s", servicePrincipalId)
 	}
 
 	id := parse.NewCredentialID(servicePrincipalId.ServicePrincipalId, "tokenSigningCertificate", credential.KeyId.GetOrZero())
@@ -232,19 +266,124 @@ func servicePrincipalTokenSigningCertificateResourceRead(ctx context.Context, d
 	tf.Set(d, "end_date", credential.EndDateTime.GetOrZero())
 
 	// thumbprint not available when querying service principal, so we generate it from the pem value in the Key field.
-	var thumbprint string
+	var certificatePem, thumbprint string
 	if credential.Key != nil {
-		thumbprint, err = credentials.GetTokenSigningCertificateThumbprint(
-			[]byte("-----BEGIN CERTIFICATE-----\n" + credential.Key.GetOrZero() + "\n-----END CERTIFICATE-----"))
+		certificatePem = tokenSigningCertificatePem(credential.Key.GetOrZero())
+		thumbprint, err = credentials.GetTokenSigningCertificateThumbprint([]byte(certificatePem))
 		if err != nil {
 			return tf.ErrorDiagPathF(err, "id", "parsing tokenSigningCertificate key value with ID %q", id.KeyId)
 		}
 	}
+	tf.Set(d, "certificate_pem", certificatePem)
 	tf.Set(d, "thumbprint", thumbprint)
 
+	if rotation := d.Get("rotation").([]interface{}); len(rotation) > 0 && rotation[0] != nil {
+		if previousKeyId := d.Get("previous_key_id").(string); previousKeyId != "" && credentials.GetKeyCredential(servicePrincipal.KeyCredentials, previousKeyId) == nil {
+			log.Printf("[DEBUG] Previous certificate credential %q (ID %q) was not found - removing from state", previousKeyId, id.ObjectId)
+			tf.Set(d, "previous_key_id", "")
+			tf.Set(d, "previous_key_removal_date", "")
+		}
+
+		rotationDate, err := credentials.RotationDate(credential.EndDateTime.GetOrZero(), rotation[0].(map[string]interface{})["rotate_before_days"].(int))
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "end_date", "Computing rotation date for token signing certificate credential %q", id.KeyId)
+		}
+		tf.Set(d, "rotation_date", rotationDate)
+	}
+
 	return nil
 }
 
+func servicePrincipalTokenSigningCertificateResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalClient
+
+	id, err := parse.SigningCertificateID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing certificate credential with ID %q", d.Id())
+	}
+
+	// A change to the key ID is only planned when the certificate is being rotated
+	rotate := d.HasChange("key_id")
+	oldPreviousKeyId, _ := d.GetChange("previous_key_id")
+	previousKeyId := oldPreviousKeyId.(string)
+	removePrevious := previousKeyId != "" && (rotate || d.Get("previous_key_id").(string) == "")
+
+	if !rotate && !removePrevious {
+		return servicePrincipalTokenSigningCertificateResourceRead(ctx, d, meta)
+	}
+
+	servicePrincipalId := stable.NewServicePrincipalID(id.ObjectId)
+
+	tf.LockByName(servicePrincipalResourceName, servicePrincipalId.ServicePrincipalId)
+	defer tf.UnlockByName(servicePrincipalResourceName, servicePrincipalId.ServicePrincipalId)
+
+	var credential *stable.KeyCredential
+	if rotate {
+		properties := serviceprincipal.AddTokenSigningCertificateRequest{
+			DisplayName: nullable.NoZero(d.Get("display_name").(string)),
+			EndDateTime: nullable.Value(time.Now().UTC().AddDate(0, 0, d.Get("rotation.0.validity_days").(int)).Format(time.RFC3339)),
+		}
+
+		if credential, err = addServicePrincipalTokenSigningCertificate(ctx, client, servicePrincipalId, properties); err != nil {
+			return tf.ErrorDiagF(err, "Rotating token signing certificate %q for %s", id.KeyId, servicePrincipalId)
+		}
+	}
+
+	if removePrevious {
+		resp, err := client.GetServicePrincipal(ctx, servicePrincipalId, serviceprincipal.DefaultGetServicePrincipalOperationOptions())
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "service_principal_id", "Retrieving %s", servicePrincipalId)
+		}
+
+		servicePrincipal := resp.Model
+		if servicePrincipal == nil {
+			return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", servicePrincipalId)
+		}
+
+		newKeyCredentials, newPasswordCredentials := servicePrincipalCredentialsWithoutTokenSigningCertificates(servicePrincipal, previousKeyId)
+		properties := stable.ServicePrincipal{
+			KeyCredentials:      &newKeyCredentials,
+			PasswordCredentials: &newPasswordCredentials,
+		}
+
+		// When the previous certificate is preferred for signing tokens, the certificate which replaced it becomes preferred
+		if preferred := servicePrincipal.PreferredTokenSigningKeyThumbprint.GetOrZero(); preferred != "" {
+			previousThumbprint, err := tokenSigningCertificateThumbprint(credentials.GetKeyCredential(servicePrincipal.KeyCredentials, previousKeyId))
+			if err != nil {
+				return tf.ErrorDiagF(err, "Parsing previous token signing certificate %q for %s", previousKeyId, servicePrincipalId)
+			}
+
+			if strings.EqualFold(preferred, previousThumbprint) {
+				thumbprint, err := tokenSigningCertificateThumbprint(credentials.GetKeyCredential(servicePrincipal.KeyCredentials, id.KeyId))
+				if err != nil {
+					return tf.ErrorDiagF(err, "Parsing token signing certificate %q for %s", id.KeyId, servicePrincipalId)
+				}
+				properties.PreferredTokenSigningKeyThumbprint = nullable.NoZero(thumbprint)
+			}
+		}
+
+		if _, err = client.UpdateServicePrincipal(ctx, servicePrincipalId, properties, serviceprincipal.DefaultUpdateServicePrincipalOperationOptions()); err != nil {
+			return tf.ErrorDiagF(err, "Removing previous token signing certificate %q from %s", previousKeyId, servicePrincipalId)
+		}
+	}
+
+	if !rotate {
+		tf.Set(d, "previous_key_id", "")
+		tf.Set(d, "previous_key_removal_date", "")
+		return servicePrincipalTokenSigningCertificateResourceRead(ctx, d, meta)
+	}
+
+	// The current certificate remains valid for the overlap period, after which it is removed on the next apply
+	overlapDays := d.Get("rotation.0.overlap_days").(int)
+	tf.Set(d, "previous_key_id", id.KeyId)
+	tf.Set(d, "previous_key_removal_date", time.Now().UTC().AddDate(0, 0, overlapDays).Format(time.RFC3339))
+
+	newId := parse.NewCredentialID(servicePrincipalId.ServicePrincipalId, "tokenSigningCertificate", credential.KeyId.GetOrZero())
+	d.SetId(newId.String())
+
+	return servicePrincipalTokenSigningCertificateResourceRead(ctx, d, meta)
+}
+
 func servicePrincipalTokenSigningCertificateResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
 	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalClient
 
@@ -271,30 +410,13 @@ func servicePrincipalTokenSigningCertificateResourceDelete(ctx context.Context,
 		return tf.ErrorDiagF(errors.New("model was nil"), "Retrieving %s", servicePrincipalId)
 	}
 
-	// use CustomKeyIdentifier to determine which certs and passwords are associated
-	customKeyId := ""
-	newKeyCredentials := make([]stable.KeyCredential, 0)
-	if servicePrincipal.KeyCredentials != nil {
-		for _, cred := range *servicePrincipal.KeyCredentials {
-			if !strings.EqualFold(cred.KeyId.GetOrZero(), id.KeyId) {
-				customKeyId = cred.CustomKeyIdentifier.GetOrZero()
-			}
-		}
-		for _, cred := range *servicePrincipal.KeyCredentials {
-			if !strings.EqualFold(cred.CustomKeyIdentifier.GetOrZero(), customKeyId) {
-				newKeyCredentials = append(newKeyCredentials, cred)
-			}
-		}
+	// The previous certificate is also removed when it has not yet reached the end of its overlap period
+	keyIds := []string{id.KeyId}
+	if previousKeyId := d.Get("previous_key_id").(string); previousKeyId != "" {
+		keyIds = append(keyIds, previousKeyId)
 	}
 
-	newPasswordCredentials := make([]stable.PasswordCredential, 0)
-	if servicePrincipal.PasswordCredentials != nil {
-		for _, cred := range *servicePrincipal.PasswordCredentials {
-			if !strings.EqualFold(cred.CustomKeyIdentifier.GetOrZero(), customKeyId) {
-				newPasswordCredentials = append(newPasswordCredentials, cred)
-			}
-		}
-	}
+	newKeyCredentials, newPasswordCredentials := servicePrincipalCredentialsWithoutTokenSigningCertificates(servicePrincipal, keyIds...)
 
 	properties := stable.ServicePrincipal{
 		KeyCredentials:      &newKeyCredentials,
@@ -324,3 +446,130 @@ func servicePrincipalTokenSigningCertificateResourceDelete(ctx context.Context,
 
 	return nil
 }
+
+// addServicePrincipalTokenSigningCertificate adds a token signing certificate to a service principal, waits for it to
+// appear in the service principal manifest, and returns the Verify key credential for the certificate
+func addServicePrincipalTokenSigningCertificate(ctx context.Context, client *serviceprincipal.ServicePrincipalClient, servicePrincipalId stable.ServicePrincipalId, properties serviceprincipal.AddTokenSigningCertificateRequest) (*stable.KeyCredential, error) {
+	resp, err := client.AddTokenSigningCertificate(ctx, servicePrincipalId, properties, serviceprincipal.DefaultAddTokenSigningCertificateOperationOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	key := resp.Model
+	if key == nil {
+		return nil, errors.New("model was nil")
+	}
+
+	// Wait for the credential to appear in the service principal manifest, this can take several minutes
+	timeout, _ := ctx.Deadline()
+	polledForCredential, err := (&pluginsdk.StateChangeConf{ //nolint:staticcheck
+		Pending:                   []string{"Waiting"},
+		Target:                    []string{"Done"},
+		Timeout:                   time.Until(timeout),
+		MinTimeout:                1 * time.Second,
+		ContinuousTargetOccurence: 5,
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.GetServicePrincipal(ctx, servicePrincipalId, serviceprincipal.DefaultGetServicePrincipalOperationOptions())
+			if err != nil {
+				return nil, "Error", err
+			}
+
+			servicePrincipal := resp.Model
+			if servicePrincipal == nil {
+				return nil, "Error", errors.New("model was nil")
+			}
+
+			if cred := credentials.GetKeyCredential(servicePrincipal.KeyCredentials, key.KeyId.GetOrZero()); cred != nil {
+				return cred, "Done", nil
+			}
+
+			return nil, "Waiting", nil
+		},
+	}).WaitForStateContext(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("waiting for token signing certificate credential: %+v", err)
+	} else if polledForCredential == nil {
+		return nil, errors.New("certificate credential not found in service principal manifest")
+	}
+
+	// Workaround b/c the returned keyId is for the Sign key, rather than Verify key,
+	// so we need to get the Verify keyId based on the customKeyIdentifier
+	servicePrincipalResponse, err := client.GetServicePrincipal(ctx, servicePrincipalId, serviceprincipal.DefaultGetServicePrincipalOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", servicePrincipalId, err)
+	}
+
+	servicePrincipal := servicePrincipalResponse.Model
+	if servicePrincipal == nil {
+		return nil, fmt.Errorf("retrieving %s: model was nil", servicePrincipalId)
+	}
+
+	credential := credentials.GetVerifyKeyCredentialFromCustomKeyId(servicePrincipal.KeyCredentials, key.CustomKeyIdentifier.GetOrZero())
+	if credential == nil {
+		return nil, errors.New("could not determine key ID for newly added token signing certificate")
+	}
+
+	return credential, nil
+}
+
+// servicePrincipalCredentialsWithoutTokenSigningCertificates returns the key and password credentials for a service
+// principal, excluding those belonging to the token signing certificates with the specified Verify key IDs. The Sign and
+// Verify keys, and the password, for a certificate are associated using their CustomKeyIdentifier.
+func servicePrincipalCredentialsWithoutTokenSigningCertificates(servicePrincipal *stable.ServicePrincipal, keyIds ...string) ([]stable.KeyCredential, []stable.PasswordCredential) {
+	customKeyIds := make([]string, 0)
+	for _, keyId := range keyIds {
+		if cred := credentials.GetKeyCredential(servicePrincipal.KeyCredentials, keyId); cred != nil && cred.CustomKeyIdentifier.GetOrZero() != "" {
+			customKeyIds = append(customKeyIds, cred.CustomKeyIdentifier.GetOrZero())
+		}
+	}
+
+	removed := func(keyId, customKeyId string) bool {
+		for _, id := range keyIds {
+			if strings.EqualFold(keyId, id) {
+				return true
+			}
+		}
+		for _, id := range customKeyIds {
+			if strings.EqualFold(customKeyId, id) {
+				return true
+			}
+		}
+		return false
+	}
+
+	newKeyCredentials := make([]stable.KeyCredential, 0)
+	if servicePrincipal.KeyCredentials != nil {
+		for _, cred := range *servicePrincipal.KeyCredentials {
+			if !removed(cred.KeyId.GetOrZero(), cred.CustomKeyIdentifier.GetOrZero()) {
+				newKeyCredentials = append(newKeyCredentials, cred)
+			}
+		}
+	}
+
+	newPasswordCredentials := make([]stable.PasswordCredential, 0)
+	if servicePrincipal.PasswordCredentials != nil {
+		for _, cred := range *servicePrincipal.PasswordCredentials {
+			if !removed(cred.KeyId.GetOrZero(), cred.CustomKeyIdentifier.GetOrZero()) {
+				newPasswordCredentials = append(newPasswordCredentials, cred)
+			}
+		}
+	}
+
+	return newKeyCredentials, newPasswordCredentials
+}
+
+// tokenSigningCertificatePem returns the PEM encoding of the key for a token signing certificate, which is returned by
+// the API without the header/footer
+func tokenSigningCertificatePem(key string) string {
+	return "-----BEGIN CERTIFICATE-----\n" + key + "\n-----END CERTIFICATE-----"
+}
+
+// tokenSigningCertificateThumbprint returns the thumbprint of a token signing certificate, or an empty string when the
+// credential is nil or has no key
+func tokenSigningCertificateThumbprint(credential *stable.KeyCredential) (string, error) {
+	if credential == nil || credential.Key.GetOrZero() == "" {
+		return "", nil
+	}
+	return credentials.GetTokenSigningCertificateThumbprint([]byte(tokenSigningCertificatePem(credential.Key.GetOrZero())))
+}
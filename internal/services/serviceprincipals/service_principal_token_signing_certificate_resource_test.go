@@ -63,6 +63,33 @@ func TestAccServicePrincipalTokenSigningCertificate_complete(t *testing.T) {
 	})
 }
 
+func TestAccServicePrincipalTokenSigningCertificate_rotation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_token_signing_certificate", "test")
+	r := servicePrincipalTokenSigningCertificateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.rotation(data, 7),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("certificate_pem").Exists(),
+				check.That(data.ResourceName).Key("end_date").Exists(),
+				check.That(data.ResourceName).Key("key_id").Exists(),
+				check.That(data.ResourceName).Key("rotation_date").Exists(),
+				check.That(data.ResourceName).Key("thumbprint").Exists(),
+			),
+		},
+		{
+			Config: r.rotation(data, 14),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("previous_key_id").IsEmpty(),
+				check.That(data.ResourceName).Key("rotation_date").Exists(),
+			),
+		},
+	})
+}
+
 func (r servicePrincipalTokenSigningCertificateResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.ServicePrincipals.ServicePrincipalClient
 
@@ -125,3 +152,20 @@ resource "azuread_service_principal_token_signing_certificate" "test" {
 }
 `, r.template(data), data.RandomID, endDate)
 }
+
+func (r servicePrincipalTokenSigningCertificateResource) rotation(data acceptance.TestData, rotateBeforeDays int) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_token_signing_certificate" "test" {
+  service_principal_id = azuread_service_principal.test.id
+  display_name         = "CN=acctestTokenSigningCert-%[2]s"
+
+  rotation {
+    validity_days      = 90
+    rotate_before_days = %[3]d
+    overlap_days       = 3
+  }
+}
+`, r.template(data), data.RandomID, rotateBeforeDays)
+}
@@ -4,10 +4,44 @@
 package serviceprincipals
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/owner"
 	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
 )
 
+// getServicePrincipalOwner returns the owner of a service principal, or nil when the principal is not an owner
+func getServicePrincipalOwner(ctx context.Context, client *owner.OwnerClient, id stable.ServicePrincipalIdOwnerId) (stable.DirectoryObject, error) {
+	servicePrincipalId := stable.NewServicePrincipalID(id.ServicePrincipalId)
+
+	options := owner.ListOwnersOperationOptions{
+		Filter: pointer.To(fmt.Sprintf("id eq '%s'", id.DirectoryObjectId)),
+	}
+
+	resp, err := client.ListOwners(ctx, servicePrincipalId, options)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to list Owners with filter %q: %+v", *options.Filter, err)
+	}
+
+	if resp.Model != nil {
+		for _, o := range *resp.Model {
+			if o.DirectoryObject().Id != nil && strings.EqualFold(*o.DirectoryObject().Id, id.DirectoryObjectId) {
+				return o, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
 func expandSamlSingleSignOn(in []interface{}) *stable.SamlSingleSignOnSettings {
 	result := stable.SamlSingleSignOnSettings{}
 	if len(in) == 0 || in[0] == nil {